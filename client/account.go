@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/mr-tron/base58"
+	sdkcommon "github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+type (
+	// DataSlice limits the returned account data to Length bytes starting at Offset.
+	DataSlice = rpc.DataSlice
+
+	// AccountInfoOptions defines the options of fetching an account.
+	AccountInfoOptions struct {
+		Commitment rpc.Commitment      // optional; overrides the client default commitment
+		DataSlice  *DataSlice          // optional; returns only the given part of the account data
+		Encoding   rpc.AccountEncoding // optional; base64 (default), base58 or jsonParsed; base64+zstd is not supported
+	}
+
+	// AccountInfo represents an on-chain account.
+	AccountInfo struct {
+		Lamports   uint64              // account balance in lamports
+		Owner      sdkcommon.PublicKey // program which owns the account
+		Executable bool                // true if the account contains a program
+		RentEpoch  uint64              // epoch at which the account will next owe rent
+		Data       []byte              // raw account data; nil for jsonParsed encoding if the node parsed the data
+		Parsed     json.RawMessage     // account data parsed by the node; set only for jsonParsed encoding
+	}
+)
+
+// GetAccountInfo returns the account info of the given base58 encoded address.
+// Returns ErrAccountNotFound if the account does not exist.
+func (c *Client) GetAccountInfo(ctx context.Context, base58Addr string, opts AccountInfoOptions) (*AccountInfo, error) {
+	if err := common.ValidateAccountAddr(base58Addr); err != nil {
+		return nil, utils.StackErrors(ErrGetAccountInfo, err)
+	}
+
+	if opts.Encoding == "" {
+		opts.Encoding = rpc.AccountEncodingBase64
+	}
+	switch opts.Encoding {
+	case rpc.AccountEncodingBase64, rpc.AccountEncodingBase58, rpc.AccountEncodingJsonParsed:
+	default:
+		return nil, utils.StackErrors(ErrGetAccountInfo, fmt.Errorf("unsupported account encoding: %s", opts.Encoding))
+	}
+
+	result, err := rpcCall[rpc.ValueWithContext[*struct {
+		Lamports   uint64          `json:"lamports"`
+		Owner      string          `json:"owner"`
+		Executable bool            `json:"executable"`
+		RentEpoch  uint64          `json:"rentEpoch"`
+		Data       json.RawMessage `json:"data"`
+	}]](ctx, c, "getAccountInfo", base58Addr, rpc.GetAccountInfoConfig{
+		Encoding:   opts.Encoding,
+		Commitment: c.getCommitment([]rpc.Commitment{opts.Commitment}),
+		DataSlice:  opts.DataSlice,
+	})
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetAccountInfo, err)
+	}
+	if result.Value == nil {
+		return nil, utils.StackErrors(ErrGetAccountInfo, ErrAccountNotFound)
+	}
+
+	acc := &AccountInfo{
+		Lamports:   result.Value.Lamports,
+		Owner:      sdkcommon.PublicKeyFromString(result.Value.Owner),
+		Executable: result.Value.Executable,
+		RentEpoch:  result.Value.RentEpoch,
+	}
+
+	// the data is an object if the node parsed it, otherwise a [data, encoding] pair
+	var encoded []string
+	if err := json.Unmarshal(result.Value.Data, &encoded); err != nil {
+		if opts.Encoding != rpc.AccountEncodingJsonParsed {
+			return nil, utils.StackErrors(ErrGetAccountInfo, fmt.Errorf("unexpected account data format: %w", err))
+		}
+		acc.Parsed = result.Value.Data
+		return acc, nil
+	}
+	if acc.Data, err = decodeEncodedData(encoded); err != nil {
+		return nil, utils.StackErrors(ErrGetAccountInfo, err)
+	}
+
+	return acc, nil
+}
+
+// GetAccountData returns the raw data of the account with the given base58 encoded address.
+// Returns ErrAccountNotFound if the account does not exist.
+func (c *Client) GetAccountData(ctx context.Context, base58Addr string) ([]byte, error) {
+	acc, err := c.GetAccountInfo(ctx, base58Addr, AccountInfoOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return acc.Data, nil
+}
+
+// AccountExists returns true if the account with the given base58 encoded address exists.
+// The account data isn't fetched.
+func (c *Client) AccountExists(ctx context.Context, base58Addr string) (bool, error) {
+	_, err := c.GetAccountInfo(ctx, base58Addr, AccountInfoOptions{DataSlice: &DataSlice{Offset: 0, Length: 0}})
+	if errors.Is(err, ErrAccountNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// WaitForAccount waits for the account with the given base58 encoded address to be created,
+// e.g. a nonce or an associated token account, polling it every opts.PollInterval for up to opts.MaxDuration.
+// The account is read with opts.Commitment, if set, or the client default commitment.
+// If opts.ExpectedOwner is set, the account must also be owned by that program.
+// Returns nil once the account exists, ErrWaitForAccountTimeout if it doesn't appear within opts.MaxDuration
+// or an error.
+func (c *Client) WaitForAccount(ctx context.Context, base58Addr string, opts ConfirmOptions) error {
+	if err := common.ValidateAccountAddr(base58Addr); err != nil {
+		return utils.StackErrors(ErrWaitForAccount, err)
+	}
+	if opts.ExpectedOwner != "" {
+		if err := common.ValidateAccountAddr(opts.ExpectedOwner); err != nil {
+			return utils.StackErrors(ErrWaitForAccount, err)
+		}
+	}
+	if opts.Commitment != "" {
+		if _, ok := commitmentLevels[opts.Commitment]; !ok {
+			return utils.StackErrors(ErrWaitForAccount, fmt.Errorf("unsupported commitment: %s", opts.Commitment))
+		}
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.MaxDuration <= 0 {
+		opts.MaxDuration = 5 * time.Minute
+	}
+
+	// the account may already exist, so check it before waiting for the first tick
+	if ok, err := c.accountCreated(ctx, base58Addr, opts); err != nil || ok {
+		return err
+	}
+
+	tick, stop := c.clock.NewTicker(opts.PollInterval)
+	defer stop()
+	timeout := c.clock.After(opts.MaxDuration)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return utils.StackErrors(ErrWaitForAccount, ErrContextDone)
+		case <-timeout:
+			return utils.StackErrors(ErrWaitForAccount, ErrWaitForAccountTimeout)
+		case <-tick:
+			if ok, err := c.accountCreated(ctx, base58Addr, opts); err != nil || ok {
+				return err
+			}
+		}
+	}
+}
+
+// accountCreated checks whether the account with the given base58 encoded address exists
+// and is owned by opts.ExpectedOwner, if set.
+// Returns false if the account isn't created yet or an error.
+func (c *Client) accountCreated(ctx context.Context, base58Addr string, opts ConfirmOptions) (bool, error) {
+	acc, err := c.GetAccountInfo(ctx, base58Addr, AccountInfoOptions{
+		Commitment: opts.Commitment,
+		DataSlice:  &DataSlice{Offset: 0, Length: 0},
+	})
+	if errors.Is(err, ErrAccountNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, utils.StackErrors(ErrWaitForAccount, err)
+	}
+
+	// the account may be created by the system program before it's assigned to the expected one
+	return opts.ExpectedOwner == "" || acc.Owner.ToBase58() == opts.ExpectedOwner, nil
+}
+
+// decodeEncodedData decodes the [data, encoding] pair returned by the RPC node.
+func decodeEncodedData(encoded []string) ([]byte, error) {
+	if len(encoded) != 2 {
+		return nil, fmt.Errorf("unexpected account data format")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch rpc.AccountEncoding(encoded[1]) {
+	case rpc.AccountEncodingBase64:
+		data, err = base64.StdEncoding.DecodeString(encoded[0])
+	case rpc.AccountEncodingBase58:
+		data, err = base58.Decode(encoded[0])
+	default:
+		return nil, fmt.Errorf("unsupported account data encoding: %s", encoded[1])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode account data: %w", err)
+	}
+
+	return data, nil
+}
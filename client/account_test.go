@@ -0,0 +1,264 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountInfoResult returns a getAccountInfo handler which slices and encodes the data as requested.
+func accountInfoResult(t *testing.T, data []byte, cfgs *[]rpc.GetAccountInfoConfig) func(params []json.RawMessage) interface{} {
+	return func(params []json.RawMessage) interface{} {
+		var cfg rpc.GetAccountInfoConfig
+		require.NoError(t, json.Unmarshal(params[1], &cfg))
+		*cfgs = append(*cfgs, cfg)
+
+		raw := data
+		if cfg.DataSlice != nil {
+			raw = raw[cfg.DataSlice.Offset : cfg.DataSlice.Offset+cfg.DataSlice.Length]
+		}
+
+		var encoded interface{}
+		switch cfg.Encoding {
+		case rpc.AccountEncodingBase58:
+			encoded = []string{base58.Encode(raw), "base58"}
+		case rpc.AccountEncodingJsonParsed:
+			encoded = map[string]interface{}{"program": "spl-token", "parsed": map[string]interface{}{"type": "mint"}}
+		default:
+			encoded = []string{base64.StdEncoding.EncodeToString(raw), "base64"}
+		}
+
+		return map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value": map[string]interface{}{
+				"lamports":   1461600,
+				"owner":      common.TokenProgramID.ToBase58(),
+				"executable": false,
+				"rentEpoch":  361,
+				"data":       encoded,
+			},
+		}
+	}
+}
+
+func TestGetAccountInfo(t *testing.T) {
+	addr := types.NewAccount().PublicKey.ToBase58()
+	data := []byte("0123456789abcdef")
+
+	var cfgs []rpc.GetAccountInfoConfig
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": accountInfoResult(t, data, &cfgs),
+	})
+
+	acc, err := sc.GetAccountInfo(context.Background(), addr, client.AccountInfoOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1461600), acc.Lamports)
+	assert.Equal(t, common.TokenProgramID, acc.Owner)
+	assert.False(t, acc.Executable)
+	assert.Equal(t, uint64(361), acc.RentEpoch)
+	assert.Equal(t, data, acc.Data)
+	assert.Nil(t, acc.Parsed)
+
+	acc, err = sc.GetAccountInfo(context.Background(), addr, client.AccountInfoOptions{
+		Commitment: rpc.CommitmentConfirmed,
+		DataSlice:  &client.DataSlice{Offset: 4, Length: 6},
+		Encoding:   rpc.AccountEncodingBase58,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("456789"), acc.Data)
+
+	acc, err = sc.GetAccountInfo(context.Background(), addr, client.AccountInfoOptions{Encoding: rpc.AccountEncodingJsonParsed})
+	require.NoError(t, err)
+	assert.Nil(t, acc.Data)
+	assert.JSONEq(t, `{"program":"spl-token","parsed":{"type":"mint"}}`, string(acc.Parsed))
+
+	require.Len(t, cfgs, 3)
+	assert.Equal(t, rpc.AccountEncodingBase64, cfgs[0].Encoding)
+	assert.Nil(t, cfgs[0].DataSlice)
+	assert.Equal(t, rpc.CommitmentConfirmed, cfgs[1].Commitment)
+	assert.Equal(t, &rpc.DataSlice{Offset: 4, Length: 6}, cfgs[1].DataSlice)
+
+	_, err = sc.GetAccountInfo(context.Background(), addr, client.AccountInfoOptions{Encoding: rpc.AccountEncodingBase64Zstd})
+	assert.ErrorIs(t, err, client.ErrGetAccountInfo)
+}
+
+func TestGetAccountInfo_ProgramDerivedAddress(t *testing.T) {
+	// the associated token accounts are off the ed25519 curve
+	ata, _, err := common.FindAssociatedTokenAddress(types.NewAccount().PublicKey, types.NewAccount().PublicKey)
+	require.NoError(t, err)
+
+	var cfgs []rpc.GetAccountInfoConfig
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": accountInfoResult(t, []byte("data"), &cfgs),
+	})
+
+	acc, err := sc.GetAccountInfo(context.Background(), ata.ToBase58(), client.AccountInfoOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), acc.Data)
+}
+
+func TestGetAccountInfo_NotFound(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value":   nil,
+		},
+	})
+
+	_, err := sc.GetAccountInfo(context.Background(), types.NewAccount().PublicKey.ToBase58(), client.AccountInfoOptions{})
+	assert.ErrorIs(t, err, client.ErrGetAccountInfo)
+	assert.ErrorIs(t, err, client.ErrAccountNotFound)
+}
+
+func TestGetAccountData(t *testing.T) {
+	addr := types.NewAccount().PublicKey.ToBase58()
+
+	var cfgs []rpc.GetAccountInfoConfig
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": accountInfoResult(t, []byte("data"), &cfgs),
+	})
+	data, err := sc.GetAccountData(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	sc = newMockClient(t, map[string]interface{}{
+		"getAccountInfo": withContext(nil),
+	})
+	_, err = sc.GetAccountData(context.Background(), addr)
+	assert.ErrorIs(t, err, client.ErrAccountNotFound)
+}
+
+func TestAccountExists(t *testing.T) {
+	addr := types.NewAccount().PublicKey.ToBase58()
+
+	var cfgs []rpc.GetAccountInfoConfig
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": accountInfoResult(t, []byte("data"), &cfgs),
+	})
+	ok, err := sc.AccountExists(context.Background(), addr)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.Len(t, cfgs, 1)
+	assert.Equal(t, &rpc.DataSlice{Offset: 0, Length: 0}, cfgs[0].DataSlice)
+
+	sc = newMockClient(t, map[string]interface{}{
+		"getAccountInfo": withContext(nil),
+	})
+	ok, err = sc.AccountExists(context.Background(), addr)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = sc.AccountExists(context.Background(), "invalid")
+	assert.ErrorIs(t, err, client.ErrGetAccountInfo)
+}
+
+func TestWaitForAccount(t *testing.T) {
+	addr := types.NewAccount().PublicKey.ToBase58()
+	account := func(owner common.PublicKey) interface{} {
+		acc := accountData(nil)
+		acc["owner"] = owner.ToBase58()
+		return withContext(acc)
+	}
+
+	tests := []struct {
+		name      string
+		opts      client.ConfirmOptions
+		accounts  []interface{}
+		wantErr   bool
+		wantPolls int
+	}{
+		{
+			name:      "appears after delay",
+			opts:      client.ConfirmOptions{PollInterval: time.Second},
+			accounts:  []interface{}{withContext(nil), withContext(nil), account(common.SystemProgramID)},
+			wantPolls: 3,
+		},
+		{
+			name:      "assigned to expected owner",
+			opts:      client.ConfirmOptions{PollInterval: time.Second, ExpectedOwner: common.TokenProgramID.ToBase58()},
+			accounts:  []interface{}{withContext(nil), account(common.SystemProgramID), account(common.TokenProgramID)},
+			wantPolls: 3,
+		},
+		{
+			name:      "timeout",
+			opts:      client.ConfirmOptions{PollInterval: time.Second, MaxDuration: 10 * time.Second},
+			accounts:  []interface{}{withContext(nil)},
+			wantErr:   true,
+			wantPolls: 11,
+		},
+		{
+			name:      "timeout with unexpected owner",
+			opts:      client.ConfirmOptions{PollInterval: time.Second, MaxDuration: 10 * time.Second, ExpectedOwner: common.TokenProgramID.ToBase58()},
+			accounts:  []interface{}{account(common.SystemProgramID)},
+			wantErr:   true,
+			wantPolls: 11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu    sync.Mutex
+				polls int
+			)
+			clk := newFakeClock()
+			c := newMockClient(t, map[string]interface{}{
+				"getAccountInfo": func() interface{} {
+					mu.Lock()
+					defer mu.Unlock()
+					acc := tt.accounts[len(tt.accounts)-1]
+					if polls < len(tt.accounts) {
+						acc = tt.accounts[polls]
+					}
+					polls++
+					return acc
+				},
+			}, client.WithClock(clk))
+
+			go clk.Advance(time.Minute)
+
+			err := c.WaitForAccount(context.Background(), addr, tt.opts)
+			if tt.wantErr {
+				require.ErrorIs(t, err, client.ErrWaitForAccount)
+				require.ErrorIs(t, err, client.ErrWaitForAccountTimeout)
+				require.NotErrorIs(t, err, client.ErrContextDone)
+			} else {
+				require.NoError(t, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, tt.wantPolls, polls)
+		})
+	}
+
+	t.Run("already exists", func(t *testing.T) {
+		var polls int
+		c := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": func() interface{} {
+				polls++
+				return account(common.SystemProgramID)
+			},
+		}, client.WithClock(newFakeClock()))
+
+		// the clock isn't advanced, so the account must be found without waiting for a tick
+		require.NoError(t, c.WaitForAccount(context.Background(), addr, client.ConfirmOptions{PollInterval: time.Second}))
+		assert.Equal(t, 1, polls)
+	})
+
+	t.Run("invalid expected owner", func(t *testing.T) {
+		c := newMockClient(t, nil, client.WithClock(newFakeClock()))
+		err := c.WaitForAccount(context.Background(), addr, client.ConfirmOptions{ExpectedOwner: "invalid"})
+		require.ErrorIs(t, err, client.ErrWaitForAccount)
+	})
+}
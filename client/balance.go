@@ -6,16 +6,21 @@ import (
 	"github.com/dmitrymomot/solana/common"
 	"github.com/dmitrymomot/solana/types"
 	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/rpc"
 )
 
 // GetSOLBalance returns the SOL balance of the given base58 encoded account address.
+// The optional commitment overrides the client default commitment.
 // Returns the balance or an error.
-func (c *Client) GetSOLBalance(ctx context.Context, base58Addr string) (uint64, error) {
+func (c *Client) GetSOLBalance(ctx context.Context, base58Addr string, commitment ...rpc.Commitment) (uint64, error) {
 	if err := common.ValidateSolanaWalletAddr(base58Addr); err != nil {
 		return 0, utils.StackErrors(ErrGetSolBalance, err)
 	}
 
-	balance, err := c.rpcClient.GetBalance(ctx, base58Addr)
+	balance, err := c.rpcClient.GetBalanceWithConfig(ctx, base58Addr, client.GetBalanceConfig{
+		Commitment: c.getCommitment(commitment),
+	})
 	if err != nil {
 		return 0, utils.StackErrors(ErrGetSolBalance, err)
 	}
@@ -23,11 +28,101 @@ func (c *Client) GetSOLBalance(ctx context.Context, base58Addr string) (uint64,
 	return balance, nil
 }
 
+// GetSOLBalances returns the SOL balances of the given base58 encoded account addresses,
+// fetched via getMultipleAccounts in chunks of up to 100 accounts.
+// Non-existent accounts have zero balance.
+// Returns the balances by address or an error if any address is invalid or the RPC request fails.
+func (c *Client) GetSOLBalances(ctx context.Context, base58Addrs []string) (map[string]types.TokenAmount, error) {
+	result := make(map[string]types.TokenAmount, len(base58Addrs))
+
+	addrs := make([]string, 0, len(base58Addrs))
+	for _, addr := range base58Addrs {
+		if _, ok := result[addr]; ok {
+			continue
+		}
+		if err := common.ValidateAccountAddr(addr); err != nil {
+			return nil, utils.StackErrors(ErrGetSolBalances, err)
+		}
+		result[addr] = types.NewTokenAmountFromLamports(0, types.SPLTokenDefaultDecimals)
+		addrs = append(addrs, addr)
+	}
+
+	accounts, err := c.getMultipleAccounts(ctx, addrs)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetSolBalances, err)
+	}
+	for i, account := range accounts {
+		result[addrs[i]] = types.NewTokenAmountFromLamports(account.Lamports, types.SPLTokenDefaultDecimals)
+	}
+
+	return result, nil
+}
+
+// WatchBalance subscribes to the changes of the given base58 encoded account and sends its SOL balance
+// to the returned channel: the current balance first, then the new balance every time it changes.
+// Cancel the context to stop watching; the channel is closed when the context is done
+// or the websocket connection fails. Requires the websocket endpoint, see SetWSEndpoint.
+func (c *Client) WatchBalance(ctx context.Context, base58Addr string) (<-chan types.TokenAmount, error) {
+	if err := common.ValidateSolanaWalletAddr(base58Addr); err != nil {
+		return nil, utils.StackErrors(ErrWatchBalance, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	updates, err := subscribe[rpc.ValueWithContext[struct {
+		Lamports uint64 `json:"lamports"`
+	}]](ctx, c, "accountSubscribe", base58Addr, rpc.GetAccountInfoConfig{
+		Encoding:   rpc.AccountEncodingBase64,
+		Commitment: c.getCommitment(nil),
+	})
+	if err != nil {
+		cancel()
+		return nil, utils.StackErrors(ErrWatchBalance, err)
+	}
+
+	// the current balance is requested after subscribing, so no change is missed
+	balance, err := c.GetSOLBalance(ctx, base58Addr)
+	if err != nil {
+		cancel()
+		return nil, utils.StackErrors(ErrWatchBalance, err)
+	}
+
+	result := make(chan types.TokenAmount)
+	go func() {
+		defer close(result)
+		defer cancel()
+
+		send := func(lamports uint64) bool {
+			select {
+			case result <- types.NewTokenAmountFromLamports(lamports, types.SPLTokenDefaultDecimals):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if !send(balance) {
+			return
+		}
+		for update := range updates {
+			if update.Value.Lamports == balance {
+				continue
+			}
+			balance = update.Value.Lamports
+			if !send(balance) {
+				return
+			}
+		}
+	}()
+
+	return result, nil
+}
+
 // GetTokenBalance returns the SPL token balance of the given base58 encoded account address and SPL token mint address.
 // base58Addr is the base58 encoded account address.
 // base58MintAddr is the base58 encoded SPL token mint address.
+// The optional commitment overrides the client default commitment.
 // Returns the balance in lamports and token decimals, or an error.
-func (c *Client) GetTokenBalance(ctx context.Context, base58Addr, base58MintAddr string) (types.TokenAmount, error) {
+func (c *Client) GetTokenBalance(ctx context.Context, base58Addr, base58MintAddr string, commitment ...rpc.Commitment) (types.TokenAmount, error) {
 	if err := common.ValidateSolanaWalletAddr(base58Addr); err != nil {
 		return types.TokenAmount{}, utils.StackErrors(ErrGetSplTokenBalance, err)
 	}
@@ -40,14 +135,17 @@ func (c *Client) GetTokenBalance(ctx context.Context, base58Addr, base58MintAddr
 		return types.TokenAmount{}, utils.StackErrors(ErrGetSplTokenBalance, ErrFindAssociatedTokenAddress, err)
 	}
 
-	return c.GetAtaBalance(ctx, ata.String())
+	return c.GetAtaBalance(ctx, ata.String(), commitment...)
 }
 
 // GetAtaBalance returns the SPL token balance of the given base58 encoded associated token account address.
 // base58Addr is the base58 encoded associated token account address.
+// The optional commitment overrides the client default commitment.
 // Returns the balance in lamports and token decimals, or an error.
-func (c *Client) GetAtaBalance(ctx context.Context, base58Addr string) (types.TokenAmount, error) {
-	balance, err := c.rpcClient.GetTokenAccountBalance(ctx, base58Addr)
+func (c *Client) GetAtaBalance(ctx context.Context, base58Addr string, commitment ...rpc.Commitment) (types.TokenAmount, error) {
+	balance, err := c.rpcClient.GetTokenAccountBalanceWithConfig(ctx, base58Addr, client.GetTokenAccountBalanceConfig{
+		Commitment: c.getCommitment(commitment),
+	})
 	if err != nil {
 		return types.TokenAmount{}, utils.StackErrors(ErrGetAtaBalance, ErrGetSplTokenBalance, err)
 	}
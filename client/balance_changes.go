@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/dmitrymomot/solana/types"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// BalanceChange is the net change of a token account balance made by a transaction.
+type BalanceChange struct {
+	Account   string                 // base58 encoded token account address
+	Owner     string                 // base58 encoded owner of the token account; empty if not returned by the node
+	Mint      string                 // base58 encoded token mint address
+	Direction TokenTransferDirection // incoming if the balance increased, outgoing otherwise
+	Amount    types.TokenAmount      // absolute amount of the change
+}
+
+// GetTransactionBalanceChanges returns the net token balance changes made by the given transaction,
+// diffing its pre and post token balances per token account and mint.
+// Accounts whose balance didn't change are skipped.
+// The changes are ordered by the account index in the transaction.
+func (c *Client) GetTransactionBalanceChanges(ctx context.Context, signature string) ([]BalanceChange, error) {
+	tx, err := c.GetTransaction(ctx, signature)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTransactionBalanceChanges, err)
+	}
+
+	changes, err := parseBalanceChanges(tx)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTransactionBalanceChanges, err)
+	}
+
+	return changes, nil
+}
+
+// parseBalanceChanges diffs the pre and post token balances of the transaction.
+func parseBalanceChanges(tx *client.Transaction) ([]BalanceChange, error) {
+	type balanceKey struct {
+		index uint64
+		mint  string
+	}
+	type balance struct {
+		owner     string
+		decimals  uint8
+		pre, post uint64
+	}
+
+	balances := make(map[balanceKey]*balance)
+	keys := make([]balanceKey, 0)
+
+	collect := func(tokenBalances []rpc.TransactionMetaTokenBalance, post bool) error {
+		for _, b := range tokenBalances {
+			amount, err := strconv.ParseUint(b.UITokenAmount.Amount, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse token balance of account #%d: %w", b.AccountIndex, err)
+			}
+
+			key := balanceKey{index: b.AccountIndex, mint: b.Mint}
+			bal, ok := balances[key]
+			if !ok {
+				bal = &balance{}
+				balances[key] = bal
+				keys = append(keys, key)
+			}
+			if b.Owner != "" {
+				bal.owner = b.Owner
+			}
+			bal.decimals = b.UITokenAmount.Decimals
+			if post {
+				bal.post = amount
+			} else {
+				bal.pre = amount
+			}
+		}
+		return nil
+	}
+	if err := collect(tx.Meta.PreTokenBalances, false); err != nil {
+		return nil, err
+	}
+	if err := collect(tx.Meta.PostTokenBalances, true); err != nil {
+		return nil, err
+	}
+
+	// the account indexes refer to the static account keys followed by the ones loaded from the lookup tables
+	accounts := make([]string, 0, len(tx.Transaction.Message.Accounts))
+	for _, acc := range tx.Transaction.Message.Accounts {
+		accounts = append(accounts, acc.ToBase58())
+	}
+	accounts = append(accounts, tx.Meta.LoadedAddresses.Writable...)
+	accounts = append(accounts, tx.Meta.LoadedAddresses.Readonly...)
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].index < keys[j].index })
+
+	result := make([]BalanceChange, 0, len(keys))
+	for _, key := range keys {
+		bal := balances[key]
+		if bal.pre == bal.post {
+			continue
+		}
+		if key.index >= uint64(len(accounts)) {
+			return nil, fmt.Errorf("token balance account index %d is out of range", key.index)
+		}
+
+		change := BalanceChange{
+			Account:   accounts[key.index],
+			Owner:     bal.owner,
+			Mint:      key.mint,
+			Direction: TokenTransferIncoming,
+		}
+		amount := bal.post - bal.pre
+		if bal.pre > bal.post {
+			change.Direction = TokenTransferOutgoing
+			amount = bal.pre - bal.post
+		}
+		change.Amount = types.NewTokenAmountFromLamports(amount, bal.decimals)
+
+		result = append(result, change)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransactionBalanceChanges(t *testing.T) {
+	sender := types.NewAccount()
+	recipient := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+	otherMint := types.NewAccount().PublicKey
+
+	senderATA, _, err := common.FindAssociatedTokenAddress(sender.PublicKey, mint)
+	require.NoError(t, err)
+	recipientATA, _, err := common.FindAssociatedTokenAddress(recipient, mint)
+	require.NoError(t, err)
+	untouchedATA, _, err := common.FindAssociatedTokenAddress(sender.PublicKey, otherMint)
+	require.NoError(t, err)
+
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        sender.PublicKey,
+			RecentBlockhash: types.NewAccount().PublicKey.ToBase58(),
+			Instructions: []types.Instruction{
+				token.TransferChecked(token.TransferCheckedParam{
+					From:     senderATA,
+					To:       recipientATA,
+					Mint:     mint,
+					Auth:     sender.PublicKey,
+					Amount:   250,
+					Decimals: 6,
+				}),
+				token.SyncNative(token.SyncNativeParam{Account: untouchedATA}),
+			},
+		}),
+		Signers: []types.Account{sender},
+	})
+	require.NoError(t, err)
+	rawTx, err := utils.EncodeTransaction(tx)
+	require.NoError(t, err)
+
+	indexOf := func(key common.PublicKey) uint64 {
+		for i, acc := range tx.Message.Accounts {
+			if acc == key {
+				return uint64(i)
+			}
+		}
+		t.Fatalf("account %s is not in the transaction", key.ToBase58())
+		return 0
+	}
+	balance := func(account common.PublicKey, mint, owner, amount string) rpc.TransactionMetaTokenBalance {
+		b := tokenBalance(common.TokenProgramID, mint, owner, amount)
+		b.AccountIndex = indexOf(account)
+		return b
+	}
+
+	newClient := func(t *testing.T, meta rpc.TransactionMeta) *client.Client {
+		return newMockClient(t, map[string]interface{}{
+			"getTransaction": map[string]interface{}{
+				"slot":        1,
+				"transaction": []string{rawTx, "base64"},
+				"meta":        meta,
+			},
+		})
+	}
+
+	t.Run("spl transfer", func(t *testing.T) {
+		c := newClient(t, rpc.TransactionMeta{
+			PreBalances:  []int64{10000, 1, 1, 1, 1, 1},
+			PostBalances: []int64{5000, 1, 1, 1, 1, 1},
+			PreTokenBalances: []rpc.TransactionMetaTokenBalance{
+				balance(senderATA, mint.ToBase58(), sender.PublicKey.ToBase58(), "1000"),
+				balance(untouchedATA, otherMint.ToBase58(), sender.PublicKey.ToBase58(), "5"),
+			},
+			PostTokenBalances: []rpc.TransactionMetaTokenBalance{
+				balance(senderATA, mint.ToBase58(), sender.PublicKey.ToBase58(), "750"),
+				balance(recipientATA, mint.ToBase58(), recipient.ToBase58(), "250"),
+				balance(untouchedATA, otherMint.ToBase58(), sender.PublicKey.ToBase58(), "5"),
+			},
+		})
+
+		changes, err := c.GetTransactionBalanceChanges(context.Background(), "sig")
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+
+		byAccount := make(map[string]client.BalanceChange, len(changes))
+		for _, change := range changes {
+			byAccount[change.Account] = change
+		}
+
+		out := byAccount[senderATA.ToBase58()]
+		assert.Equal(t, sender.PublicKey.ToBase58(), out.Owner)
+		assert.Equal(t, mint.ToBase58(), out.Mint)
+		assert.Equal(t, client.TokenTransferOutgoing, out.Direction)
+		assert.Equal(t, uint64(250), out.Amount.Amount)
+		assert.Equal(t, uint8(6), out.Amount.Decimals)
+
+		in := byAccount[recipientATA.ToBase58()]
+		assert.Equal(t, recipient.ToBase58(), in.Owner)
+		assert.Equal(t, mint.ToBase58(), in.Mint)
+		assert.Equal(t, client.TokenTransferIncoming, in.Direction)
+		assert.Equal(t, uint64(250), in.Amount.Amount)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		c := newClient(t, rpc.TransactionMeta{
+			PreBalances:      []int64{10000},
+			PostBalances:     []int64{5000},
+			PreTokenBalances: []rpc.TransactionMetaTokenBalance{balance(senderATA, mint.ToBase58(), "", "invalid")},
+		})
+
+		_, err := c.GetTransactionBalanceChanges(context.Background(), "sig")
+		require.ErrorIs(t, err, client.ErrGetTransactionBalanceChanges)
+	})
+}
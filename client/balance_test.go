@@ -0,0 +1,96 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/common"
+	sdktypes "github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSOLBalances(t *testing.T) {
+	funded := sdktypes.NewAccount().PublicKey.ToBase58()
+	rich := sdktypes.NewAccount().PublicKey.ToBase58()
+	empty := sdktypes.NewAccount().PublicKey.ToBase58()
+	lamports := map[string]uint64{
+		funded: 1_500_000_000,
+		rich:   42_000_000_000,
+	}
+
+	var requests [][]string
+	c := newMockClient(t, map[string]interface{}{
+		"getMultipleAccounts": func(params []json.RawMessage) interface{} {
+			var addrs []string
+			require.NoError(t, json.Unmarshal(params[0], &addrs))
+			requests = append(requests, addrs)
+
+			value := make([]interface{}, 0, len(addrs))
+			for _, addr := range addrs {
+				if balance, ok := lamports[addr]; ok {
+					value = append(value, map[string]interface{}{
+						"lamports":   balance,
+						"owner":      common.SystemProgramID.ToBase58(),
+						"executable": false,
+						"rentEpoch":  0,
+						"data":       []string{"", "base64"},
+					})
+				} else {
+					value = append(value, nil)
+				}
+			}
+			return withContext(value)
+		},
+	})
+
+	balances, err := c.GetSOLBalances(context.Background(), []string{funded, empty, rich, funded})
+	require.NoError(t, err)
+	require.Len(t, balances, 3)
+	assert.Equal(t, [][]string{{funded, empty, rich}}, requests)
+
+	assert.EqualValues(t, 1_500_000_000, balances[funded].Amount)
+	assert.Equal(t, 1.5, balances[funded].UIAmount)
+	assert.EqualValues(t, 9, balances[funded].Decimals)
+	assert.EqualValues(t, 42_000_000_000, balances[rich].Amount)
+	assert.EqualValues(t, 0, balances[empty].Amount)
+	assert.EqualValues(t, 9, balances[empty].Decimals)
+
+	_, err = c.GetSOLBalances(context.Background(), []string{funded, "invalid"})
+	require.ErrorIs(t, err, client.ErrGetSolBalances)
+}
+
+func TestGetSOLBalances_Chunks(t *testing.T) {
+	var requests []int
+	c := newMockClient(t, map[string]interface{}{
+		"getMultipleAccounts": func(params []json.RawMessage) interface{} {
+			var addrs []string
+			require.NoError(t, json.Unmarshal(params[0], &addrs))
+			requests = append(requests, len(addrs))
+			return withContext(make([]interface{}, len(addrs)))
+		},
+	})
+
+	addrs := make([]string, 0, 250)
+	for i := 0; i < 250; i++ {
+		addrs = append(addrs, sdktypes.NewAccount().PublicKey.ToBase58())
+	}
+
+	balances, err := c.GetSOLBalances(context.Background(), addrs)
+	require.NoError(t, err)
+	assert.Len(t, balances, 250)
+	assert.Equal(t, []int{100, 100, 50}, requests)
+
+	balances, err = c.GetSOLBalances(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, balances)
+	assert.Len(t, requests, 3)
+}
+
+func TestGetSOLBalances_RPCError(t *testing.T) {
+	c := newMockClient(t, nil)
+	_, err := c.GetSOLBalances(context.Background(), []string{sdktypes.NewAccount().PublicKey.ToBase58()})
+	require.ErrorIs(t, err, client.ErrGetSolBalances)
+}
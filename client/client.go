@@ -1,28 +1,50 @@
 package client
 
 import (
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/dmitrymomot/solana/types"
 	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/rpc"
 )
 
 type (
-	// Solana client wrapper
+	// Solana client wrapper.
+	// The client is safe for concurrent use by multiple goroutines:
+	// its configuration is immutable once New returns, and the caches are synchronized.
 	Client struct {
 		rpcClient       *client.Client
+		rpcEndpoint     string            // solana RPC node endpoint; the rpc client is created on New
+		rpcHeaders      map[string]string // headers added to the RPC node requests
 		http            *http.Client
 		defaultDecimals uint8
 		tokenListPath   string
+		clock           clock
+		commitment      rpc.Commitment
+
+		metadataCache    MetadataCache
+		metadataCacheTTL time.Duration
+
+		rentExemptionCache *sync.Map // account size -> minimum balance for rent exemption; nil if disabled
+		tokenProgramCache  *sync.Map // mint address -> token program which owns the mint
+
+		dasEndpoint string // Digital Asset Standard API endpoint; empty if not set
+		wsEndpoint  string // websocket endpoint of the RPC node; empty if not set
 	}
 
+	// ClientOption configures the client; the options are applied by New only,
+	// so the client can't be reconfigured while it's in use.
 	ClientOption func(*Client)
 )
 
-// WithCustomSolanaClient sets a custom solana client
+// WithCustomSolanaClient sets a custom solana client.
+// SetHTTPClient and SetRPCHeaders don't affect the requests of the custom client.
 func WithCustomSolanaClient(solana *client.Client) ClientOption {
 	return func(c *Client) {
-		if c.rpcClient != nil {
+		if c.rpcClient != nil || c.rpcEndpoint != "" {
 			panic("solana client is already set")
 		}
 		c.rpcClient = solana
@@ -30,8 +52,21 @@ func WithCustomSolanaClient(solana *client.Client) ClientOption {
 }
 
 // WithCustomDecimals sets the custom default decimals
+//
+// Deprecated: use SetDefaultDecimals instead.
 func WithCustomDecimals(decimals uint8) ClientOption {
+	return SetDefaultDecimals(decimals)
+}
+
+// SetDefaultDecimals sets the decimals used to mint the fungible tokens
+// if the given ones are unset or out of range, see instructions.MintFungible.
+// The decimals must be in range 0-9, otherwise it panics.
+// Default: types.SPLTokenDefaultDecimals.
+func SetDefaultDecimals(decimals uint8) ClientOption {
 	return func(c *Client) {
+		if decimals > types.SPLTokenMaxDecimals {
+			panic(fmt.Sprintf("default decimals must be in range 0-%d, got %d", types.SPLTokenMaxDecimals, decimals))
+		}
 		c.defaultDecimals = decimals
 	}
 }
@@ -39,14 +74,75 @@ func WithCustomDecimals(decimals uint8) ClientOption {
 // SetSolanaEndpoint sets the solana endpoint
 func SetSolanaEndpoint(endpoint string) ClientOption {
 	return func(c *Client) {
-		if c.rpcClient != nil {
+		if c.rpcClient != nil || c.rpcEndpoint != "" {
 			panic("solana client is already set")
 		}
-		c.rpcClient = client.NewClient(endpoint)
+		c.rpcEndpoint = endpoint
+	}
+}
+
+// SetRPCHeaders sets the headers added to the requests to the solana RPC node,
+// including the websocket handshake, e.g. the API key header of the paid RPC providers.
+// The headers aren't sent to the other endpoints, e.g. the DAS API or the metadata URIs.
+func SetRPCHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		if c.rpcHeaders == nil {
+			c.rpcHeaders = make(map[string]string, len(headers))
+		}
+		for key, value := range headers {
+			c.rpcHeaders[key] = value
+		}
+	}
+}
+
+// SetDefaultCommitment sets the default commitment level of the read requests.
+// The RPC node's default commitment is used if it's not set.
+func SetDefaultCommitment(commitment rpc.Commitment) ClientOption {
+	return func(c *Client) {
+		c.commitment = commitment
+	}
+}
+
+// SetMetadataCache sets the cache of the token metadata lookups.
+// The in-memory LRU cache of DefaultMetadataCacheCapacity is used if the cache is nil.
+// The metadata is not cached by default.
+func SetMetadataCache(cache MetadataCache) ClientOption {
+	return func(c *Client) {
+		if cache == nil {
+			cache = NewLRUMetadataCache(DefaultMetadataCacheCapacity)
+		}
+		c.metadataCache = cache
+	}
+}
+
+// SetMetadataCacheTTL sets the time to live of the cached token metadata.
+// Default: DefaultMetadataCacheTTL.
+func SetMetadataCacheTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.metadataCacheTTL = ttl
 	}
 }
 
-// SetHTTPClient sets the http client
+// DisableRentExemptionCache disables caching of the minimum balance for rent exemption.
+// By default the balance is cached per account size for the client's lifetime,
+// since it changes only with the cluster rent configuration.
+func DisableRentExemptionCache() ClientOption {
+	return func(c *Client) {
+		c.rentExemptionCache = nil
+	}
+}
+
+// SetDASEndpoint sets the endpoint of the Digital Asset Standard (DAS) API,
+// which is required to read compressed NFTs, see GetAsset.
+// Not all RPC providers support the DAS API, so it's not set by default.
+func SetDASEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		c.dasEndpoint = endpoint
+	}
+}
+
+// SetHTTPClient sets the http client of the requests to the solana RPC node and the DAS API,
+// e.g. to set a proxy, a custom transport or timeouts. Default: http.DefaultClient.
 func SetHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
 		if c.http != nil {
@@ -70,20 +166,32 @@ func SetTokenListPath(path string) ClientOption {
 // endpoint is the endpoint of the solana RPC node
 // cnf is the configuration for the client
 func New(opts ...ClientOption) *Client {
-	c := &Client{defaultDecimals: types.SPLTokenDefaultDecimals}
+	c := &Client{
+		defaultDecimals:    types.SPLTokenDefaultDecimals,
+		clock:              realClock{},
+		metadataCacheTTL:   DefaultMetadataCacheTTL,
+		rentExemptionCache: &sync.Map{},
+		tokenProgramCache:  &sync.Map{},
+	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	if c.rpcClient == nil {
-		panic("missing solana client")
-	}
-
 	if c.http == nil {
 		c.http = http.DefaultClient
 	}
 
+	if c.rpcClient == nil && c.rpcEndpoint != "" {
+		c.rpcClient = client.New(
+			rpc.WithEndpoint(c.rpcEndpoint),
+			rpc.WithHTTPClient(withHeaders(c.http, c.rpcHeaders)),
+		)
+	}
+	if c.rpcClient == nil {
+		panic("missing solana client")
+	}
+
 	if c.tokenListPath == "" {
 		c.tokenListPath = types.DeprecatedTokenListPath
 	}
@@ -91,12 +199,32 @@ func New(opts ...ClientOption) *Client {
 	return c
 }
 
-// Solana returns the solana client
+// Solana returns the wrapped solana-go-sdk client.
+// It's an escape hatch for the calls which are not covered by this client yet;
+// there are no stability guarantees, since it exposes the underlying SDK.
 func (c *Client) Solana() *client.Client {
 	return c.rpcClient
 }
 
-// DefaultDecimals returns the default decimals
+// RPC returns the raw JSON-RPC client of the wrapped solana-go-sdk client,
+// which calls any RPC method by name, e.g. RPC().Call(ctx, "getBlockHeight").
+// It's an escape hatch for the RPC methods which are not covered by this client yet;
+// there are no stability guarantees, since it exposes the underlying SDK.
+func (c *Client) RPC() *rpc.RpcClient {
+	return &c.rpcClient.RpcClient
+}
+
+// DefaultDecimals returns the default decimals, see SetDefaultDecimals
 func (c *Client) DefaultDecimals() uint8 {
 	return c.defaultDecimals
 }
+
+// getCommitment returns the first non-empty commitment override or the client default commitment.
+func (c *Client) getCommitment(overrides []rpc.Commitment) rpc.Commitment {
+	for _, commitment := range overrides {
+		if commitment != "" {
+			return commitment
+		}
+	}
+	return c.commitment
+}
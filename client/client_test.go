@@ -0,0 +1,216 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/types"
+	"github.com/portto/solana-go-sdk/rpc"
+	sdktypes "github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitmentRecorder records the commitment of the RPC requests.
+type commitmentRecorder struct {
+	mu          sync.Mutex
+	commitments []rpc.Commitment
+}
+
+// respond returns the mock RPC handler which records the commitment from the config param
+// and responds with the given result.
+func (r *commitmentRecorder) respond(t *testing.T, result interface{}) func(params []json.RawMessage) interface{} {
+	return func(params []json.RawMessage) interface{} {
+		var cfg struct {
+			Commitment rpc.Commitment `json:"commitment"`
+		}
+		if len(params) > 1 {
+			require.NoError(t, json.Unmarshal(params[len(params)-1], &cfg))
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.commitments = append(r.commitments, cfg.Commitment)
+
+		return result
+	}
+}
+
+func (r *commitmentRecorder) last() rpc.Commitment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.commitments) == 0 {
+		return ""
+	}
+	return r.commitments[len(r.commitments)-1]
+}
+
+func withContext(value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"context": map[string]interface{}{"slot": 1},
+		"value":   value,
+	}
+}
+
+func TestCommitment(t *testing.T) {
+	ctx := context.Background()
+	addr := sdktypes.NewAccount().PublicKey.ToBase58()
+	mint := sdktypes.NewAccount().PublicKey.ToBase58()
+	tokenAmount := map[string]interface{}{"amount": "100", "decimals": 2, "uiAmountString": "1"}
+
+	tests := []struct {
+		name   string
+		method string
+		result interface{}
+		call   func(c *client.Client, commitment ...rpc.Commitment) error
+	}{
+		{
+			name:   "GetSOLBalance",
+			method: "getBalance",
+			result: withContext(100),
+			call: func(c *client.Client, commitment ...rpc.Commitment) error {
+				_, err := c.GetSOLBalance(ctx, addr, commitment...)
+				return err
+			},
+		},
+		{
+			name:   "GetTokenBalance",
+			method: "getTokenAccountBalance",
+			result: withContext(tokenAmount),
+			call: func(c *client.Client, commitment ...rpc.Commitment) error {
+				_, err := c.GetTokenBalance(ctx, addr, mint, commitment...)
+				return err
+			},
+		},
+		{
+			name:   "GetAtaBalance",
+			method: "getTokenAccountBalance",
+			result: withContext(tokenAmount),
+			call: func(c *client.Client, commitment ...rpc.Commitment) error {
+				_, err := c.GetAtaBalance(ctx, addr, commitment...)
+				return err
+			},
+		},
+		{
+			name:   "GetTokenSupply",
+			method: "getTokenSupply",
+			result: withContext(tokenAmount),
+			call: func(c *client.Client, commitment ...rpc.Commitment) error {
+				_, err := c.GetTokenSupply(ctx, mint, commitment...)
+				return err
+			},
+		},
+		{
+			name:   "GetMintInfo",
+			method: "getAccountInfo",
+			result: withContext(nil),
+			call: func(c *client.Client, commitment ...rpc.Commitment) error {
+				_, err := c.GetMintInfo(ctx, mint, commitment...)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &commitmentRecorder{}
+			results := map[string]interface{}{tt.method: rec.respond(t, tt.result)}
+
+			// node default
+			c := newMockClient(t, results)
+			_ = tt.call(c)
+			assert.Equal(t, rpc.Commitment(""), rec.last())
+
+			// client default
+			c = newMockClient(t, results, client.SetDefaultCommitment(rpc.CommitmentFinalized))
+			_ = tt.call(c)
+			assert.Equal(t, rpc.CommitmentFinalized, rec.last())
+
+			// per-call override
+			_ = tt.call(c, rpc.CommitmentProcessed)
+			assert.Equal(t, rpc.CommitmentProcessed, rec.last())
+			assert.Len(t, rec.commitments, 3)
+		})
+	}
+}
+
+func TestGetTransactionStatus_Commitment(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     rpc.Commitment
+		defaults   rpc.Commitment
+		override   []rpc.Commitment
+		wantStatus types.TransactionStatus
+	}{
+		{name: "processed, finalized by default", status: rpc.CommitmentProcessed, wantStatus: types.TransactionStatusInProgress},
+		{name: "confirmed, finalized by default", status: rpc.CommitmentConfirmed, wantStatus: types.TransactionStatusInProgress},
+		{name: "finalized, finalized by default", status: rpc.CommitmentFinalized, wantStatus: types.TransactionStatusSuccess},
+		{name: "processed, client confirmed", status: rpc.CommitmentProcessed, defaults: rpc.CommitmentConfirmed, wantStatus: types.TransactionStatusInProgress},
+		{name: "confirmed, client confirmed", status: rpc.CommitmentConfirmed, defaults: rpc.CommitmentConfirmed, wantStatus: types.TransactionStatusSuccess},
+		{name: "finalized, client confirmed", status: rpc.CommitmentFinalized, defaults: rpc.CommitmentConfirmed, wantStatus: types.TransactionStatusSuccess},
+		{name: "confirmed, override processed", status: rpc.CommitmentConfirmed, defaults: rpc.CommitmentFinalized, override: []rpc.Commitment{rpc.CommitmentProcessed}, wantStatus: types.TransactionStatusSuccess},
+		{name: "confirmed, override finalized", status: rpc.CommitmentConfirmed, defaults: rpc.CommitmentProcessed, override: []rpc.Commitment{rpc.CommitmentFinalized}, wantStatus: types.TransactionStatusInProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMockClient(t, map[string]interface{}{
+				"getSignatureStatuses": signatureStatus(tt.status),
+			}, client.SetDefaultCommitment(tt.defaults))
+
+			status, err := c.GetTransactionStatus(context.Background(), "txhash", tt.override...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+
+	c := newMockClient(t, nil)
+	_, err := c.GetTransactionStatus(context.Background(), "txhash", "recent")
+	require.Error(t, err)
+}
+
+func TestRPC(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{
+		"getBlockHeight":         42,
+		"getFirstAvailableBlock": 7,
+	})
+
+	body, err := sc.RPC().Call(context.Background(), "getBlockHeight")
+	require.NoError(t, err)
+
+	var resp rpc.JsonRpcResponse[uint64]
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Nil(t, resp.Error)
+	assert.Equal(t, uint64(42), resp.Result)
+
+	// the SDK client shares the same RPC client
+	first, err := sc.Solana().GetFirstAvailableBlock(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), first)
+}
+
+func TestSetDefaultDecimals(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []client.ClientOption
+		want uint8
+	}{
+		{"unset", nil, types.SPLTokenDefaultDecimals},
+		{"custom", []client.ClientOption{client.SetDefaultDecimals(6)}, 6},
+		{"zero", []client.ClientOption{client.SetDefaultDecimals(0)}, 0},
+		{"deprecated option", []client.ClientOption{client.WithCustomDecimals(2)}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMockClient(t, nil, tt.opts...)
+			assert.Equal(t, tt.want, c.DefaultDecimals())
+		})
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		assert.PanicsWithValue(t, "default decimals must be in range 0-9, got 19", func() {
+			newMockClient(t, nil, client.SetDefaultDecimals(19))
+		})
+	})
+}
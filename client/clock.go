@@ -0,0 +1,27 @@
+package client
+
+import "time"
+
+type (
+	// clock is the time source of the polling loops, it's replaced in tests.
+	clock interface {
+		// NewTicker returns the ticks channel and the function to stop the ticker.
+		NewTicker(d time.Duration) (<-chan time.Time, func())
+		// After returns the channel which receives the current time after the given duration.
+		After(d time.Duration) <-chan time.Time
+	}
+
+	// realClock is the clock based on the time package.
+	realClock struct{}
+)
+
+// NewTicker returns the ticks channel and the function to stop the ticker.
+func (realClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(d)
+	return t.C, t.Stop
+}
+
+// After returns the channel which receives the current time after the given duration.
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
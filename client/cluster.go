@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+type (
+	// EpochInfo represents the current epoch of the cluster.
+	EpochInfo struct {
+		Epoch            uint64 // current epoch
+		SlotIndex        uint64 // current slot relative to the start of the epoch
+		SlotsInEpoch     uint64 // number of slots in the epoch
+		AbsoluteSlot     uint64 // current slot
+		BlockHeight      uint64 // current block height
+		TransactionCount uint64 // total number of transactions processed without error since genesis; 0 if not available
+	}
+
+	// ClusterNode represents a node participating in the cluster.
+	ClusterNode struct {
+		PublicKey    string // base58 encoded node identity public key
+		Gossip       string // gossip network address; empty if not available
+		TPU          string // TPU network address; empty if not available
+		RPC          string // JSON RPC network address; empty if the RPC service is not enabled
+		Version      string // software version; empty if not available
+		FeatureSet   uint32 // unique identifier of the node's feature set; 0 if not available
+		ShredVersion uint16 // shred version the node has been configured to use; 0 if not available
+	}
+)
+
+// GetEpochInfo returns information about the current epoch.
+// The commitment overrides the client default commitment.
+func (c *Client) GetEpochInfo(ctx context.Context, commitment ...rpc.Commitment) (EpochInfo, error) {
+	info, err := rpcCall[rpc.GetEpochInfo](ctx, c, "getEpochInfo", rpc.GetEpochInfoConfig{
+		Commitment: c.getCommitment(commitment),
+	})
+	if err != nil {
+		return EpochInfo{}, utils.StackErrors(ErrGetEpochInfo, err)
+	}
+
+	result := EpochInfo{
+		Epoch:        info.Epoch,
+		SlotIndex:    info.SlotIndex,
+		SlotsInEpoch: info.SlotsInEpoch,
+		AbsoluteSlot: info.AbsoluteSlot,
+		BlockHeight:  info.BlockHeight,
+	}
+	if info.TransactionCount != nil {
+		result.TransactionCount = *info.TransactionCount
+	}
+
+	return result, nil
+}
+
+// GetBlockHeight returns the current block height of the node.
+// The commitment overrides the client default commitment.
+func (c *Client) GetBlockHeight(ctx context.Context, commitment ...rpc.Commitment) (uint64, error) {
+	height, err := rpcCall[uint64](ctx, c, "getBlockHeight", rpc.GetBlockHeightConfig{
+		Commitment: c.getCommitment(commitment),
+	})
+	if err != nil {
+		return 0, utils.StackErrors(ErrGetBlockHeight, err)
+	}
+
+	return height, nil
+}
+
+// GetClusterNodes returns information about all the nodes participating in the cluster.
+func (c *Client) GetClusterNodes(ctx context.Context) ([]ClusterNode, error) {
+	nodes, err := rpcCall[[]struct {
+		Pubkey       string  `json:"pubkey"`
+		Gossip       *string `json:"gossip"`
+		Tpu          *string `json:"tpu"`
+		Rpc          *string `json:"rpc"`
+		Version      *string `json:"version"`
+		FeatureSet   *uint32 `json:"featureSet"`
+		ShredVersion *uint16 `json:"shredVersion"`
+	}](ctx, c, "getClusterNodes")
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetClusterNodes, err)
+	}
+
+	result := make([]ClusterNode, 0, len(nodes))
+	for _, node := range nodes {
+		n := ClusterNode{
+			PublicKey: node.Pubkey,
+			Gossip:    stringValue(node.Gossip),
+			TPU:       stringValue(node.Tpu),
+			RPC:       stringValue(node.Rpc),
+			Version:   stringValue(node.Version),
+		}
+		if node.FeatureSet != nil {
+			n.FeatureSet = *node.FeatureSet
+		}
+		if node.ShredVersion != nil {
+			n.ShredVersion = *node.ShredVersion
+		}
+		result = append(result, n)
+	}
+
+	return result, nil
+}
+
+// stringValue returns the string or empty string if it's nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
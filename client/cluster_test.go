@@ -0,0 +1,102 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEpochInfo(t *testing.T) {
+	var cfg rpc.GetEpochInfoConfig
+	sc := newMockClient(t, map[string]interface{}{
+		"getEpochInfo": func(params []json.RawMessage) interface{} {
+			require.Len(t, params, 1)
+			require.NoError(t, json.Unmarshal(params[0], &cfg))
+			return map[string]interface{}{
+				"absoluteSlot":     166598,
+				"blockHeight":      166500,
+				"epoch":            27,
+				"slotIndex":        2790,
+				"slotsInEpoch":     8192,
+				"transactionCount": 22661093,
+			}
+		},
+	}, client.SetDefaultCommitment(rpc.CommitmentConfirmed))
+
+	info, err := sc.GetEpochInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, client.EpochInfo{
+		Epoch:            27,
+		SlotIndex:        2790,
+		SlotsInEpoch:     8192,
+		AbsoluteSlot:     166598,
+		BlockHeight:      166500,
+		TransactionCount: 22661093,
+	}, info)
+	assert.Equal(t, rpc.CommitmentConfirmed, cfg.Commitment)
+
+	_, err = sc.GetEpochInfo(context.Background(), rpc.CommitmentFinalized)
+	require.NoError(t, err)
+	assert.Equal(t, rpc.CommitmentFinalized, cfg.Commitment)
+}
+
+func TestGetClusterNodes(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{
+		"getClusterNodes": []map[string]interface{}{
+			{
+				"pubkey":       "9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ",
+				"gossip":       "10.239.6.48:8001",
+				"tpu":          "10.239.6.48:8856",
+				"rpc":          "10.239.6.48:8899",
+				"version":      "1.14.17",
+				"featureSet":   1879391783,
+				"shredVersion": 56177,
+			},
+			{
+				"pubkey":       "7Np41oeYqPefeNQEHSv1UDhYrehxin3NStELsSKCT4K2",
+				"gossip":       "10.239.6.49:8001",
+				"tpu":          nil,
+				"rpc":          nil,
+				"version":      nil,
+				"featureSet":   nil,
+				"shredVersion": nil,
+			},
+		},
+	})
+
+	nodes, err := sc.GetClusterNodes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []client.ClusterNode{
+		{
+			PublicKey:    "9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ",
+			Gossip:       "10.239.6.48:8001",
+			TPU:          "10.239.6.48:8856",
+			RPC:          "10.239.6.48:8899",
+			Version:      "1.14.17",
+			FeatureSet:   1879391783,
+			ShredVersion: 56177,
+		},
+		{
+			PublicKey: "7Np41oeYqPefeNQEHSv1UDhYrehxin3NStELsSKCT4K2",
+			Gossip:    "10.239.6.49:8001",
+		},
+	}, nodes)
+}
+
+func TestGetClusterNodes_Error(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{})
+
+	_, err := sc.GetClusterNodes(context.Background())
+	assert.ErrorIs(t, err, client.ErrGetClusterNodes)
+
+	_, err = sc.GetEpochInfo(context.Background())
+	assert.ErrorIs(t, err, client.ErrGetEpochInfo)
+
+	_, err = sc.GetBlockHeight(context.Background())
+	assert.ErrorIs(t, err, client.ErrGetBlockHeight)
+}
@@ -0,0 +1,73 @@
+package client_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/transaction"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClient_Concurrent runs the reads and the transaction builds sharing a single client
+// from many goroutines; run it with -race to detect the unsynchronized state.
+func TestClient_Concurrent(t *testing.T) {
+	mint := types.NewAccount()
+	payer := types.NewAccount()
+
+	c := newMockClient(t, map[string]interface{}{
+		"getAccountInfo":                    withContext(accountData(serializedMetadata(t, mint.PublicKey, "Shared", metaplex_token_metadata.Fungible))),
+		"getMinimumBalanceForRentExemption": 2039280,
+		"getLatestBlockhash": withContext(map[string]interface{}{
+			"blockhash":            types.NewAccount().PublicKey.ToBase58(),
+			"lastValidBlockHeight": 150,
+		}),
+	}, client.SetMetadataCache(nil))
+
+	const goroutines = 32
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*3)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if _, err := c.GetTokenMetadata(ctx, mint.PublicKey.ToBase58()); err != nil {
+				errs <- err
+			}
+			if i%2 == 0 {
+				c.InvalidateTokenMetadata(mint.PublicKey.ToBase58())
+			}
+
+			if _, err := c.GetMinimumBalanceForRentExemption(ctx, token.TokenAccountSize); err != nil {
+				errs <- err
+			}
+
+			_, err := transaction.NewTransactionBuilder(c).
+				SetFeePayer(payer.PublicKey).
+				AddSigner(payer).
+				AddInstruction(instructions.TransferSOL(instructions.TransferSOLParams{
+					Sender:    payer.PublicKey,
+					Recipient: types.NewAccount().PublicKey,
+					Amount:    uint64(i + 1),
+				})).
+				Build(ctx)
+			if err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// DASMaxLimit is the maximum number of assets per page of the DAS API.
+const DASMaxLimit = 1000
+
+type (
+	// DASAsset is a digital asset returned by the Digital Asset Standard (DAS) API.
+	DASAsset struct {
+		Interface   string         `json:"interface"` // e.g. "V1_NFT", "ProgrammableNFT", "FungibleToken"
+		ID          string         `json:"id"`        // asset id; the mint address of uncompressed assets
+		Content     DASContent     `json:"content"`
+		Authorities []DASAuthority `json:"authorities"`
+		Compression DASCompression `json:"compression"`
+		Grouping    []DASGrouping  `json:"grouping"`
+		Royalty     DASRoyalty     `json:"royalty"`
+		Creators    []DASCreator   `json:"creators"`
+		Ownership   DASOwnership   `json:"ownership"`
+		Supply      *DASSupply     `json:"supply"` // nil for fungible assets
+		Mutable     bool           `json:"mutable"`
+		Burnt       bool           `json:"burnt"`
+	}
+
+	// DASContent is the off-chain content of the asset.
+	DASContent struct {
+		Schema   string      `json:"$schema"`
+		JSONURI  string      `json:"json_uri"`
+		Metadata DASMetadata `json:"metadata"`
+		Files    []DASFile   `json:"files"`
+	}
+
+	// DASMetadata is the asset metadata.
+	DASMetadata struct {
+		Name          string `json:"name"`
+		Symbol        string `json:"symbol"`
+		Description   string `json:"description"`
+		TokenStandard string `json:"token_standard"`
+	}
+
+	// DASFile is a file of the asset content.
+	DASFile struct {
+		URI  string `json:"uri"`
+		Mime string `json:"mime"`
+	}
+
+	// DASAuthority is an authority of the asset.
+	DASAuthority struct {
+		Address string   `json:"address"`
+		Scopes  []string `json:"scopes"`
+	}
+
+	// DASCompression is the compression state of the asset.
+	// The fields are empty if the asset is not compressed.
+	DASCompression struct {
+		Eligible    bool   `json:"eligible"`
+		Compressed  bool   `json:"compressed"`
+		DataHash    string `json:"data_hash"`
+		CreatorHash string `json:"creator_hash"`
+		AssetHash   string `json:"asset_hash"`
+		Tree        string `json:"tree"` // merkle tree address
+		Seq         uint64 `json:"seq"`
+		LeafID      uint64 `json:"leaf_id"`
+	}
+
+	// DASGrouping is a group the asset belongs to, e.g. a collection.
+	DASGrouping struct {
+		GroupKey   string `json:"group_key"` // e.g. "collection"
+		GroupValue string `json:"group_value"`
+	}
+
+	// DASRoyalty is the royalty configuration of the asset.
+	DASRoyalty struct {
+		RoyaltyModel        string  `json:"royalty_model"`
+		Target              *string `json:"target"`
+		Percent             float64 `json:"percent"`
+		BasisPoints         uint16  `json:"basis_points"`
+		PrimarySaleHappened bool    `json:"primary_sale_happened"`
+		Locked              bool    `json:"locked"`
+	}
+
+	// DASCreator is a creator of the asset.
+	DASCreator struct {
+		Address  string `json:"address"`
+		Share    uint8  `json:"share"`
+		Verified bool   `json:"verified"`
+	}
+
+	// DASOwnership is the ownership of the asset.
+	DASOwnership struct {
+		Frozen         bool    `json:"frozen"`
+		Delegated      bool    `json:"delegated"`
+		Delegate       *string `json:"delegate"`
+		OwnershipModel string  `json:"ownership_model"` // "single" or "token"
+		Owner          string  `json:"owner"`
+	}
+
+	// DASSupply is the edition supply of the asset.
+	DASSupply struct {
+		PrintMaxSupply     *uint64 `json:"print_max_supply"`
+		PrintCurrentSupply uint64  `json:"print_current_supply"`
+		EditionNonce       *uint8  `json:"edition_nonce"`
+	}
+
+	// DASAssetProof is the merkle proof of a compressed asset,
+	// required to transfer or burn it.
+	DASAssetProof struct {
+		Root      string   `json:"root"`
+		Proof     []string `json:"proof"`
+		NodeIndex uint64   `json:"node_index"`
+		Leaf      string   `json:"leaf"`
+		TreeID    string   `json:"tree_id"`
+	}
+
+	// DASAssetList is a page of assets.
+	DASAssetList struct {
+		Total int        `json:"total"`
+		Limit int        `json:"limit"`
+		Page  int        `json:"page"`
+		Items []DASAsset `json:"items"`
+	}
+)
+
+// GetAsset returns the asset by its id via the DAS API.
+// Works for both compressed and uncompressed assets.
+// The DAS endpoint must be set, see SetDASEndpoint.
+func (c *Client) GetAsset(ctx context.Context, assetID string) (*DASAsset, error) {
+	if err := commonx.ValidateAccountAddr(assetID); err != nil {
+		return nil, utils.StackErrors(ErrGetAsset, err)
+	}
+
+	asset, err := dasCall[*DASAsset](ctx, c, "getAsset", map[string]interface{}{"id": assetID})
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetAsset, err)
+	}
+	if asset == nil {
+		return nil, utils.StackErrors(ErrGetAsset, ErrAccountNotFound)
+	}
+
+	return asset, nil
+}
+
+// GetAssetProof returns the merkle proof of the compressed asset via the DAS API.
+// The DAS endpoint must be set, see SetDASEndpoint.
+func (c *Client) GetAssetProof(ctx context.Context, assetID string) (*DASAssetProof, error) {
+	if err := commonx.ValidateAccountAddr(assetID); err != nil {
+		return nil, utils.StackErrors(ErrGetAssetProof, err)
+	}
+
+	proof, err := dasCall[*DASAssetProof](ctx, c, "getAssetProof", map[string]interface{}{"id": assetID})
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetAssetProof, err)
+	}
+	if proof == nil {
+		return nil, utils.StackErrors(ErrGetAssetProof, ErrAccountNotFound)
+	}
+
+	return proof, nil
+}
+
+// GetAssetsByOwner returns a page of the assets owned by the given wallet via the DAS API.
+// The page numbering starts from 1; the limit must be between 1 and DASMaxLimit.
+// The DAS endpoint must be set, see SetDASEndpoint.
+func (c *Client) GetAssetsByOwner(ctx context.Context, owner string, page, limit int) (*DASAssetList, error) {
+	if err := commonx.ValidateSolanaWalletAddr(owner); err != nil {
+		return nil, utils.StackErrors(ErrGetAssetsByOwner, err)
+	}
+	if page < 1 {
+		return nil, utils.StackErrors(ErrGetAssetsByOwner, fmt.Errorf("page must be greater than 0, got %d", page))
+	}
+	if limit < 1 || limit > DASMaxLimit {
+		return nil, utils.StackErrors(ErrGetAssetsByOwner, fmt.Errorf("limit must be between 1 and %d, got %d", DASMaxLimit, limit))
+	}
+
+	list, err := dasCall[DASAssetList](ctx, c, "getAssetsByOwner", map[string]interface{}{
+		"ownerAddress": owner,
+		"page":         page,
+		"limit":        limit,
+	})
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetAssetsByOwner, err)
+	}
+
+	return &list, nil
+}
+
+// dasCall calls the given DAS API method with the named params and decodes the result into T.
+// Returns the result or an error, including the JSON-RPC error returned by the node.
+func dasCall[T any](ctx context.Context, c *Client, method string, params map[string]interface{}) (T, error) {
+	var result T
+
+	if c.dasEndpoint == "" {
+		return result, ErrDASEndpointNotSet
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return result, fmt.Errorf("das call %s: failed to encode request: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.dasEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return result, fmt.Errorf("das call %s: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return result, fmt.Errorf("das call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpc.JsonRpcResponse[T]
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return result, fmt.Errorf("das call %s: failed to decode response (http status %d): %w", method, resp.StatusCode, err)
+	}
+	if rpcResp.Error != nil {
+		return result, rpcResp.Error
+	}
+
+	return rpcResp.Result, nil
+}
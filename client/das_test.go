@@ -0,0 +1,183 @@
+package client_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDASClient starts a fake DAS API node which responds to the given methods with the recorded responses
+// from the testdata directory, and returns a client connected to it.
+// The request params are passed to the params callback if it's not nil.
+func newDASClient(t *testing.T, responses map[string]string, params func(method string, params map[string]interface{})) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string                 `json:"method"`
+			Params map[string]interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if params != nil {
+			params(req.Method, req.Params)
+		}
+
+		file, ok := responses[req.Method]
+		if !ok {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"error":   map[string]interface{}{"code": -32601, "message": "method not found: " + req.Method},
+			})
+			return
+		}
+		data, err := os.ReadFile("testdata/" + file)
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	return newMockClient(t, map[string]interface{}{}, client.SetDASEndpoint(srv.URL))
+}
+
+func TestGetAsset(t *testing.T) {
+	const assetID = "JDuAmJY7ezTPWb8ZrYPMJ6Fn7G4gtPxxuC5FBnGVNEvN"
+
+	sc := newDASClient(t, map[string]string{"getAsset": "das_get_asset.json"}, func(method string, params map[string]interface{}) {
+		assert.Equal(t, map[string]interface{}{"id": assetID}, params)
+	})
+
+	asset, err := sc.GetAsset(context.Background(), assetID)
+	require.NoError(t, err)
+
+	assert.Equal(t, assetID, asset.ID)
+	assert.Equal(t, "V1_NFT", asset.Interface)
+	assert.Equal(t, "cNFT #1", asset.Content.Metadata.Name)
+	assert.Equal(t, "CNFT", asset.Content.Metadata.Symbol)
+	assert.Equal(t, "https://arweave.net/7Z6uJfTYbSxnCzp4UCQRkEd2Mz6kBMYBYBEZbQiCFRMk", asset.Content.JSONURI)
+	require.Len(t, asset.Content.Files, 1)
+	assert.Equal(t, "image/png", asset.Content.Files[0].Mime)
+
+	assert.True(t, asset.Compression.Compressed)
+	assert.Equal(t, "HsvbJ6mbDrNe8jyHGKoN4Bn9VAtBezzj96KWWUQHmSGB", asset.Compression.Tree)
+	assert.Equal(t, uint64(2030), asset.Compression.LeafID)
+	assert.Equal(t, "DxRxGXbQ6LMAWKk6zptWZHH4trcWPdYiBk7tVodNa6ha", asset.Compression.AssetHash)
+
+	assert.Equal(t, "FuQhSmAT6kAmmzCMiiYbzFcTQJFuu6raXAdCFibz4YPR", asset.Ownership.Owner)
+	assert.True(t, asset.Ownership.Delegated)
+	require.NotNil(t, asset.Ownership.Delegate)
+	assert.Equal(t, "RjpQLUttBMdoQ4HKMygScEjkd6S69dZZC9T4W3Z3DKD", *asset.Ownership.Delegate)
+
+	assert.Equal(t, []client.DASGrouping{{GroupKey: "collection", GroupValue: "5kyJBiH1ybSnhMniwr2CyaL7LitMKaLGA4HpGZtRcD6e"}}, asset.Grouping)
+	assert.Equal(t, uint16(500), asset.Royalty.BasisPoints)
+	assert.Equal(t, []client.DASCreator{{Address: "8Xp3CxmnwTbjYNKwsKEqgCSozqGWcDZHCWtAnxWb86oc", Share: 100, Verified: true}}, asset.Creators)
+	require.NotNil(t, asset.Supply)
+	require.NotNil(t, asset.Supply.PrintMaxSupply)
+	assert.Equal(t, uint64(0), *asset.Supply.PrintMaxSupply)
+	assert.True(t, asset.Mutable)
+	assert.False(t, asset.Burnt)
+}
+
+func TestGetAssetProof(t *testing.T) {
+	const assetID = "JDuAmJY7ezTPWb8ZrYPMJ6Fn7G4gtPxxuC5FBnGVNEvN"
+
+	sc := newDASClient(t, map[string]string{"getAssetProof": "das_get_asset_proof.json"}, nil)
+
+	proof, err := sc.GetAssetProof(context.Background(), assetID)
+	require.NoError(t, err)
+	assert.Equal(t, "8v5QB9YAqx5HD4ZgfCF5KcGRqVPAfgq6XXVJoSuQzD2Z", proof.Root)
+	assert.Len(t, proof.Proof, 3)
+	assert.Equal(t, uint64(18414), proof.NodeIndex)
+	assert.Equal(t, "HsvbJ6mbDrNe8jyHGKoN4Bn9VAtBezzj96KWWUQHmSGB", proof.TreeID)
+}
+
+func TestDAS_CompressedAssetID(t *testing.T) {
+	// the compressed NFT id is the bubblegum PDA of the tree and the leaf index, which is off the ed25519 curve
+	tree := common.PublicKeyFromString("HsvbJ6mbDrNe8jyHGKoN4Bn9VAtBezzj96KWWUQHmSGB")
+	leafIndex := make([]byte, 8)
+	binary.LittleEndian.PutUint64(leafIndex, 2030)
+	assetPubkey, _, err := common.FindProgramAddress(
+		[][]byte{[]byte("asset"), tree.Bytes(), leafIndex},
+		common.PublicKeyFromString("BGUMAp9Gq7iTEuizy4pqaxsTyUCBK68MDfK752saRPUY"),
+	)
+	require.NoError(t, err)
+	assetID := assetPubkey.ToBase58()
+	require.Error(t, commonx.ValidateSolanaWalletAddr(assetID))
+
+	var ids []interface{}
+	sc := newDASClient(t, map[string]string{
+		"getAsset":      "das_get_asset.json",
+		"getAssetProof": "das_get_asset_proof.json",
+	}, func(method string, params map[string]interface{}) {
+		ids = append(ids, params["id"])
+	})
+
+	_, err = sc.GetAsset(context.Background(), assetID)
+	require.NoError(t, err)
+	_, err = sc.GetAssetProof(context.Background(), assetID)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{assetID, assetID}, ids)
+}
+
+func TestGetAssetsByOwner(t *testing.T) {
+	const owner = "FuQhSmAT6kAmmzCMiiYbzFcTQJFuu6raXAdCFibz4YPR"
+
+	sc := newDASClient(t, map[string]string{"getAssetsByOwner": "das_get_assets_by_owner.json"}, func(method string, params map[string]interface{}) {
+		assert.Equal(t, map[string]interface{}{"ownerAddress": owner, "page": float64(1), "limit": float64(2)}, params)
+	})
+
+	list, err := sc.GetAssetsByOwner(context.Background(), owner, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+	assert.Equal(t, 1, list.Page)
+	require.Len(t, list.Items, 2)
+
+	assert.True(t, list.Items[0].Compression.Compressed)
+	assert.False(t, list.Items[1].Compression.Compressed)
+	assert.Equal(t, "ProgrammableNFT", list.Items[1].Interface)
+	assert.Equal(t, "pNFT #2", list.Items[1].Content.Metadata.Name)
+	assert.Nil(t, list.Items[1].Ownership.Delegate)
+	assert.Nil(t, list.Items[1].Supply.PrintMaxSupply)
+	for _, item := range list.Items {
+		assert.Equal(t, owner, item.Ownership.Owner)
+	}
+
+	_, err = sc.GetAssetsByOwner(context.Background(), owner, 0, 10)
+	require.ErrorIs(t, err, client.ErrGetAssetsByOwner)
+	_, err = sc.GetAssetsByOwner(context.Background(), owner, 1, client.DASMaxLimit+1)
+	require.ErrorIs(t, err, client.ErrGetAssetsByOwner)
+	_, err = sc.GetAssetsByOwner(context.Background(), "invalid", 1, 10)
+	require.ErrorIs(t, err, client.ErrGetAssetsByOwner)
+}
+
+func TestDAS_Errors(t *testing.T) {
+	const assetID = "JDuAmJY7ezTPWb8ZrYPMJ6Fn7G4gtPxxuC5FBnGVNEvN"
+
+	// the DAS endpoint is not set
+	sc := newMockClient(t, map[string]interface{}{})
+	_, err := sc.GetAsset(context.Background(), assetID)
+	require.ErrorIs(t, err, client.ErrGetAsset)
+	require.ErrorIs(t, err, client.ErrDASEndpointNotSet)
+
+	// the DAS method is not supported
+	sc = newDASClient(t, map[string]string{}, nil)
+	_, err = sc.GetAsset(context.Background(), assetID)
+	require.ErrorIs(t, err, client.ErrGetAsset)
+	var rpcErr *rpc.JsonRpcError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32601, rpcErr.Code)
+
+	_, err = sc.GetAsset(context.Background(), "invalid")
+	require.ErrorIs(t, err, client.ErrGetAsset)
+}
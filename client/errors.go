@@ -37,9 +37,13 @@ var (
 	ErrDeriveAccountFromMnemonicBip39      = errors.New("failed to derive account from mnemonic bip39")
 	ErrGetAtaBalance                       = errors.New("failed to get associated token account balance")
 	ErrWithoutInsufficientFound            = errors.New("you need to send enough SOL to make the account rent-exempt")
+	ErrInsufficientFunds                   = errors.New("insufficient funds to pay for the transaction")
+	ErrBlockhashNotFound                   = errors.New("blockhash not found")
+	ErrBlockhashExpired                    = errors.New("transaction blockhash has expired")
 	ErrWaitForTransaction                  = errors.New("failed to wait for transaction status")
 	ErrContextDone                         = errors.New("context done")
 	ErrGetTokenAccount                     = errors.New("failed to get token account")
+	ErrTokenAccountNotFound                = errors.New("token account not found")
 	ErrDeriveTokenAccount                  = errors.New("failed to derive associated token account")
 	ErrGetMintInfo                         = errors.New("failed to get mint info")
 	ErrGetTokenSupply                      = errors.New("failed to get token supply")
@@ -49,8 +53,51 @@ var (
 	ErrMaxSupplyReached                    = errors.New("max edition supply is already printed")
 	ErrTokenIsNotMasterEdition             = errors.New("token is not master edition")
 	ErrGetMasterEditionCurrentSupply       = errors.New("failed to get master edition current supply")
+	ErrGetMasterEditionState               = errors.New("failed to get master edition state")
 	ErrNewDurableTransaction               = errors.New("failed to create new durable transaction")
 	ErrNoTransactionsFound                 = errors.New("no transactions found")
 	ErrTransactionNotFound                 = errors.New("transaction not found")
+	ErrTooManyTransactions                 = errors.New("too many transactions to find the oldest one")
 	ErrTransactionNotConfirmed             = errors.New("transaction not confirmed yet")
+	ErrGetTokenLargestAccounts             = errors.New("failed to get token largest accounts")
+	ErrUnsupportedTokenProgram             = errors.New("mint account is not owned by a supported token program")
+	ErrIterateSignatures                   = errors.New("failed to iterate signatures")
+	ErrGetTokenTransferHistory             = errors.New("failed to get token transfer history")
+	ErrGetProgramAccounts                  = errors.New("failed to get program accounts")
+	ErrGetMintsByUpdateAuthority           = errors.New("failed to get mints by update authority")
+	ErrGetAccountInfo                      = errors.New("failed to get account info")
+	ErrAccountNotFound                     = errors.New("account not found")
+	ErrWaitForAccount                      = errors.New("failed to wait for account")
+	ErrWaitForAccountTimeout               = errors.New("account was not created within the max duration")
+	ErrGetEpochInfo                        = errors.New("failed to get epoch info")
+	ErrGetBlockHeight                      = errors.New("failed to get block height")
+	ErrGetClusterNodes                     = errors.New("failed to get cluster nodes")
+	ErrGetHealth                           = errors.New("failed to get node health")
+	ErrNodeUnhealthy                       = errors.New("node is unhealthy")
+	ErrGetVersion                          = errors.New("failed to get node version")
+	ErrDASEndpointNotSet                   = errors.New("digital asset standard api endpoint is not set")
+	ErrGetAsset                            = errors.New("failed to get asset")
+	ErrGetAssetProof                       = errors.New("failed to get asset proof")
+	ErrGetAssetsByOwner                    = errors.New("failed to get assets by owner")
+	ErrResolveSNSDomain                    = errors.New("failed to resolve solana name service domain")
+	ErrGetFavoriteDomain                   = errors.New("failed to get favorite domain")
+	ErrSNSDomainNotFound                   = errors.New("solana name service domain not found")
+	ErrSendAndConfirmTransaction           = errors.New("failed to send and confirm transaction")
+	ErrSimulateTransaction                 = errors.New("failed to simulate transaction")
+	ErrGetRecentPrioritizationFees         = errors.New("failed to get recent prioritization fees")
+	ErrGetTokenRecord                      = errors.New("failed to get token record")
+	ErrTokenRecordNotFound                 = errors.New("token record not found")
+	ErrWSEndpointNotSet                    = errors.New("websocket endpoint is not set")
+	ErrWatchBalance                        = errors.New("failed to watch balance")
+	ErrSubscribeLogs                       = errors.New("failed to subscribe to logs")
+	ErrGetSolBalances                      = errors.New("failed to get SOL balances")
+	ErrCheckCollectionAuthority            = errors.New("failed to check collection authority")
+	ErrGetNFTOnChainState                  = errors.New("failed to get NFT on-chain state")
+	ErrGetTransactionBalanceChanges        = errors.New("failed to get transaction balance changes")
+	ErrGetTransferFee                      = errors.New("failed to get transfer fee")
+	ErrGetTokenHoldersCount                = errors.New("failed to get token holders count")
+	ErrIsVerifiedCollectionItem            = errors.New("failed to check verified collection item")
+	ErrEstimateTransactionCost             = errors.New("failed to estimate transaction cost")
+	ErrGetTokenUses                        = errors.New("failed to get token uses")
+	ErrGetUseAuthorities                   = errors.New("failed to get use authorities")
 )
@@ -0,0 +1,18 @@
+package client
+
+import "time"
+
+// Clock exposes the clock interface to the tests.
+type Clock = clock
+
+// WithClock sets the clock used by the polling loops.
+func WithClock(clk Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clk
+	}
+}
+
+// SetNow sets the time source of the cache.
+func (c *LRUMetadataCache) SetNow(now func() time.Time) {
+	c.now = now
+}
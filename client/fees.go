@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// MaxPrioritizationFeesAccounts is the maximum number of accounts accepted by GetRecentPrioritizationFees.
+const MaxPrioritizationFeesAccounts = 128
+
+// PrioritizationFee is the prioritization fee paid in a recent slot.
+type PrioritizationFee struct {
+	Slot              uint64 // slot in which the fee was observed
+	PrioritizationFee uint64 // per-compute-unit fee paid by at least one transaction in the slot, in micro-lamports
+}
+
+// GetRecentPrioritizationFees returns the prioritization fees of the recent slots (up to 150).
+// If accounts are given, the fees are the minimum fees paid by the transactions
+// which locked all of the accounts as writable; otherwise, the minimum fees paid in the slots.
+// Returns the list of fees or an error.
+func (c *Client) GetRecentPrioritizationFees(ctx context.Context, accounts ...common.PublicKey) ([]PrioritizationFee, error) {
+	if len(accounts) > MaxPrioritizationFeesAccounts {
+		return nil, utils.StackErrors(
+			ErrGetRecentPrioritizationFees,
+			fmt.Errorf("too many accounts: %d, max %d", len(accounts), MaxPrioritizationFeesAccounts),
+		)
+	}
+
+	addrs := make([]string, 0, len(accounts))
+	for _, acc := range accounts {
+		addrs = append(addrs, acc.ToBase58())
+	}
+
+	fees, err := rpcCall[[]struct {
+		Slot              uint64 `json:"slot"`
+		PrioritizationFee uint64 `json:"prioritizationFee"`
+	}](ctx, c, "getRecentPrioritizationFees", addrs)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetRecentPrioritizationFees, err)
+	}
+
+	result := make([]PrioritizationFee, 0, len(fees))
+	for _, fee := range fees {
+		result = append(result, PrioritizationFee{Slot: fee.Slot, PrioritizationFee: fee.PrioritizationFee})
+	}
+
+	return result, nil
+}
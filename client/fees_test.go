@@ -0,0 +1,54 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecentPrioritizationFees(t *testing.T) {
+	account := types.NewAccount().PublicKey
+
+	var addrs []string
+	sc := newMockClient(t, map[string]interface{}{
+		"getRecentPrioritizationFees": func(params []json.RawMessage) interface{} {
+			require.Len(t, params, 1)
+			require.NoError(t, json.Unmarshal(params[0], &addrs))
+			return []map[string]interface{}{
+				{"slot": 348125, "prioritizationFee": 0},
+				{"slot": 348126, "prioritizationFee": 1000},
+			}
+		},
+	})
+
+	fees, err := sc.GetRecentPrioritizationFees(context.Background(), account)
+	require.NoError(t, err)
+	assert.Equal(t, []client.PrioritizationFee{
+		{Slot: 348125, PrioritizationFee: 0},
+		{Slot: 348126, PrioritizationFee: 1000},
+	}, fees)
+	assert.Equal(t, []string{account.ToBase58()}, addrs)
+
+	_, err = sc.GetRecentPrioritizationFees(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, addrs)
+}
+
+func TestGetRecentPrioritizationFees_Errors(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{
+		"getRecentPrioritizationFees": &rpc.JsonRpcError{Code: -32602, Message: "Invalid params"},
+	})
+
+	_, err := sc.GetRecentPrioritizationFees(context.Background(), types.NewAccount().PublicKey)
+	require.ErrorIs(t, err, client.ErrGetRecentPrioritizationFees)
+
+	_, err = sc.GetRecentPrioritizationFees(context.Background(), make([]common.PublicKey, client.MaxPrioritizationFeesAccounts+1)...)
+	require.ErrorIs(t, err, client.ErrGetRecentPrioritizationFees)
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// rpcErrNodeUnhealthy is the JSON-RPC error code returned by the unhealthy node.
+const rpcErrNodeUnhealthy = -32005
+
+type (
+	// NodeUnhealthyError describes why the RPC node is unhealthy.
+	NodeUnhealthyError struct {
+		NumSlotsBehind *uint64 // number of slots the node is behind the cluster; nil if unknown
+		Message        string  // message returned by the node
+	}
+
+	// SolanaVersion represents the software version running on the RPC node.
+	SolanaVersion struct {
+		SolanaCore string // software version of solana-core, e.g. "1.14.17"
+		FeatureSet uint32 // unique identifier of the current software's feature set; 0 if not available
+	}
+)
+
+// Error returns the error message.
+func (e *NodeUnhealthyError) Error() string {
+	if e.NumSlotsBehind != nil {
+		return fmt.Sprintf("node is behind by %d slots", *e.NumSlotsBehind)
+	}
+	return e.Message
+}
+
+// GetHealth returns true if the RPC node is healthy.
+// Returns false and ErrNodeUnhealthy stacked with *NodeUnhealthyError if the node is behind the cluster or its state is unknown.
+// Returns false and ErrGetHealth if the request failed.
+func (c *Client) GetHealth(ctx context.Context) (bool, error) {
+	health, err := rpcCall[string](ctx, c, "getHealth")
+	if err != nil {
+		var rpcErr *rpc.JsonRpcError
+		if errors.As(err, &rpcErr) && rpcErr.Code == rpcErrNodeUnhealthy {
+			return false, utils.StackErrors(ErrNodeUnhealthy, nodeUnhealthyError(rpcErr))
+		}
+		return false, utils.StackErrors(ErrGetHealth, err)
+	}
+	if health != "ok" {
+		return false, utils.StackErrors(ErrNodeUnhealthy, &NodeUnhealthyError{Message: health})
+	}
+
+	return true, nil
+}
+
+// GetVersion returns the software version running on the RPC node.
+func (c *Client) GetVersion(ctx context.Context) (SolanaVersion, error) {
+	version, err := rpcCall[rpc.GetVersion](ctx, c, "getVersion")
+	if err != nil {
+		return SolanaVersion{}, utils.StackErrors(ErrGetVersion, err)
+	}
+
+	result := SolanaVersion{SolanaCore: version.SolanaCore}
+	if version.FeatureSet != nil {
+		result.FeatureSet = *version.FeatureSet
+	}
+
+	return result, nil
+}
+
+// nodeUnhealthyError converts the JSON-RPC error of the unhealthy node.
+func nodeUnhealthyError(rpcErr *rpc.JsonRpcError) *NodeUnhealthyError {
+	result := &NodeUnhealthyError{Message: rpcErr.Message}
+
+	// the data is {"numSlotsBehind": N} or {"numSlotsBehind": null} if it's unknown
+	raw, err := json.Marshal(rpcErr.Data)
+	if err != nil {
+		return result
+	}
+	var data struct {
+		NumSlotsBehind *uint64 `json:"numSlotsBehind"`
+	}
+	if err := json.Unmarshal(raw, &data); err == nil {
+		result.NumSlotsBehind = data.NumSlotsBehind
+	}
+
+	return result
+}
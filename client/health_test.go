@@ -0,0 +1,82 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHealth(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{"getHealth": "ok"})
+
+		healthy, err := sc.GetHealth(context.Background())
+		require.NoError(t, err)
+		assert.True(t, healthy)
+	})
+
+	t.Run("behind", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getHealth": &rpc.JsonRpcError{
+				Code:    -32005,
+				Message: "Node is behind by 42 slots",
+				Data:    map[string]interface{}{"numSlotsBehind": 42},
+			},
+		})
+
+		healthy, err := sc.GetHealth(context.Background())
+		assert.False(t, healthy)
+		assert.ErrorIs(t, err, client.ErrNodeUnhealthy)
+
+		var unhealthy *client.NodeUnhealthyError
+		require.True(t, errors.As(err, &unhealthy))
+		require.NotNil(t, unhealthy.NumSlotsBehind)
+		assert.Equal(t, uint64(42), *unhealthy.NumSlotsBehind)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getHealth": &rpc.JsonRpcError{
+				Code:    -32005,
+				Message: "Node is unhealthy",
+				Data:    map[string]interface{}{"numSlotsBehind": nil},
+			},
+		})
+
+		healthy, err := sc.GetHealth(context.Background())
+		assert.False(t, healthy)
+		assert.ErrorIs(t, err, client.ErrNodeUnhealthy)
+
+		var unhealthy *client.NodeUnhealthyError
+		require.True(t, errors.As(err, &unhealthy))
+		assert.Nil(t, unhealthy.NumSlotsBehind)
+		assert.Equal(t, "Node is unhealthy", unhealthy.Message)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{})
+
+		healthy, err := sc.GetHealth(context.Background())
+		assert.False(t, healthy)
+		assert.ErrorIs(t, err, client.ErrGetHealth)
+		assert.False(t, errors.Is(err, client.ErrNodeUnhealthy))
+	})
+}
+
+func TestGetVersion(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{
+		"getVersion": map[string]interface{}{"solana-core": "1.14.17", "feature-set": 1879391783},
+	})
+
+	version, err := sc.GetVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, client.SolanaVersion{SolanaCore: "1.14.17", FeatureSet: 1879391783}, version)
+
+	_, err = newMockClient(t, map[string]interface{}{}).GetVersion(context.Background())
+	assert.ErrorIs(t, err, client.ErrGetVersion)
+}
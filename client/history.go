@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/types"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// defaultTokenTransferHistoryLimit is the default number of the wallet transactions to scan.
+const defaultTokenTransferHistoryLimit = 100
+
+// TokenTransferDirection is the direction of the token transfer relative to the wallet.
+type TokenTransferDirection string
+
+// TokenTransferDirection enum.
+const (
+	TokenTransferIncoming TokenTransferDirection = "incoming"
+	TokenTransferOutgoing TokenTransferDirection = "outgoing"
+)
+
+// TokenTransfer represents a token transfer which changed the wallet balance of the token.
+type TokenTransfer struct {
+	Signature    string                 // base58 encoded transaction signature
+	BlockTime    int64                  // unix timestamp of the transaction; 0 if not available
+	Mint         string                 // base58 encoded token mint address
+	Direction    TokenTransferDirection // incoming or outgoing
+	Counterparty string                 // base58 encoded owner of the other side; empty for mints and burns
+	Amount       types.TokenAmount      // absolute amount of the transfer
+}
+
+// GetTokenTransferHistory returns the transfers of the given token mint made by the given wallet,
+// from the newest to the oldest one.
+// Scans up to limit of the most recent wallet transactions, 100 by default; failed transactions are skipped.
+// Note: the transfers to the wallet's token account made without the wallet involvement
+// are not included, since such transactions do not refer to the wallet address.
+func (c *Client) GetTokenTransferHistory(ctx context.Context, wallet, mint string, limit int) ([]TokenTransfer, error) {
+	if err := common.ValidateSolanaWalletAddr(wallet); err != nil {
+		return nil, utils.StackErrors(ErrGetTokenTransferHistory, err)
+	}
+	if err := common.ValidateSolanaWalletAddr(mint); err != nil {
+		return nil, utils.StackErrors(ErrGetTokenTransferHistory, err)
+	}
+	if limit <= 0 {
+		limit = defaultTokenTransferHistoryLimit
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	signatures, errs := c.IterateSignatures(ctx, wallet, IterateOptions{Limit: limit})
+
+	result := make([]TokenTransfer, 0)
+	for sig := range signatures {
+		if sig.Err != nil {
+			continue
+		}
+
+		tx, err := c.GetTransaction(ctx, sig.Signature)
+		if err != nil {
+			return nil, utils.StackErrors(ErrGetTokenTransferHistory, err)
+		}
+
+		transfer, ok, err := parseTokenTransfer(tx.Meta, wallet, mint)
+		if err != nil {
+			return nil, utils.StackErrors(ErrGetTokenTransferHistory, err)
+		}
+		if !ok {
+			continue
+		}
+
+		transfer.Signature = sig.Signature
+		transfer.BlockTime = sig.BlockTime
+		result = append(result, transfer)
+	}
+	if err := <-errs; err != nil {
+		return nil, utils.StackErrors(ErrGetTokenTransferHistory, err)
+	}
+
+	return result, nil
+}
+
+// parseTokenTransfer parses the change of the wallet token balance from the transaction meta.
+// Returns false if the transaction didn't change the wallet balance of the given mint.
+func parseTokenTransfer(meta *client.TransactionMeta, wallet, mint string) (TokenTransfer, bool, error) {
+	type balance struct {
+		pre, post uint64
+	}
+
+	var decimals uint8
+	balances := make(map[string]*balance)
+	owners := make([]string, 0)
+
+	collect := func(tokenBalances []rpc.TransactionMetaTokenBalance, post bool) error {
+		for _, b := range tokenBalances {
+			if b.Mint != mint || b.Owner == "" {
+				continue
+			}
+			amount, err := strconv.ParseUint(b.UITokenAmount.Amount, 10, 64)
+			if err != nil {
+				return err
+			}
+
+			bal, ok := balances[b.Owner]
+			if !ok {
+				bal = &balance{}
+				balances[b.Owner] = bal
+				owners = append(owners, b.Owner)
+			}
+			if post {
+				bal.post += amount
+			} else {
+				bal.pre += amount
+			}
+			decimals = b.UITokenAmount.Decimals
+		}
+		return nil
+	}
+	if err := collect(meta.PreTokenBalances, false); err != nil {
+		return TokenTransfer{}, false, err
+	}
+	if err := collect(meta.PostTokenBalances, true); err != nil {
+		return TokenTransfer{}, false, err
+	}
+
+	own, ok := balances[wallet]
+	if !ok || own.pre == own.post {
+		return TokenTransfer{}, false, nil
+	}
+
+	transfer := TokenTransfer{Mint: mint, Direction: TokenTransferIncoming}
+	amount := own.post - own.pre
+	if own.pre > own.post {
+		transfer.Direction = TokenTransferOutgoing
+		amount = own.pre - own.post
+	}
+	transfer.Amount = types.NewTokenAmountFromLamports(amount, decimals)
+
+	// the counterparty is the owner whose balance changed the most in the opposite direction
+	var counterpartyAmount uint64
+	for _, owner := range owners {
+		bal := balances[owner]
+		var change uint64
+		switch {
+		case transfer.Direction == TokenTransferIncoming && bal.pre > bal.post:
+			change = bal.pre - bal.post
+		case transfer.Direction == TokenTransferOutgoing && bal.post > bal.pre:
+			change = bal.post - bal.pre
+		}
+		if change > counterpartyAmount {
+			transfer.Counterparty, counterpartyAmount = owner, change
+		}
+	}
+
+	return transfer, true, nil
+}
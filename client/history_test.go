@@ -0,0 +1,201 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/memo"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodedTestTransaction returns a base64 encoded signed transaction of the given fee payer.
+func encodedTestTransaction(t *testing.T, payer types.Account) string {
+	t.Helper()
+
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        payer.PublicKey,
+			RecentBlockhash: types.NewAccount().PublicKey.ToBase58(),
+			Instructions:    []types.Instruction{memo.BuildMemo(memo.BuildMemoParam{Memo: []byte("test")})},
+		}),
+		Signers: []types.Account{payer},
+	})
+	require.NoError(t, err)
+
+	rawTx, err := utils.EncodeTransaction(tx)
+	require.NoError(t, err)
+
+	return rawTx
+}
+
+func TestGetTokenTransferHistory(t *testing.T) {
+	wallet := types.NewAccount()
+	alice := types.NewAccount().PublicKey.ToBase58()
+	bob := types.NewAccount().PublicKey.ToBase58()
+	mint := types.NewAccount().PublicKey.ToBase58()
+	otherMint := types.NewAccount().PublicKey.ToBase58()
+	owner := wallet.PublicKey.ToBase58()
+	rawTx := encodedTestTransaction(t, wallet)
+
+	// fixtures from the newest to the oldest transaction
+	fixtures := []struct {
+		signature string
+		err       interface{}
+		pre, post []rpc.TransactionMetaTokenBalance
+	}{
+		{
+			// outgoing transfer to bob
+			signature: "sig0",
+			pre: []rpc.TransactionMetaTokenBalance{
+				tokenBalance(common.TokenProgramID, mint, owner, "1500"),
+				tokenBalance(common.TokenProgramID, mint, bob, "0"),
+			},
+			post: []rpc.TransactionMetaTokenBalance{
+				tokenBalance(common.TokenProgramID, mint, owner, "1000"),
+				tokenBalance(common.TokenProgramID, mint, bob, "500"),
+			},
+		},
+		{
+			// transfer of the other mint
+			signature: "sig1",
+			pre:       []rpc.TransactionMetaTokenBalance{tokenBalance(common.TokenProgramID, otherMint, owner, "0")},
+			post:      []rpc.TransactionMetaTokenBalance{tokenBalance(common.TokenProgramID, otherMint, owner, "42")},
+		},
+		{
+			// failed transaction
+			signature: "sig2",
+			err:       map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}},
+		},
+		{
+			// incoming transfer from alice alongside the other mint
+			signature: "sig3",
+			pre: []rpc.TransactionMetaTokenBalance{
+				tokenBalance(common.TokenProgramID, mint, alice, "2000"),
+				tokenBalance(common.TokenProgramID, otherMint, owner, "100"),
+			},
+			post: []rpc.TransactionMetaTokenBalance{
+				tokenBalance(common.TokenProgramID, mint, alice, "500"),
+				tokenBalance(common.TokenProgramID, mint, owner, "1500"),
+				tokenBalance(common.TokenProgramID, otherMint, owner, "0"),
+			},
+		},
+		{
+			// mint to the wallet
+			signature: "sig4",
+			post:      []rpc.TransactionMetaTokenBalance{tokenBalance(common.TokenProgramID, mint, owner, "7")},
+		},
+	}
+
+	signatures := make([]rpc.SignatureWithStatus, 0, len(fixtures))
+	transactions := make(map[string]interface{}, len(fixtures))
+	for i, f := range fixtures {
+		blockTime := int64(1700000000 - i)
+		signatures = append(signatures, rpc.SignatureWithStatus{
+			Signature: f.signature,
+			Slot:      uint64(100 - i),
+			BlockTime: &blockTime,
+			Err:       f.err,
+		})
+
+		pre, post := f.pre, f.post
+		if pre == nil {
+			pre = []rpc.TransactionMetaTokenBalance{}
+		}
+		if post == nil {
+			post = []rpc.TransactionMetaTokenBalance{}
+		}
+		transactions[f.signature] = map[string]interface{}{
+			"slot":        100 - i,
+			"blockTime":   blockTime,
+			"transaction": []string{rawTx, "base64"},
+			"meta": rpc.TransactionMeta{
+				Err:               f.err,
+				PreBalances:       []int64{10000, 1},
+				PostBalances:      []int64{5000, 1},
+				PreTokenBalances:  pre,
+				PostTokenBalances: post,
+			},
+		}
+	}
+
+	newClient := func(t *testing.T, requested *[]string) *client.Client {
+		return newMockClient(t, map[string]interface{}{
+			"getSignaturesForAddress": func(params []json.RawMessage) interface{} {
+				var cfg rpc.GetSignaturesForAddressConfig
+				require.NoError(t, json.Unmarshal(params[1], &cfg))
+				if cfg.Limit < len(signatures) {
+					return signatures[:cfg.Limit]
+				}
+				return signatures
+			},
+			"getTransaction": func(params []json.RawMessage) interface{} {
+				var signature string
+				require.NoError(t, json.Unmarshal(params[0], &signature))
+				*requested = append(*requested, signature)
+				return transactions[signature]
+			},
+		})
+	}
+
+	t.Run("all transactions", func(t *testing.T) {
+		var requested []string
+		transfers, err := newClient(t, &requested).GetTokenTransferHistory(context.Background(), owner, mint, 10)
+		require.NoError(t, err)
+		require.Len(t, transfers, 3)
+
+		assert.Equal(t, "sig0", transfers[0].Signature)
+		assert.Equal(t, int64(1700000000), transfers[0].BlockTime)
+		assert.Equal(t, mint, transfers[0].Mint)
+		assert.Equal(t, client.TokenTransferOutgoing, transfers[0].Direction)
+		assert.Equal(t, bob, transfers[0].Counterparty)
+		assert.Equal(t, uint64(500), transfers[0].Amount.Amount)
+		assert.Equal(t, uint8(6), transfers[0].Amount.Decimals)
+
+		assert.Equal(t, "sig3", transfers[1].Signature)
+		assert.Equal(t, client.TokenTransferIncoming, transfers[1].Direction)
+		assert.Equal(t, alice, transfers[1].Counterparty)
+		assert.Equal(t, uint64(1500), transfers[1].Amount.Amount)
+
+		assert.Equal(t, "sig4", transfers[2].Signature)
+		assert.Equal(t, client.TokenTransferIncoming, transfers[2].Direction)
+		assert.Empty(t, transfers[2].Counterparty)
+		assert.Equal(t, uint64(7), transfers[2].Amount.Amount)
+
+		// the failed transaction is not fetched
+		assert.Equal(t, []string{"sig0", "sig1", "sig3", "sig4"}, requested)
+	})
+
+	t.Run("limited scan depth", func(t *testing.T) {
+		var requested []string
+		transfers, err := newClient(t, &requested).GetTokenTransferHistory(context.Background(), owner, mint, 2)
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+		assert.Equal(t, "sig0", transfers[0].Signature)
+		assert.Equal(t, []string{"sig0", "sig1"}, requested)
+	})
+
+	t.Run("other mint", func(t *testing.T) {
+		var requested []string
+		transfers, err := newClient(t, &requested).GetTokenTransferHistory(context.Background(), owner, otherMint, 10)
+		require.NoError(t, err)
+		require.Len(t, transfers, 2)
+		assert.Equal(t, "sig1", transfers[0].Signature)
+		assert.Equal(t, client.TokenTransferIncoming, transfers[0].Direction)
+		assert.Equal(t, "sig3", transfers[1].Signature)
+		assert.Equal(t, client.TokenTransferOutgoing, transfers[1].Direction)
+		assert.Equal(t, uint64(100), transfers[1].Amount.Amount)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		var requested []string
+		_, err := newClient(t, &requested).GetTokenTransferHistory(context.Background(), "invalid", mint, 10)
+		require.ErrorIs(t, err, client.ErrGetTokenTransferHistory)
+	})
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// LogNotification is the logs of the transaction delivered by SubscribeLogs.
+type LogNotification struct {
+	Slot      uint64            `json:"slot"`      // slot of the transaction
+	Signature string            `json:"signature"` // base58 encoded transaction signature
+	Err       *TransactionError `json:"err"`       // transaction error; nil if the transaction succeeded
+	Logs      []string          `json:"logs"`      // transaction log messages
+}
+
+// SubscribeLogs subscribes to the logs of the transactions mentioning the given base58 encoded public key,
+// e.g. a mint or a program, and sends them to the returned channel.
+// The subscription is restored when the connection fails; the transactions processed meanwhile are missed.
+// Cancel the context to unsubscribe; the channel is closed when the context is done.
+// Requires the websocket endpoint, see SetWSEndpoint.
+func (c *Client) SubscribeLogs(ctx context.Context, mention string) (<-chan LogNotification, error) {
+	if err := common.ValidateAccountAddr(mention); err != nil {
+		return nil, utils.StackErrors(ErrSubscribeLogs, err)
+	}
+
+	logs, err := resubscribe[rpc.ValueWithContext[struct {
+		Signature string            `json:"signature"`
+		Err       *TransactionError `json:"err"`
+		Logs      []string          `json:"logs"`
+	}]](ctx, c, "logsSubscribe", map[string]interface{}{
+		"mentions": []string{mention},
+	}, struct {
+		Commitment rpc.Commitment `json:"commitment,omitempty"`
+	}{
+		Commitment: c.getCommitment(nil),
+	})
+	if err != nil {
+		return nil, utils.StackErrors(ErrSubscribeLogs, err)
+	}
+
+	result := make(chan LogNotification)
+	go func() {
+		defer close(result)
+
+		for log := range logs {
+			select {
+			case result <- LogNotification{
+				Slot:      log.Context.Slot,
+				Signature: log.Value.Signature,
+				Err:       log.Value.Err,
+				Logs:      log.Value.Logs,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return result, nil
+}
@@ -0,0 +1,177 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/solana/client"
+	sdktypes "github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// immediateClock fires the timers immediately and records their durations.
+type immediateClock struct {
+	mu     sync.Mutex
+	delays []time.Duration
+}
+
+func (c *immediateClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	return make(chan time.Time), func() {}
+}
+
+func (c *immediateClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.delays = append(c.delays, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+// logsNotification returns the logs subscription notification of the given transaction.
+func logsNotification(subscription uint64, signature string, err interface{}, logs ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "logsNotification",
+		"params": map[string]interface{}{
+			"subscription": subscription,
+			"result": withContext(map[string]interface{}{
+				"signature": signature,
+				"err":       err,
+				"logs":      logs,
+			}),
+		},
+	}
+}
+
+func TestSubscribeLogs(t *testing.T) {
+	mint := sdktypes.NewAccount().PublicKey.ToBase58()
+
+	t.Run("notifications and reconnection", func(t *testing.T) {
+		var (
+			mu          sync.Mutex
+			connections int
+		)
+		requests := make(chan wsRequest, 2)
+		ws := newMockWSServer(t, func(conn *websocket.Conn) {
+			var req wsRequest
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				return
+			}
+			requests <- req
+
+			mu.Lock()
+			connections++
+			n := connections
+			mu.Unlock()
+
+			_ = websocket.JSON.Send(conn, map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": n})
+			if n == 1 {
+				// the first connection is dropped after the notification
+				_ = websocket.JSON.Send(conn, logsNotification(1, "sig1", nil, "Program log: first"))
+				return
+			}
+			_ = websocket.JSON.Send(conn, logsNotification(uint64(n), "sig2",
+				map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 1}}},
+				"Program log: second", "Program failed",
+			))
+
+			var discard json.RawMessage
+			_ = websocket.JSON.Receive(conn, &discard)
+		})
+		clk := &immediateClock{}
+		c := newMockClient(t, map[string]interface{}{}, ws, client.WithClock(clk))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		logs, err := c.SubscribeLogs(ctx, mint)
+		require.NoError(t, err)
+
+		req := <-requests
+		require.Equal(t, "logsSubscribe", req.Method)
+		require.Len(t, req.Params, 2)
+		require.JSONEq(t, `{"mentions":["`+mint+`"]}`, string(req.Params[0]))
+		require.JSONEq(t, `{}`, string(req.Params[1]))
+
+		receive := func() client.LogNotification {
+			select {
+			case log := <-logs:
+				return log
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for the logs")
+			}
+			return client.LogNotification{}
+		}
+
+		first := receive()
+		require.Equal(t, "sig1", first.Signature)
+		require.EqualValues(t, 1, first.Slot)
+		require.Nil(t, first.Err)
+		require.Equal(t, []string{"Program log: first"}, first.Logs)
+
+		second := receive()
+		require.Equal(t, "sig2", second.Signature)
+		require.NotNil(t, second.Err)
+		require.Equal(t, client.TransactionErrorInstructionError, second.Err.Type)
+		require.NotNil(t, second.Err.CustomCode)
+		require.EqualValues(t, 1, *second.Err.CustomCode)
+		require.Equal(t, []string{"Program log: second", "Program failed"}, second.Logs)
+
+		// the subscription is restored with the same params
+		req = <-requests
+		require.Equal(t, "logsSubscribe", req.Method)
+		require.JSONEq(t, `{"mentions":["`+mint+`"]}`, string(req.Params[0]))
+
+		clk.mu.Lock()
+		require.Equal(t, []time.Duration{time.Second}, clk.delays)
+		clk.mu.Unlock()
+
+		cancel()
+		select {
+		case _, ok := <-logs:
+			require.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the channel isn't closed after the context is done")
+		}
+	})
+
+	t.Run("subscription error", func(t *testing.T) {
+		ws := newMockWSServer(t, func(conn *websocket.Conn) {
+			var req wsRequest
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				return
+			}
+			_ = websocket.JSON.Send(conn, map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"error":   map[string]interface{}{"code": -32602, "message": "Invalid params"},
+			})
+		})
+		c := newMockClient(t, map[string]interface{}{}, ws)
+
+		_, err := c.SubscribeLogs(context.Background(), mint)
+		require.ErrorIs(t, err, client.ErrSubscribeLogs)
+		require.ErrorContains(t, err, "Invalid params")
+	})
+
+	t.Run("endpoint not set", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{})
+
+		_, err := c.SubscribeLogs(context.Background(), mint)
+		require.ErrorIs(t, err, client.ErrSubscribeLogs)
+		require.ErrorIs(t, err, client.ErrWSEndpointNotSet)
+	})
+
+	t.Run("invalid mention", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{})
+
+		_, err := c.SubscribeLogs(context.Background(), "invalid")
+		require.ErrorIs(t, err, client.ErrSubscribeLogs)
+	})
+}
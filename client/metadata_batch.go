@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// maxMultipleAccounts is the maximum number of accounts in a single getMultipleAccounts request.
+const maxMultipleAccounts = 100
+
+// GetTokenMetadataBatch returns the token metadata for each of the given base58 encoded mint addresses.
+// All metadata accounts are fetched via getMultipleAccounts and deserialized concurrently.
+// Invalid mints, missing or undeserializable metadata accounts are mapped to nil.
+// Returns an error only if the RPC request fails.
+func (c *Client) GetTokenMetadataBatch(ctx context.Context, base58Mints []string) (map[string]*token_metadata.Metadata, error) {
+	result := make(map[string]*token_metadata.Metadata, len(base58Mints))
+
+	mints := make([]common.PublicKey, 0, len(base58Mints))
+	metadataAddrs := make([]string, 0, len(base58Mints))
+	for _, mint := range base58Mints {
+		if _, ok := result[mint]; ok {
+			continue
+		}
+		result[mint] = nil
+
+		if err := commonx.ValidateSolanaWalletAddr(mint); err != nil {
+			continue
+		}
+		mintPubkey := common.PublicKeyFromString(mint)
+		metadataAccount, err := token_metadata.DeriveTokenMetadataPubkey(mintPubkey)
+		if err != nil {
+			continue
+		}
+
+		mints = append(mints, mintPubkey)
+		metadataAddrs = append(metadataAddrs, metadataAccount.ToBase58())
+	}
+
+	metadataAccounts, err := c.getMultipleAccounts(ctx, metadataAddrs)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenMetadata, err)
+	}
+
+	metadataList := make([]*token_metadata.Metadata, len(mints))
+	forEachAccountData(metadataAccounts, func(i int, data []byte) {
+		if md, err := token_metadata.DeserializeMetadata(ctx, data); err == nil {
+			metadataList[i] = md
+		}
+	})
+
+	// fetch the editions of the non-fungible tokens, as GetTokenMetadata does
+	editionIdx := make([]int, 0, len(mints))
+	editionAddrs := make([]string, 0, len(mints))
+	for i, md := range metadataList {
+		if md == nil ||
+			(md.TokenStandard != token_metadata.TokenStandardNonFungible.String() &&
+				md.TokenStandard != token_metadata.TokenStandardNonFungibleEdition.String()) {
+			continue
+		}
+		editionPubkey, err := token_metadata.DeriveEditionPubkey(mints[i])
+		if err != nil {
+			continue
+		}
+		editionIdx = append(editionIdx, i)
+		editionAddrs = append(editionAddrs, editionPubkey.ToBase58())
+	}
+
+	editionAccounts, err := c.getMultipleAccounts(ctx, editionAddrs)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenMetadata, err)
+	}
+
+	forEachAccountData(editionAccounts, func(i int, data []byte) {
+		if edition, err := token_metadata.DeserializeEdition(ctx, data, c.rpcClient.GetAccountInfo); err == nil {
+			metadataList[editionIdx[i]].Edition = edition
+		}
+	})
+
+	for i, mint := range mints {
+		result[mint.ToBase58()] = metadataList[i]
+	}
+
+	return result, nil
+}
+
+// getMultipleAccounts returns the accounts info of the given base58 encoded addresses,
+// splitting them into the requests of the maximum allowed size.
+// Missing accounts have nil data.
+func (c *Client) getMultipleAccounts(ctx context.Context, base58Addrs []string) ([]client.AccountInfo, error) {
+	result := make([]client.AccountInfo, 0, len(base58Addrs))
+	for start := 0; start < len(base58Addrs); start += maxMultipleAccounts {
+		end := start + maxMultipleAccounts
+		if end > len(base58Addrs) {
+			end = len(base58Addrs)
+		}
+
+		accounts, err := c.rpcClient.GetMultipleAccountsWithConfig(ctx, base58Addrs[start:end], client.GetMultipleAccountsConfig{
+			Commitment: c.commitment,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, accounts...)
+	}
+
+	return result, nil
+}
+
+// forEachAccountData concurrently calls fn for each account with non-empty data.
+func forEachAccountData(accounts []client.AccountInfo, fn func(i int, data []byte)) {
+	var wg sync.WaitGroup
+	for i, acc := range accounts {
+		if len(acc.Data) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, data []byte) {
+			defer wg.Done()
+			fn(i, data)
+		}(i, acc.Data)
+	}
+	wg.Wait()
+}
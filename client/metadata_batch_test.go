@@ -0,0 +1,150 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountData returns the mock base64 encoded account.
+func accountData(data []byte) map[string]interface{} {
+	return map[string]interface{}{
+		"lamports":   1461600,
+		"owner":      common.MetaplexTokenMetaProgramID.ToBase58(),
+		"executable": false,
+		"rentEpoch":  0,
+		"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+	}
+}
+
+func serializedMetadata(t *testing.T, mint common.PublicKey, name string, standard metaplex_token_metadata.TokenStandard) []byte {
+	data, err := borsh.Serialize(metaplex_token_metadata.Metadata{
+		Key:             metaplex_token_metadata.KeyMetadataV1,
+		UpdateAuthority: types.NewAccount().PublicKey,
+		Mint:            mint,
+		Data:            metaplex_token_metadata.Data{Name: name, Symbol: "TST"},
+		IsMutable:       true,
+		TokenStandard:   &standard,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func TestGetTokenMetadataBatch(t *testing.T) {
+	fungible := types.NewAccount().PublicKey
+	nft := types.NewAccount().PublicKey
+	missing := types.NewAccount().PublicKey
+	broken := types.NewAccount().PublicKey
+
+	maxSupply := uint64(10)
+	editionData, err := borsh.Serialize(metaplex_token_metadata.MasterEditionV2{
+		Key:       metaplex_token_metadata.KeyMasterEditionV2,
+		Supply:    3,
+		MaxSupply: &maxSupply,
+	})
+	require.NoError(t, err)
+
+	accounts := map[string][]byte{}
+	for mint, data := range map[common.PublicKey][]byte{
+		fungible: serializedMetadata(t, fungible, "Fungible", metaplex_token_metadata.Fungible),
+		nft:      serializedMetadata(t, nft, "NFT", metaplex_token_metadata.NonFungible),
+		broken:   {0xff, 0x01},
+	} {
+		pda, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+		require.NoError(t, err)
+		accounts[pda.ToBase58()] = data
+	}
+	editionPDA, err := token_metadata.DeriveEditionPubkey(nft)
+	require.NoError(t, err)
+	accounts[editionPDA.ToBase58()] = editionData
+
+	var requests [][]string
+	c := newMockClient(t, map[string]interface{}{
+		"getMultipleAccounts": func(params []json.RawMessage) interface{} {
+			var addrs []string
+			require.NoError(t, json.Unmarshal(params[0], &addrs))
+			requests = append(requests, addrs)
+
+			value := make([]interface{}, 0, len(addrs))
+			for _, addr := range addrs {
+				if data, ok := accounts[addr]; ok {
+					value = append(value, accountData(data))
+				} else {
+					value = append(value, nil)
+				}
+			}
+			return map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value":   value,
+			}
+		},
+	})
+
+	mints := []string{fungible.ToBase58(), nft.ToBase58(), missing.ToBase58(), broken.ToBase58(), "invalid", fungible.ToBase58()}
+	result, err := c.GetTokenMetadataBatch(context.Background(), mints)
+	require.NoError(t, err)
+	require.Len(t, result, 5)
+
+	require.NotNil(t, result[fungible.ToBase58()])
+	assert.Equal(t, fungible.ToBase58(), result[fungible.ToBase58()].Mint)
+	assert.Equal(t, "Fungible", result[fungible.ToBase58()].Data.Name)
+	assert.Equal(t, token_metadata.TokenStandardFungible.String(), result[fungible.ToBase58()].TokenStandard)
+	assert.Nil(t, result[fungible.ToBase58()].Edition)
+
+	require.NotNil(t, result[nft.ToBase58()])
+	assert.Equal(t, "NFT", result[nft.ToBase58()].Data.Name)
+	require.NotNil(t, result[nft.ToBase58()].Edition)
+	assert.Equal(t, uint64(3), result[nft.ToBase58()].Edition.Supply)
+	assert.Equal(t, uint64(10), result[nft.ToBase58()].Edition.MaxSupply)
+
+	for _, mint := range []string{missing.ToBase58(), broken.ToBase58(), "invalid"} {
+		v, ok := result[mint]
+		assert.True(t, ok, mint)
+		assert.Nil(t, v, mint)
+	}
+
+	// one request for the metadata accounts and one for the editions
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[0], 4)
+	assert.Equal(t, []string{editionPDA.ToBase58()}, requests[1])
+}
+
+func TestGetTokenMetadataBatch_Chunks(t *testing.T) {
+	var requests []int
+	c := newMockClient(t, map[string]interface{}{
+		"getMultipleAccounts": func(params []json.RawMessage) interface{} {
+			var addrs []string
+			require.NoError(t, json.Unmarshal(params[0], &addrs))
+			requests = append(requests, len(addrs))
+			return map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value":   make([]interface{}, len(addrs)),
+			}
+		},
+	})
+
+	mints := make([]string, 0, 150)
+	for i := 0; i < 150; i++ {
+		mints = append(mints, types.NewAccount().PublicKey.ToBase58())
+	}
+
+	result, err := c.GetTokenMetadataBatch(context.Background(), mints)
+	require.NoError(t, err)
+	assert.Len(t, result, 150)
+	assert.Equal(t, []int{100, 50}, requests)
+}
+
+func TestGetTokenMetadataBatch_RPCError(t *testing.T) {
+	c := newMockClient(t, nil)
+	_, err := c.GetTokenMetadataBatch(context.Background(), []string{types.NewAccount().PublicKey.ToBase58()})
+	require.Error(t, err)
+}
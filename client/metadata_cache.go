@@ -0,0 +1,153 @@
+package client
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Metadata cache defaults.
+const (
+	DefaultMetadataCacheCapacity = 1000
+	DefaultMetadataCacheTTL      = 10 * time.Minute
+)
+
+type (
+	// MetadataCache is the cache of the token metadata lookups, keyed by the mint address.
+	// Implementations must be safe for concurrent use.
+	MetadataCache interface {
+		// Get returns the cached value and true, or false if the key is missing or expired.
+		Get(key string) (interface{}, bool)
+		// Set stores the value for the given time to live; zero ttl means no expiration.
+		Set(key string, value interface{}, ttl time.Duration)
+		// Delete removes the key from the cache.
+		Delete(key string)
+	}
+
+	// LRUMetadataCache is the in-memory MetadataCache which evicts the least recently used entries
+	// once the capacity is reached.
+	LRUMetadataCache struct {
+		mu       sync.Mutex
+		capacity int
+		items    map[string]*list.Element
+		order    *list.List
+		now      func() time.Time
+	}
+
+	// lruEntry is the LRUMetadataCache entry.
+	lruEntry struct {
+		key       string
+		value     interface{}
+		expiresAt time.Time
+	}
+)
+
+// NewLRUMetadataCache creates a new in-memory LRU metadata cache with the given capacity.
+// DefaultMetadataCacheCapacity is used if the capacity is not positive.
+func NewLRUMetadataCache(capacity int) *LRUMetadataCache {
+	if capacity <= 0 {
+		capacity = DefaultMetadataCacheCapacity
+	}
+
+	return &LRUMetadataCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns the cached value and true, or false if the key is missing or expired.
+func (c *LRUMetadataCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set stores the value for the given time to live; zero ttl means no expiration.
+func (c *LRUMetadataCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the key from the cache.
+func (c *LRUMetadataCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+}
+
+// InvalidateTokenMetadata removes the cached metadata of the given base58 encoded mint address.
+// Call it once the transaction updating the token metadata is confirmed:
+// a lookup between building and confirming the transaction caches the stale metadata again.
+func (c *Client) InvalidateTokenMetadata(base58MintAddr string) {
+	if c.metadataCache == nil {
+		return
+	}
+	c.metadataCache.Delete(tokenMetadataCacheKey(base58MintAddr))
+	c.metadataCache.Delete(fungibleTokenMetadataCacheKey(base58MintAddr))
+}
+
+// tokenMetadataCacheKey returns the cache key of the GetTokenMetadata result.
+func tokenMetadataCacheKey(base58MintAddr string) string {
+	return "token_metadata:" + base58MintAddr
+}
+
+// fungibleTokenMetadataCacheKey returns the cache key of the GetFungibleTokenMetadata result.
+func fungibleTokenMetadataCacheKey(base58MintAddr string) string {
+	return "fungible_token_metadata:" + base58MintAddr
+}
+
+// cloneMetadata returns a deep copy of the given metadata, so the cached value
+// can't be changed by the callers; the metadata types are JSON round-trippable.
+func cloneMetadata[T any](md *T) (*T, error) {
+	data, err := json.Marshal(md)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(T)
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/token_metadata"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUMetadataCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := client.NewLRUMetadataCache(2)
+	cache.SetNow(func() time.Time { return now })
+
+	cache.Set("a", 1, time.Minute)
+	cache.Set("b", 2, 0)
+
+	v, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// "b" is the least recently used one
+	cache.Set("c", 3, time.Minute)
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+
+	// ttl expiry
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+
+	// no expiration
+	cache.Set("d", 4, 0)
+	now = now.Add(24 * time.Hour)
+	v, ok = cache.Get("d")
+	require.True(t, ok)
+	assert.Equal(t, 4, v)
+
+	cache.Delete("d")
+	_, ok = cache.Get("d")
+	assert.False(t, ok)
+}
+
+// newMetadataMockClient returns the client connected to the fake node which serves the fungible token metadata
+// of the given mint and counts the getAccountInfo requests.
+func newMetadataMockClient(t *testing.T, mint types.Account, requests *int32, opts ...client.ClientOption) *client.Client {
+	t.Helper()
+
+	// the empty token list for the deprecated metadata fallback
+	tokenList := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"tokens": []interface{}{}})
+	}))
+	t.Cleanup(tokenList.Close)
+
+	data := serializedMetadata(t, mint.PublicKey, "Cached", metaplex_token_metadata.Fungible)
+	opts = append(opts, client.SetTokenListPath(tokenList.URL))
+
+	return newMockClient(t, map[string]interface{}{
+		"getAccountInfo": func() interface{} {
+			atomic.AddInt32(requests, 1)
+			return withContext(accountData(data))
+		},
+	}, opts...)
+}
+
+func TestGetTokenMetadata_Cache(t *testing.T) {
+	mint := types.NewAccount()
+	now := time.Unix(1700000000, 0)
+	cache := client.NewLRUMetadataCache(10)
+	cache.SetNow(func() time.Time { return now })
+
+	var requests int32
+	c := newMetadataMockClient(t, mint, &requests, client.SetMetadataCache(cache), client.SetMetadataCacheTTL(time.Minute))
+
+	md, err := c.GetTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, "Cached", md.Data.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// the second lookup hits the cache
+	cached, err := c.GetTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, md, cached)
+	assert.NotSame(t, md, cached)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// the callers get copies, so changing them doesn't affect the cache
+	cached.Data.Name = "Changed"
+	cached.Creators = append(cached.Creators, token_metadata.Creator{Address: mint.PublicKey.ToBase58()})
+	cached, err = c.GetTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, md, cached)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// ttl expiry triggers a refetch
+	now = now.Add(time.Minute)
+	_, err = c.GetTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// invalidation triggers a refetch
+	c.InvalidateTokenMetadata(mint.PublicKey.ToBase58())
+	_, err = c.GetTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestGetFungibleTokenMetadata_Cache(t *testing.T) {
+	mint := types.NewAccount()
+
+	var requests int32
+	c := newMetadataMockClient(t, mint, &requests, client.SetMetadataCache(nil))
+
+	md, err := c.GetFungibleTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, "Cached", md.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	md.Name = "Changed"
+	cached, err := c.GetFungibleTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, "Cached", cached.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	c.InvalidateTokenMetadata(mint.PublicKey.ToBase58())
+	_, err = c.GetFungibleTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestGetTokenMetadata_NoCache(t *testing.T) {
+	mint := types.NewAccount()
+
+	var requests int32
+	c := newMetadataMockClient(t, mint, &requests)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetTokenMetadata(context.Background(), mint.PublicKey.ToBase58())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
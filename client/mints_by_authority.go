@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	sdkcommon "github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// Metaplex token metadata account layout:
+//
+//	offset 0:  key, 1 byte; KeyMetadataV1 for the metadata accounts
+//	offset 1:  update authority, 32 bytes
+//	offset 33: mint, 32 bytes
+const (
+	metadataKeyOffset             = 0
+	metadataUpdateAuthorityOffset = 1
+	metadataMintOffset            = 33
+)
+
+// GetMintsByUpdateAuthority returns the base58 encoded addresses of all mints
+// whose token metadata update authority is the given base58 encoded address.
+// Only the mint field of the metadata accounts is fetched.
+func (c *Client) GetMintsByUpdateAuthority(ctx context.Context, base58Authority string) ([]string, error) {
+	if err := common.ValidateSolanaWalletAddr(base58Authority); err != nil {
+		return nil, utils.StackErrors(ErrGetMintsByUpdateAuthority, err)
+	}
+
+	accounts, err := c.GetProgramAccounts(ctx, sdkcommon.MetaplexTokenMetaProgramID.ToBase58(), []ProgramAccountFilter{
+		MemcmpFilter(metadataKeyOffset, []byte{byte(metaplex_token_metadata.KeyMetadataV1)}),
+		MemcmpFilter(metadataUpdateAuthorityOffset, sdkcommon.PublicKeyFromString(base58Authority).Bytes()),
+	}, rpc.DataSlice{Offset: metadataMintOffset, Length: sdkcommon.PublicKeyLength})
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetMintsByUpdateAuthority, err)
+	}
+
+	mints := make([]string, 0, len(accounts))
+	for _, acc := range accounts {
+		if len(acc.Account.Data) != sdkcommon.PublicKeyLength {
+			continue
+		}
+		mints = append(mints, sdkcommon.PublicKeyFromBytes(acc.Account.Data).ToBase58())
+	}
+
+	return mints, nil
+}
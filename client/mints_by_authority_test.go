@@ -0,0 +1,67 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMintsByUpdateAuthority(t *testing.T) {
+	authority := types.NewAccount().PublicKey
+	mints := []common.PublicKey{types.NewAccount().PublicKey, types.NewAccount().PublicKey, types.NewAccount().PublicKey}
+
+	var accounts [][]byte
+	for i, updateAuthority := range []common.PublicKey{authority, types.NewAccount().PublicKey, authority} {
+		data, err := borsh.Serialize(metaplex_token_metadata.Metadata{
+			Key:             metaplex_token_metadata.KeyMetadataV1,
+			UpdateAuthority: updateAuthority,
+			Mint:            mints[i],
+			Data:            metaplex_token_metadata.Data{Name: "Test", Symbol: "TST"},
+		})
+		require.NoError(t, err)
+		accounts = append(accounts, data)
+	}
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getProgramAccounts": func(params []json.RawMessage) interface{} {
+			var programID string
+			require.NoError(t, json.Unmarshal(params[0], &programID))
+			require.Equal(t, common.MetaplexTokenMetaProgramID.ToBase58(), programID)
+
+			var cfg rpc.GetProgramAccountsConfig
+			require.NoError(t, json.Unmarshal(params[1], &cfg))
+			require.NotNil(t, cfg.DataSlice)
+
+			result := []map[string]interface{}{}
+		accountsLoop:
+			for _, data := range accounts {
+				for _, f := range cfg.Filters {
+					b, err := base58.Decode(f.MemCmp.Bytes)
+					require.NoError(t, err)
+					if !bytes.HasPrefix(data[f.MemCmp.Offset:], b) {
+						continue accountsLoop
+					}
+				}
+				result = append(result, map[string]interface{}{
+					"pubkey":  types.NewAccount().PublicKey.ToBase58(),
+					"account": accountData(data[cfg.DataSlice.Offset : cfg.DataSlice.Offset+cfg.DataSlice.Length]),
+				})
+			}
+			return result
+		},
+	})
+
+	result, err := sc.GetMintsByUpdateAuthority(context.Background(), authority.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, []string{mints[0].ToBase58(), mints[2].ToBase58()}, result)
+}
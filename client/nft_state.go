@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+)
+
+// NFTState is the on-chain state of the NFT metadata checked by marketplaces,
+// see GetNFTOnChainState.
+type NFTState struct {
+	UpdateAuthority     string                       `json:"update_authority"`      // base58 encoded update authority
+	IsMutable           bool                         `json:"is_mutable"`            // true if the metadata can be updated
+	PrimarySaleHappened bool                         `json:"primary_sale_happened"` // true if the token has been sold at least once
+	TokenStandard       token_metadata.TokenStandard `json:"token_standard"`        // undefined for the legacy tokens without the token standard
+	Collection          string                       `json:"collection,omitempty"`  // base58 encoded collection mint; empty if the token isn't in a collection
+	CollectionVerified  bool                         `json:"collection_verified"`   // true if the collection authority verified the token
+}
+
+// HasVerifiedCollection returns true if the token belongs to the verified collection.
+func (s NFTState) HasVerifiedCollection() bool {
+	return s.Collection != "" && s.CollectionVerified
+}
+
+// GetNFTOnChainState returns the on-chain state of the NFT by the given base58 encoded mint address.
+// Unlike GetTokenMetadata, it reads only the metadata account, without the edition and the off-chain metadata.
+func (c *Client) GetNFTOnChainState(ctx context.Context, base58MintAddr string) (NFTState, error) {
+	md, err := c.getOnChainMetadata(ctx, base58MintAddr)
+	if err != nil {
+		return NFTState{}, utils.StackErrors(ErrGetNFTOnChainState, err)
+	}
+
+	state := NFTState{
+		UpdateAuthority:     md.UpdateAuthority.ToBase58(),
+		IsMutable:           md.IsMutable,
+		PrimarySaleHappened: md.PrimarySaleHappened,
+		TokenStandard:       token_metadata.TokenStandardUndefined,
+	}
+	if md.TokenStandard != nil {
+		state.TokenStandard = token_metadata.CastToTokenStandard(*md.TokenStandard)
+	}
+	if md.Collection != nil {
+		state.Collection = md.Collection.Key.ToBase58()
+		state.CollectionVerified = md.Collection.Verified
+	}
+
+	return state, nil
+}
+
+// IsVerifiedCollectionItem returns true if the NFT with the given base58 encoded mint address
+// belongs to the collection with the given base58 encoded mint address and the collection authority verified it.
+// The membership of an unverified item can be set by anyone, so it must not be trusted.
+func (c *Client) IsVerifiedCollectionItem(ctx context.Context, mint, collectionMint string) (bool, error) {
+	if err := commonx.ValidateAccountAddr(collectionMint); err != nil {
+		return false, utils.StackErrors(ErrIsVerifiedCollectionItem, err)
+	}
+
+	state, err := c.GetNFTOnChainState(ctx, mint)
+	if err != nil {
+		return false, utils.StackErrors(ErrIsVerifiedCollectionItem, err)
+	}
+
+	return state.HasVerifiedCollection() && state.Collection == collectionMint, nil
+}
+
+// getOnChainMetadata reads and deserializes the metadata account of the given base58 encoded mint address,
+// without the edition and the off-chain metadata.
+func (c *Client) getOnChainMetadata(ctx context.Context, mint string) (metaplex_token_metadata.Metadata, error) {
+	if err := commonx.ValidateAccountAddr(mint); err != nil {
+		return metaplex_token_metadata.Metadata{}, err
+	}
+
+	metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(common.PublicKeyFromString(mint))
+	if err != nil {
+		return metaplex_token_metadata.Metadata{}, err
+	}
+
+	accInfo, err := c.rpcClient.GetAccountInfoWithConfig(ctx, metadataPubkey.ToBase58(), client.GetAccountInfoConfig{
+		Commitment: c.commitment,
+	})
+	if err != nil {
+		return metaplex_token_metadata.Metadata{}, err
+	}
+	if accInfo.Owner == (common.PublicKey{}) {
+		return metaplex_token_metadata.Metadata{}, ErrAccountNotFound
+	}
+
+	return metaplex_token_metadata.MetadataDeserialize(accInfo.Data)
+}
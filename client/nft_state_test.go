@@ -0,0 +1,168 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/near/borsh-go"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNFTOnChainState(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	updateAuthority := types.NewAccount().PublicKey
+	collection := types.NewAccount().PublicKey
+	metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+	require.NoError(t, err)
+
+	newClient := func(t *testing.T, md metaplex_token_metadata.Metadata) *client.Client {
+		data, err := borsh.Serialize(md)
+		require.NoError(t, err)
+
+		return newMockClient(t, map[string]interface{}{
+			"getAccountInfo": func(params []json.RawMessage) interface{} {
+				var addr string
+				require.NoError(t, json.Unmarshal(params[0], &addr))
+				require.Equal(t, metadataPubkey.ToBase58(), addr)
+				return withContext(accountData(data))
+			},
+		})
+	}
+
+	t.Run("verified collection", func(t *testing.T) {
+		standard := metaplex_token_metadata.ProgrammableNonFungible
+		c := newClient(t, metaplex_token_metadata.Metadata{
+			Key:                 metaplex_token_metadata.KeyMetadataV1,
+			UpdateAuthority:     updateAuthority,
+			Mint:                mint,
+			Data:                metaplex_token_metadata.Data{Name: "NFT", Symbol: "NFT", Uri: "https://example.com/nft.json"},
+			PrimarySaleHappened: true,
+			IsMutable:           false,
+			TokenStandard:       &standard,
+			Collection:          &metaplex_token_metadata.Collection{Verified: true, Key: collection},
+		})
+
+		state, err := c.GetNFTOnChainState(context.Background(), mint.ToBase58())
+		require.NoError(t, err)
+		assert.Equal(t, client.NFTState{
+			UpdateAuthority:     updateAuthority.ToBase58(),
+			IsMutable:           false,
+			PrimarySaleHappened: true,
+			TokenStandard:       token_metadata.TokenStandardProgrammableNonFungible,
+			Collection:          collection.ToBase58(),
+			CollectionVerified:  true,
+		}, state)
+		assert.True(t, state.HasVerifiedCollection())
+	})
+
+	t.Run("unverified collection", func(t *testing.T) {
+		c := newClient(t, metaplex_token_metadata.Metadata{
+			Key:             metaplex_token_metadata.KeyMetadataV1,
+			UpdateAuthority: updateAuthority,
+			Mint:            mint,
+			Data:            metaplex_token_metadata.Data{Name: "NFT", Symbol: "NFT"},
+			IsMutable:       true,
+			Collection:      &metaplex_token_metadata.Collection{Verified: false, Key: collection},
+		})
+
+		state, err := c.GetNFTOnChainState(context.Background(), mint.ToBase58())
+		require.NoError(t, err)
+		assert.Equal(t, collection.ToBase58(), state.Collection)
+		assert.False(t, state.CollectionVerified)
+		assert.False(t, state.HasVerifiedCollection())
+	})
+
+	t.Run("without collection", func(t *testing.T) {
+		c := newClient(t, metaplex_token_metadata.Metadata{
+			Key:             metaplex_token_metadata.KeyMetadataV1,
+			UpdateAuthority: updateAuthority,
+			Mint:            mint,
+			Data:            metaplex_token_metadata.Data{Name: "NFT", Symbol: "NFT"},
+			IsMutable:       true,
+		})
+
+		state, err := c.GetNFTOnChainState(context.Background(), mint.ToBase58())
+		require.NoError(t, err)
+		assert.True(t, state.IsMutable)
+		assert.False(t, state.PrimarySaleHappened)
+		assert.Equal(t, token_metadata.TokenStandardUndefined, state.TokenStandard)
+		assert.Empty(t, state.Collection)
+		assert.False(t, state.HasVerifiedCollection())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(nil),
+		})
+
+		_, err := c.GetNFTOnChainState(context.Background(), mint.ToBase58())
+		require.ErrorIs(t, err, client.ErrGetNFTOnChainState)
+		require.ErrorIs(t, err, client.ErrAccountNotFound)
+	})
+
+	t.Run("invalid mint", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{})
+
+		_, err := c.GetNFTOnChainState(context.Background(), "invalid")
+		require.ErrorIs(t, err, client.ErrGetNFTOnChainState)
+	})
+}
+
+func TestIsVerifiedCollectionItem(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	collection := types.NewAccount().PublicKey
+
+	newClient := func(t *testing.T, col *metaplex_token_metadata.Collection) *client.Client {
+		data, err := borsh.Serialize(metaplex_token_metadata.Metadata{
+			Key:             metaplex_token_metadata.KeyMetadataV1,
+			UpdateAuthority: types.NewAccount().PublicKey,
+			Mint:            mint,
+			Data:            metaplex_token_metadata.Data{Name: "NFT", Symbol: "NFT"},
+			Collection:      col,
+		})
+		require.NoError(t, err)
+
+		return newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(accountData(data)),
+		})
+	}
+
+	tests := []struct {
+		name       string
+		collection *metaplex_token_metadata.Collection
+		want       bool
+	}{
+		{"verified", &metaplex_token_metadata.Collection{Verified: true, Key: collection}, true},
+		{"unverified", &metaplex_token_metadata.Collection{Verified: false, Key: collection}, false},
+		{"wrong collection", &metaplex_token_metadata.Collection{Verified: true, Key: types.NewAccount().PublicKey}, false},
+		{"without collection", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := newClient(t, tt.collection).IsVerifiedCollectionItem(context.Background(), mint.ToBase58(), collection.ToBase58())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+
+	t.Run("invalid collection mint", func(t *testing.T) {
+		_, err := newMockClient(t, map[string]interface{}{}).IsVerifiedCollectionItem(context.Background(), mint.ToBase58(), "invalid")
+		require.ErrorIs(t, err, client.ErrIsVerifiedCollectionItem)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(nil),
+		})
+
+		_, err := c.IsVerifiedCollectionItem(context.Background(), mint.ToBase58(), collection.ToBase58())
+		require.ErrorIs(t, err, client.ErrIsVerifiedCollectionItem)
+		require.ErrorIs(t, err, client.ErrAccountNotFound)
+	})
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/client"
+	sdkcommon "github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// maxMemcmpBytes is the maximum length of the memcmp filter bytes accepted by the RPC node.
+const maxMemcmpBytes = 128
+
+type (
+	// ProgramAccountFilter defines a getProgramAccounts filter.
+	// Set either DataSize or Memcmp; use separate filters to combine them.
+	ProgramAccountFilter struct {
+		DataSize uint64  // optional; matches accounts with the given data length
+		Memcmp   *Memcmp // optional; matches accounts with the given bytes at the offset
+	}
+
+	// Memcmp compares the provided bytes with the account data at the given offset.
+	Memcmp struct {
+		Offset uint64 // offset into the account data
+		Bytes  []byte // bytes to match; up to 128 bytes
+	}
+
+	// ProgramAccount represents an account owned by a program.
+	ProgramAccount struct {
+		PublicKey sdkcommon.PublicKey // account address
+		Account   client.AccountInfo  // account info; data is sliced if the data slice is set
+	}
+)
+
+// DataSizeFilter returns a filter matching accounts with the given data length.
+func DataSizeFilter(size uint64) ProgramAccountFilter {
+	return ProgramAccountFilter{DataSize: size}
+}
+
+// MemcmpFilter returns a filter matching accounts with the given bytes at the offset.
+func MemcmpFilter(offset uint64, bytes []byte) ProgramAccountFilter {
+	return ProgramAccountFilter{Memcmp: &Memcmp{Offset: offset, Bytes: bytes}}
+}
+
+// GetProgramAccounts returns all accounts owned by the given base58 encoded program ID
+// which match all the given filters.
+// The optional data slice limits the returned account data.
+// E.g. all token accounts of a mint:
+//
+//	c.GetProgramAccounts(ctx, common.TokenProgramID.ToBase58(), []ProgramAccountFilter{
+//		DataSizeFilter(token.TokenAccountSize),
+//		MemcmpFilter(0, mint.Bytes()),
+//	})
+func (c *Client) GetProgramAccounts(ctx context.Context, programID string, filters []ProgramAccountFilter, dataSlice ...DataSlice) ([]ProgramAccount, error) {
+	if err := common.ValidateSolanaWalletAddr(programID); err != nil {
+		return nil, utils.StackErrors(ErrGetProgramAccounts, err)
+	}
+
+	cfg := rpc.GetProgramAccountsConfig{
+		Encoding:   rpc.AccountEncodingBase64,
+		Commitment: c.commitment,
+	}
+	if len(dataSlice) > 0 {
+		cfg.DataSlice = &dataSlice[0]
+	}
+	for _, f := range filters {
+		filter, err := f.toRpc()
+		if err != nil {
+			return nil, utils.StackErrors(ErrGetProgramAccounts, err)
+		}
+		cfg.Filters = append(cfg.Filters, filter)
+	}
+
+	accounts, err := rpcCall[rpc.GetProgramAccounts](ctx, c, "getProgramAccounts", programID, cfg)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetProgramAccounts, err)
+	}
+
+	result := make([]ProgramAccount, 0, len(accounts))
+	for _, acc := range accounts {
+		data, err := decodeAccountData(acc.Account.Data)
+		if err != nil {
+			return nil, utils.StackErrors(ErrGetProgramAccounts, err)
+		}
+		result = append(result, ProgramAccount{
+			PublicKey: sdkcommon.PublicKeyFromString(acc.Pubkey),
+			Account: client.AccountInfo{
+				Lamports:   acc.Account.Lamports,
+				Owner:      sdkcommon.PublicKeyFromString(acc.Account.Owner),
+				Executable: acc.Account.Executable,
+				RentEpoch:  acc.Account.RentEpoch,
+				Data:       data,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// toRpc converts the filter to the RPC request format.
+func (f ProgramAccountFilter) toRpc() (rpc.GetProgramAccountsConfigFilter, error) {
+	switch {
+	case f.Memcmp != nil && f.DataSize > 0:
+		return rpc.GetProgramAccountsConfigFilter{}, fmt.Errorf("filter must have either data size or memcmp set, not both")
+	case f.Memcmp != nil:
+		if len(f.Memcmp.Bytes) == 0 || len(f.Memcmp.Bytes) > maxMemcmpBytes {
+			return rpc.GetProgramAccountsConfigFilter{}, fmt.Errorf("memcmp bytes length must be between 1 and %d", maxMemcmpBytes)
+		}
+		return rpc.GetProgramAccountsConfigFilter{
+			MemCmp: &rpc.GetProgramAccountsConfigFilterMemCmp{
+				Offset: f.Memcmp.Offset,
+				Bytes:  base58.Encode(f.Memcmp.Bytes),
+			},
+		}, nil
+	case f.DataSize > 0:
+		return rpc.GetProgramAccountsConfigFilter{DataSize: f.DataSize}, nil
+	}
+
+	return rpc.GetProgramAccountsConfigFilter{}, fmt.Errorf("filter must have either data size or memcmp set")
+}
+
+// decodeAccountData decodes the account data returned by the RPC node as a [data, encoding] pair.
+func decodeAccountData(data interface{}) ([]byte, error) {
+	raw, ok := data.([]interface{})
+	if !ok || len(raw) != 2 {
+		return nil, fmt.Errorf("unexpected account data format")
+	}
+	encoded := make([]string, len(raw))
+	for i, v := range raw {
+		if encoded[i], ok = v.(string); !ok {
+			return nil, fmt.Errorf("unexpected account data format")
+		}
+	}
+
+	return decodeEncodedData(encoded)
+}
@@ -0,0 +1,82 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProgramAccounts(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	account := types.NewAccount().PublicKey
+	data := append(mint.Bytes(), make([]byte, 32)...)
+
+	var params []json.RawMessage
+	sc := newMockClient(t, map[string]interface{}{
+		"getProgramAccounts": func(p []json.RawMessage) interface{} {
+			params = p
+			return []map[string]interface{}{{
+				"pubkey": account.ToBase58(),
+				"account": map[string]interface{}{
+					"lamports":   2039280,
+					"owner":      common.TokenProgramID.ToBase58(),
+					"executable": false,
+					"rentEpoch":  0,
+					"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+				},
+			}}
+		},
+	})
+
+	accounts, err := sc.GetProgramAccounts(context.Background(), common.TokenProgramID.ToBase58(), []client.ProgramAccountFilter{
+		client.DataSizeFilter(token.TokenAccountSize),
+		client.MemcmpFilter(0, mint.Bytes()),
+	}, rpc.DataSlice{Offset: 0, Length: 64})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, account, accounts[0].PublicKey)
+	assert.Equal(t, common.TokenProgramID, accounts[0].Account.Owner)
+	assert.Equal(t, uint64(2039280), accounts[0].Account.Lamports)
+	assert.Equal(t, data, accounts[0].Account.Data)
+
+	require.Len(t, params, 2)
+	var programID string
+	require.NoError(t, json.Unmarshal(params[0], &programID))
+	assert.Equal(t, common.TokenProgramID.ToBase58(), programID)
+	assert.JSONEq(t, `{
+		"encoding": "base64",
+		"dataSlice": {"offset": 0, "length": 64},
+		"filters": [
+			{"dataSize": 165},
+			{"memcmp": {"offset": 0, "bytes": "`+base58.Encode(mint.Bytes())+`"}}
+		]
+	}`, string(params[1]))
+}
+
+func TestGetProgramAccounts_InvalidFilter(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{})
+	programID := common.TokenProgramID.ToBase58()
+
+	for _, filters := range [][]client.ProgramAccountFilter{
+		{{}},
+		{{DataSize: 165, Memcmp: &client.Memcmp{Bytes: []byte{1}}}},
+		{client.MemcmpFilter(0, nil)},
+		{client.MemcmpFilter(0, make([]byte, 129))},
+	} {
+		_, err := sc.GetProgramAccounts(context.Background(), programID, filters)
+		assert.ErrorIs(t, err, client.ErrGetProgramAccounts)
+	}
+
+	_, err := sc.GetProgramAccounts(context.Background(), programID, nil)
+	assert.ErrorIs(t, err, client.ErrGetProgramAccounts)
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProgramLogEvent is the invocation of the program decoded from the transaction logs,
+// see ParseProgramLogs.
+type ProgramLogEvent struct {
+	ProgramID     string   `json:"program_id"`               // base58 encoded program ID
+	Depth         int      `json:"depth"`                    // invocation depth; 1 for the transaction instructions, 2+ for the cross-program invocations
+	Messages      []string `json:"messages,omitempty"`       // log lines emitted by the invocation, excluding the nested invocations
+	Logs          []string `json:"logs,omitempty"`           // payloads of the "Program log:" lines
+	Data          []string `json:"data,omitempty"`           // base64 encoded payloads of the "Program data:" lines, e.g. anchor events
+	UnitsConsumed uint64   `json:"units_consumed,omitempty"` // compute units consumed by the invocation, including the nested ones
+	Success       bool     `json:"success"`                  // true if the invocation succeeded
+	Failure       string   `json:"failure,omitempty"`        // failure reason; empty if the invocation succeeded or didn't finish
+}
+
+// Program log line prefixes.
+const (
+	programLogPrefix  = "Program log: "
+	programDataPrefix = "Program data: "
+)
+
+// ParseProgramLogs groups the transaction logs, e.g. the SubscribeLogs notification logs
+// or the simulation result logs, into the program invocation events.
+// The events are ordered by the invocation start, so the cross-program invocations follow their caller.
+// The lines outside of any invocation are skipped.
+func ParseProgramLogs(logs []string) []ProgramLogEvent {
+	events := make([]ProgramLogEvent, 0)
+	stack := make([]int, 0) // indexes of the running invocations
+
+	for _, line := range logs {
+		if programID, depth, ok := parseProgramInvoke(line); ok {
+			events = append(events, ProgramLogEvent{ProgramID: programID, Depth: depth})
+			stack = append(stack, len(events)-1)
+			continue
+		}
+		if len(stack) == 0 {
+			continue
+		}
+		event := &events[stack[len(stack)-1]]
+
+		switch {
+		case line == "Program "+event.ProgramID+" success":
+			event.Success = true
+			stack = stack[:len(stack)-1]
+		case strings.HasPrefix(line, "Program "+event.ProgramID+" failed: "):
+			event.Failure = strings.TrimPrefix(line, "Program "+event.ProgramID+" failed: ")
+			stack = stack[:len(stack)-1]
+		case strings.HasPrefix(line, "Program "+event.ProgramID+" consumed "):
+			var consumed, limit uint64
+			if _, err := fmt.Sscanf(
+				strings.TrimPrefix(line, "Program "+event.ProgramID+" "),
+				"consumed %d of %d compute units", &consumed, &limit,
+			); err == nil {
+				event.UnitsConsumed = consumed
+			}
+			event.Messages = append(event.Messages, line)
+		case strings.HasPrefix(line, programLogPrefix):
+			event.Logs = append(event.Logs, strings.TrimPrefix(line, programLogPrefix))
+			event.Messages = append(event.Messages, line)
+		case strings.HasPrefix(line, programDataPrefix):
+			event.Data = append(event.Data, strings.Fields(strings.TrimPrefix(line, programDataPrefix))...)
+			event.Messages = append(event.Messages, line)
+		default:
+			event.Messages = append(event.Messages, line)
+		}
+	}
+
+	return events
+}
+
+// parseProgramInvoke parses the "Program <id> invoke [<depth>]" log line.
+func parseProgramInvoke(line string) (programID string, depth int, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 || fields[0] != "Program" || fields[2] != "invoke" {
+		return "", 0, false
+	}
+	if _, err := fmt.Sscanf(fields[3], "[%d]", &depth); err != nil {
+		return "", 0, false
+	}
+
+	return fields[1], depth, true
+}
@@ -0,0 +1,85 @@
+package client_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProgramLogs(t *testing.T) {
+	t.Run("cross-program invocations", func(t *testing.T) {
+		fixture, err := os.ReadFile("testdata/program_logs.json")
+		require.NoError(t, err)
+		var logs []string
+		require.NoError(t, json.Unmarshal(fixture, &logs))
+
+		events := client.ParseProgramLogs(logs)
+		require.Len(t, events, 7)
+
+		type invocation struct {
+			programID string
+			depth     int
+			logs      []string
+			units     uint64
+		}
+		expected := []invocation{
+			{"ComputeBudget111111111111111111111111111111", 1, nil, 0},
+			{"ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL", 1, []string{"Create", "Initialize the associated token account"}, 20345},
+			{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", 2, []string{"Instruction: GetAccountDataSize"}, 1622},
+			{"11111111111111111111111111111111", 2, nil, 0},
+			{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", 2, []string{
+				"Instruction: InitializeImmutableOwner",
+				"Please upgrade to SPL Token 2022 for immutable owner support",
+			}, 1405},
+			{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", 2, []string{"Instruction: InitializeAccount3"}, 4188},
+			{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA", 1, []string{"Instruction: TransferChecked"}, 6200},
+		}
+		for i, e := range expected {
+			require.Equal(t, e.programID, events[i].ProgramID, "event %d", i)
+			require.Equal(t, e.depth, events[i].Depth, "event %d", i)
+			require.Equal(t, e.logs, events[i].Logs, "event %d", i)
+			require.Equal(t, e.units, events[i].UnitsConsumed, "event %d", i)
+			require.True(t, events[i].Success, "event %d", i)
+			require.Empty(t, events[i].Failure, "event %d", i)
+		}
+
+		// the nested invocations aren't included in the caller messages
+		require.Equal(t, []string{
+			"Program log: Create",
+			"Program log: Initialize the associated token account",
+			"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL consumed 20345 of 400000 compute units",
+		}, events[1].Messages)
+		require.Contains(t, events[2].Messages, "Program return: TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA pQAAAAAAAAA=")
+	})
+
+	t.Run("failed invocation and data", func(t *testing.T) {
+		events := client.ParseProgramLogs([]string{
+			"Program cndy3Z4yapfJBmL3ShUp5exZKqR3z33thTzeNMm2gRZ invoke [1]",
+			"Program data: Zm9v YmFy",
+			"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+			"Program log: Error: insufficient funds",
+			"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1",
+			"Program cndy3Z4yapfJBmL3ShUp5exZKqR3z33thTzeNMm2gRZ consumed 3000 of 200000 compute units",
+			"Program cndy3Z4yapfJBmL3ShUp5exZKqR3z33thTzeNMm2gRZ failed: custom program error: 0x1",
+		})
+		require.Len(t, events, 2)
+
+		require.Equal(t, []string{"Zm9v", "YmFy"}, events[0].Data)
+		require.False(t, events[0].Success)
+		require.Equal(t, "custom program error: 0x1", events[0].Failure)
+		require.EqualValues(t, 3000, events[0].UnitsConsumed)
+
+		require.Equal(t, 2, events[1].Depth)
+		require.Equal(t, []string{"Error: insufficient funds"}, events[1].Logs)
+		require.False(t, events[1].Success)
+		require.Equal(t, "custom program error: 0x1", events[1].Failure)
+	})
+
+	t.Run("no invocations", func(t *testing.T) {
+		require.Empty(t, client.ParseProgramLogs(nil))
+		require.Empty(t, client.ParseProgramLogs([]string{"Log truncated"}))
+	})
+}
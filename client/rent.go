@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dmitrymomot/solana/types"
+	"github.com/dmitrymomot/solana/utils"
+)
+
+// AccountType is the type of the account with the well-known size, see GetRentExemption.
+type AccountType string
+
+// AccountType enum.
+const (
+	AccountTypeMint  AccountType = "mint"
+	AccountTypeToken AccountType = "token"
+	AccountTypeNonce AccountType = "nonce"
+	AccountTypeStake AccountType = "stake"
+)
+
+// accountTypeSizes maps the account types to their data sizes.
+var accountTypeSizes = map[AccountType]uint64{
+	AccountTypeMint:  types.MintAccountSize,
+	AccountTypeToken: types.TokenAccountSize,
+	AccountTypeNonce: types.NonceAccountSize,
+	AccountTypeStake: types.StakeAccountSize,
+}
+
+// Size returns the data size of the account type in bytes, or 0 if the type is unknown.
+func (t AccountType) Size() uint64 {
+	return accountTypeSizes[t]
+}
+
+// GetRentExemption returns the minimum balance for rent exemption of the given account type in SOL,
+// e.g. to show the cost of the account creation before the transaction is signed.
+// Returns the rent as a SOL amount or an error.
+func (c *Client) GetRentExemption(ctx context.Context, accountType AccountType) (types.TokenAmount, error) {
+	size, ok := accountTypeSizes[accountType]
+	if !ok {
+		return types.TokenAmount{}, utils.StackErrors(
+			ErrGetMinimumBalanceForRentExemption,
+			fmt.Errorf("unsupported account type: %s", accountType),
+		)
+	}
+
+	rent, err := c.GetMinimumBalanceForRentExemption(ctx, size)
+	if err != nil {
+		return types.TokenAmount{}, err
+	}
+
+	return types.NewDefaultTokenAmount(rent), nil
+}
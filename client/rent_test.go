@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rentExemptionResult returns a getMinimumBalanceForRentExemption handler counting the calls.
+func rentExemptionResult(t *testing.T, calls *int32) func(params []json.RawMessage) interface{} {
+	return func(params []json.RawMessage) interface{} {
+		atomic.AddInt32(calls, 1)
+		var size uint64
+		require.NoError(t, json.Unmarshal(params[0], &size))
+		return (size + 128) * 6960
+	}
+}
+
+func TestGetMinimumBalanceForRentExemption_Cache(t *testing.T) {
+	var calls int32
+	sc := newMockClient(t, map[string]interface{}{
+		"getMinimumBalanceForRentExemption": rentExemptionResult(t, &calls),
+	})
+
+	for i := 0; i < 3; i++ {
+		rent, err := sc.GetMinimumBalanceForRentExemption(context.Background(), token.MintAccountSize)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1461600), rent)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	for i := 0; i < 3; i++ {
+		rent, err := sc.GetMinimumBalanceForRentExemption(context.Background(), token.TokenAccountSize)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2039280), rent)
+	}
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestGetMinimumBalanceForRentExemption_CacheDisabled(t *testing.T) {
+	var calls int32
+	sc := newMockClient(t, map[string]interface{}{
+		"getMinimumBalanceForRentExemption": rentExemptionResult(t, &calls),
+	}, client.DisableRentExemptionCache())
+
+	for i := 0; i < 3; i++ {
+		_, err := sc.GetMinimumBalanceForRentExemption(context.Background(), token.MintAccountSize)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestGetRentExemption(t *testing.T) {
+	var calls int32
+	sc := newMockClient(t, map[string]interface{}{
+		"getMinimumBalanceForRentExemption": rentExemptionResult(t, &calls),
+	})
+
+	tests := []struct {
+		accountType client.AccountType
+		want        uint64
+		wantUI      string
+	}{
+		{client.AccountTypeMint, 1461600, "0.0014616"},
+		{client.AccountTypeToken, 2039280, "0.00203928"},
+		{client.AccountTypeNonce, 1447680, "0.00144768"},
+		{client.AccountTypeStake, 2282880, "0.00228288"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.accountType), func(t *testing.T) {
+			rent, err := sc.GetRentExemption(context.Background(), tt.accountType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rent.Amount)
+			assert.Equal(t, uint8(9), rent.Decimals)
+			assert.Equal(t, tt.wantUI, rent.UIAmountString)
+		})
+	}
+
+	_, err := sc.GetRentExemption(context.Background(), client.AccountType("unknown"))
+	require.ErrorIs(t, err, client.ErrGetMinimumBalanceForRentExemption)
+}
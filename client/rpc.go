@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// rpcCall calls the given JSON-RPC method which is not wrapped by the solana-go-sdk
+// and decodes the result into T.
+// Returns the result or an error, including the JSON-RPC error returned by the node.
+func rpcCall[T any](ctx context.Context, c *Client, method string, params ...interface{}) (T, error) {
+	var result T
+
+	body, err := c.rpcClient.RpcClient.Call(ctx, append([]interface{}{method}, params...)...)
+	if err != nil {
+		return result, fmt.Errorf("rpc call %s: %w", method, err)
+	}
+
+	var resp rpc.JsonRpcResponse[T]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return result, fmt.Errorf("rpc call %s: failed to decode response: %w", method, err)
+	}
+	if resp.Error != nil {
+		return result, resp.Error
+	}
+
+	return resp.Result, nil
+}
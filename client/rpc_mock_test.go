@@ -0,0 +1,55 @@
+package client_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// newMockClient starts a fake JSON-RPC node which responds to the given methods
+// with the given results, and returns a client connected to it.
+// A result of the func() interface{} type is called on every request,
+// a result of the func(params []json.RawMessage) interface{} type also receives the request params.
+// A *rpc.JsonRpcError result is returned as the JSON-RPC error.
+func newMockClient(t *testing.T, results map[string]interface{}, opts ...client.ClientOption) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if result, ok := results[req.Method]; ok {
+			switch fn := result.(type) {
+			case func() interface{}:
+				result = fn()
+			case func(params []json.RawMessage) interface{}:
+				result = fn(req.Params)
+			}
+			if rpcErr, ok := result.(*rpc.JsonRpcError); ok {
+				resp["error"] = rpcErr
+			} else {
+				resp["result"] = result
+			}
+		} else {
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found: " + req.Method}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	return client.New(append([]client.ClientOption{client.SetSolanaEndpoint(srv.URL)}, opts...)...)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// maxSignaturesPageSize is the maximum number of signatures returned by a single getSignaturesForAddress request.
+const maxSignaturesPageSize = 1000
+
+type (
+	// IterateOptions defines the options of iterating over the wallet signatures.
+	IterateOptions struct {
+		Before     string         // optional; start iterating backwards from this signature (exclusive)
+		Until      string         // optional; stop iterating at this signature (exclusive)
+		PageSize   int            // optional; number of signatures per request, between 1 and 1000, default: 1000
+		Limit      int            // optional; maximum number of signatures to iterate over, default: no limit
+		Commitment rpc.Commitment // optional; overrides the client default commitment
+	}
+
+	// SignatureInfo represents a transaction signature of the wallet.
+	SignatureInfo struct {
+		Signature string      // base58 encoded transaction signature
+		Slot      uint64      // the slot that contains the transaction
+		BlockTime int64       // unix timestamp of the transaction; 0 if not available
+		Err       interface{} // transaction error; nil if the transaction succeeded
+		Memo      string      // memo associated with the transaction
+	}
+)
+
+// IterateSignatures iterates over the transaction signatures of the given base58 encoded address,
+// from the newest to the oldest one, fetching them page by page.
+// The signatures channel is closed when the iteration is over.
+// The errors channel receives at most one error and is closed after the signatures channel.
+// The iteration stops on the context cancellation.
+func (c *Client) IterateSignatures(ctx context.Context, base58Addr string, opts IterateOptions) (<-chan SignatureInfo, <-chan error) {
+	signatures := make(chan SignatureInfo)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(signatures)
+
+		if err := common.ValidateSolanaWalletAddr(base58Addr); err != nil {
+			errs <- utils.StackErrors(ErrIterateSignatures, err)
+			return
+		}
+
+		pageSize := opts.PageSize
+		if pageSize <= 0 || pageSize > maxSignaturesPageSize {
+			pageSize = maxSignaturesPageSize
+		}
+
+		before, count := opts.Before, 0
+		for {
+			limit := pageSize
+			if opts.Limit > 0 && opts.Limit-count < limit {
+				limit = opts.Limit - count
+			}
+
+			page, err := c.rpcClient.GetSignaturesForAddressWithConfig(ctx, base58Addr, client.GetSignaturesForAddressConfig{
+				Limit:      limit,
+				Before:     before,
+				Until:      opts.Until,
+				Commitment: c.getCommitment([]rpc.Commitment{opts.Commitment}),
+			})
+			if err != nil {
+				errs <- utils.StackErrors(ErrIterateSignatures, err)
+				return
+			}
+
+			for _, sig := range page {
+				info := SignatureInfo{
+					Signature: sig.Signature,
+					Slot:      sig.Slot,
+					Err:       sig.Err,
+				}
+				if sig.BlockTime != nil {
+					info.BlockTime = *sig.BlockTime
+				}
+				if sig.Memo != nil {
+					info.Memo = *sig.Memo
+				}
+
+				select {
+				case signatures <- info:
+					count++
+				case <-ctx.Done():
+					errs <- utils.StackErrors(ErrIterateSignatures, ErrContextDone, ctx.Err())
+					return
+				}
+			}
+
+			if len(page) < limit || (opts.Limit > 0 && count >= opts.Limit) {
+				return
+			}
+			before = page[len(page)-1].Signature
+		}
+	}()
+
+	return signatures, errs
+}
@@ -0,0 +1,145 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signaturesPages returns the mock getSignaturesForAddress handler which serves the given signatures
+// from the newest to the oldest one, respecting the limit, before and until params.
+func signaturesPages(t *testing.T, signatures []string, requests *int) func(params []json.RawMessage) interface{} {
+	return func(params []json.RawMessage) interface{} {
+		*requests++
+
+		var cfg rpc.GetSignaturesForAddressConfig
+		require.Len(t, params, 2)
+		require.NoError(t, json.Unmarshal(params[1], &cfg))
+
+		start := 0
+		if cfg.Before != "" {
+			for i, sig := range signatures {
+				if sig == cfg.Before {
+					start = i + 1
+				}
+			}
+		}
+
+		page := []rpc.SignatureWithStatus{}
+		for i := start; i < len(signatures) && len(page) < cfg.Limit; i++ {
+			if signatures[i] == cfg.Until {
+				break
+			}
+			blockTime := int64(1700000000 - i)
+			page = append(page, rpc.SignatureWithStatus{
+				Signature: signatures[i],
+				Slot:      uint64(100 - i),
+				BlockTime: &blockTime,
+			})
+		}
+		return page
+	}
+}
+
+func collectSignatures(sigs <-chan client.SignatureInfo, errs <-chan error) ([]string, error) {
+	var result []string
+	for sig := range sigs {
+		result = append(result, sig.Signature)
+	}
+	return result, <-errs
+}
+
+func TestIterateSignatures(t *testing.T) {
+	addr := types.NewAccount().PublicKey.ToBase58()
+	signatures := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		signatures = append(signatures, fmt.Sprintf("sig%d", i))
+	}
+
+	tests := []struct {
+		name         string
+		opts         client.IterateOptions
+		want         []string
+		wantRequests int
+	}{
+		{
+			name:         "three pages",
+			opts:         client.IterateOptions{PageSize: 3},
+			want:         signatures,
+			wantRequests: 3,
+		},
+		{
+			name:         "before",
+			opts:         client.IterateOptions{PageSize: 3, Before: "sig1"},
+			want:         signatures[2:],
+			wantRequests: 2,
+		},
+		{
+			name:         "until",
+			opts:         client.IterateOptions{PageSize: 3, Until: "sig5"},
+			want:         signatures[:5],
+			wantRequests: 2,
+		},
+		{
+			name:         "limit",
+			opts:         client.IterateOptions{PageSize: 3, Limit: 4},
+			want:         signatures[:4],
+			wantRequests: 2,
+		},
+		{
+			name:         "full last page",
+			opts:         client.IterateOptions{PageSize: 7},
+			want:         signatures,
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			c := newMockClient(t, map[string]interface{}{
+				"getSignaturesForAddress": signaturesPages(t, signatures, &requests),
+			})
+
+			got, err := collectSignatures(c.IterateSignatures(context.Background(), addr, tt.opts))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
+
+func TestIterateSignatures_ContextCancel(t *testing.T) {
+	var requests int
+	c := newMockClient(t, map[string]interface{}{
+		"getSignaturesForAddress": signaturesPages(t, []string{"sig0", "sig1", "sig2", "sig3"}, &requests),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigs, errs := c.IterateSignatures(ctx, types.NewAccount().PublicKey.ToBase58(), client.IterateOptions{PageSize: 2})
+	first := <-sigs
+	assert.Equal(t, "sig0", first.Signature)
+	assert.Equal(t, int64(1700000000), first.BlockTime)
+	cancel()
+
+	_, err := collectSignatures(sigs, errs)
+	require.ErrorIs(t, err, client.ErrIterateSignatures)
+}
+
+func TestIterateSignatures_Errors(t *testing.T) {
+	c := newMockClient(t, nil)
+
+	_, err := collectSignatures(c.IterateSignatures(context.Background(), "invalid", client.IterateOptions{}))
+	require.ErrorIs(t, err, client.ErrIterateSignatures)
+
+	_, err = collectSignatures(c.IterateSignatures(context.Background(), types.NewAccount().PublicKey.ToBase58(), client.IterateOptions{}))
+	require.ErrorIs(t, err, client.ErrIterateSignatures)
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// SimulationResult is the result of the transaction simulation.
+type SimulationResult struct {
+	Err           *TransactionError // transaction error; nil if the simulation succeeded
+	Logs          []string          // program log messages
+	UnitsConsumed uint64            // compute units consumed by the transaction
+}
+
+// SimulationError is the error of the failed transaction simulation.
+// It unwraps to *TransactionError.
+type SimulationError struct {
+	Err  *TransactionError // transaction error reported by the node
+	Logs []string          // program log messages
+}
+
+// Error returns the error message including the program logs.
+// Implements the error interface.
+func (e *SimulationError) Error() string {
+	msg := fmt.Sprintf("simulation failed: %s", e.Err)
+	if len(e.Logs) > 0 {
+		msg = fmt.Sprintf("%s; logs:\n%s", msg, strings.Join(e.Logs, "\n"))
+	}
+	return msg
+}
+
+// Unwrap returns the transaction error.
+func (e *SimulationError) Unwrap() error {
+	return e.Err
+}
+
+// SimulateTransaction simulates the given base64 encoded transaction.
+// The signatures aren't verified and the recent blockhash is replaced with the latest one,
+// so the transaction can be simulated before it's signed by all the signers.
+// The failed simulation isn't an error: it's reported by the Err field of the result,
+// use SimulationResult.ToError to get it as an error.
+// Returns the simulation result or an error if the simulation can't be run.
+func (c *Client) SimulateTransaction(ctx context.Context, txSource string) (*SimulationResult, error) {
+	if _, err := utils.DecodeTransaction(txSource); err != nil {
+		return nil, utils.StackErrors(ErrSimulateTransaction, ErrDeserializeTransaction, err)
+	}
+
+	result, err := rpcCall[rpc.ValueWithContext[struct {
+		Err           *TransactionError `json:"err"`
+		Logs          []string          `json:"logs"`
+		UnitsConsumed uint64            `json:"unitsConsumed"`
+	}]](ctx, c, "simulateTransaction", txSource, rpc.SimulateTransactionConfig{
+		Encoding:               rpc.SimulateTransactionEncodingBase64,
+		Commitment:             c.getCommitment(nil),
+		ReplaceRecentBlockhash: true,
+	})
+	if err != nil {
+		return nil, utils.StackErrors(ErrSimulateTransaction, err)
+	}
+
+	return &SimulationResult{
+		Err:           result.Value.Err,
+		Logs:          result.Value.Logs,
+		UnitsConsumed: result.Value.UnitsConsumed,
+	}, nil
+}
+
+// ToError returns *SimulationError if the simulation failed, otherwise nil.
+func (r *SimulationResult) ToError() error {
+	if r.Err == nil {
+		return nil
+	}
+	return &SimulationError{Err: r.Err, Logs: r.Logs}
+}
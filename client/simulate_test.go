@@ -0,0 +1,82 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateTransaction(t *testing.T) {
+	txSource := transferTransaction(t)
+
+	t.Run("success", func(t *testing.T) {
+		var config map[string]interface{}
+		sc := newMockClient(t, map[string]interface{}{
+			"simulateTransaction": func(params []json.RawMessage) interface{} {
+				require.Len(t, params, 2)
+				require.NoError(t, json.Unmarshal(params[1], &config))
+				return withContext(map[string]interface{}{
+					"err":           nil,
+					"logs":          []string{"Program 11111111111111111111111111111111 success"},
+					"unitsConsumed": 150,
+				})
+			},
+		})
+
+		result, err := sc.SimulateTransaction(context.Background(), txSource)
+		require.NoError(t, err)
+		assert.Nil(t, result.Err)
+		assert.NoError(t, result.ToError())
+		assert.Equal(t, []string{"Program 11111111111111111111111111111111 success"}, result.Logs)
+		assert.EqualValues(t, 150, result.UnitsConsumed)
+
+		assert.Equal(t, "base64", config["encoding"])
+		assert.Equal(t, true, config["replaceRecentBlockhash"])
+		assert.Nil(t, config["sigVerify"])
+	})
+
+	t.Run("failed simulation", func(t *testing.T) {
+		logs := []string{
+			"Program 11111111111111111111111111111111 invoke [1]",
+			"Transfer: insufficient lamports 0, need 1000",
+			"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+		}
+		sc := newMockClient(t, map[string]interface{}{
+			"simulateTransaction": withContext(map[string]interface{}{
+				"err":  map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 1}}},
+				"logs": logs,
+			}),
+		})
+
+		result, err := sc.SimulateTransaction(context.Background(), txSource)
+		require.NoError(t, err)
+		require.NotNil(t, result.Err)
+		assert.Equal(t, 0, result.Err.InstructionIndex)
+		require.NotNil(t, result.Err.CustomCode)
+		assert.EqualValues(t, 1, *result.Err.CustomCode)
+
+		err = result.ToError()
+		var simErr *client.SimulationError
+		require.True(t, errors.As(err, &simErr))
+		assert.Equal(t, logs, simErr.Logs)
+		assert.Contains(t, err.Error(), "custom program error: 0x1")
+		assert.Contains(t, err.Error(), "Transfer: insufficient lamports 0, need 1000")
+
+		var txErr *client.TransactionError
+		require.ErrorAs(t, err, &txErr)
+		assert.Equal(t, client.TransactionErrorInstructionError, txErr.Type)
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{})
+
+		_, err := sc.SimulateTransaction(context.Background(), "not a transaction")
+		require.ErrorIs(t, err, client.ErrSimulateTransaction)
+		require.ErrorIs(t, err, client.ErrDeserializeTransaction)
+	})
+}
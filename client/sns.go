@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// ResolveSNSDomain returns the owner of the given .sol domain, e.g. "bonfida.sol",
+// which is the wallet the tokens sent to the domain should go to.
+// Returns ErrSNSDomainNotFound if the domain is not registered.
+func (c *Client) ResolveSNSDomain(ctx context.Context, domain string) (common.PublicKey, error) {
+	nameAccount, err := commonx.DeriveSNSDomainKey(domain)
+	if err != nil {
+		return common.PublicKey{}, utils.StackErrors(ErrResolveSNSDomain, err)
+	}
+
+	data, err := c.getSNSAccountData(ctx, nameAccount, commonx.SNSNameRegistryHeaderSize)
+	if err != nil {
+		return common.PublicKey{}, utils.StackErrors(ErrResolveSNSDomain, err)
+	}
+
+	// name registry header: parent name (32 bytes), owner (32 bytes), class (32 bytes)
+	return common.PublicKeyFromBytes(data[32:64]), nil
+}
+
+// GetFavoriteDomain returns the favorite (primary) .sol domain of the given wallet, e.g. "bonfida.sol".
+// Returns ErrSNSDomainNotFound if the wallet has no favorite domain.
+// Favorite subdomains are not supported.
+func (c *Client) GetFavoriteDomain(ctx context.Context, base58Owner string) (string, error) {
+	if err := commonx.ValidateSolanaWalletAddr(base58Owner); err != nil {
+		return "", utils.StackErrors(ErrGetFavoriteDomain, err)
+	}
+
+	favoriteKey, err := commonx.DeriveSNSFavoriteDomainKey(common.PublicKeyFromString(base58Owner))
+	if err != nil {
+		return "", utils.StackErrors(ErrGetFavoriteDomain, err)
+	}
+
+	// favorite domain account: tag (1 byte), name account (32 bytes)
+	favorite, err := c.getSNSAccountData(ctx, favoriteKey, 33)
+	if err != nil {
+		return "", utils.StackErrors(ErrGetFavoriteDomain, err)
+	}
+
+	reverseKey, err := commonx.DeriveSNSReverseKey(common.PublicKeyFromBytes(favorite[1:33]))
+	if err != nil {
+		return "", utils.StackErrors(ErrGetFavoriteDomain, err)
+	}
+
+	// reverse lookup account: name registry header, then the borsh encoded domain name
+	reverse, err := c.getSNSAccountData(ctx, reverseKey, commonx.SNSNameRegistryHeaderSize+4)
+	if err != nil {
+		return "", utils.StackErrors(ErrGetFavoriteDomain, err)
+	}
+	name := reverse[commonx.SNSNameRegistryHeaderSize+4:]
+	length := binary.LittleEndian.Uint32(reverse[commonx.SNSNameRegistryHeaderSize:])
+	if uint64(length) > uint64(len(name)) || length == 0 {
+		return "", utils.StackErrors(ErrGetFavoriteDomain, fmt.Errorf("invalid reverse lookup account data"))
+	}
+
+	return string(name[:length]) + ".sol", nil
+}
+
+// getSNSAccountData returns the data of the given name service account,
+// which must be at least minSize bytes long.
+// Returns ErrSNSDomainNotFound if the account does not exist or is too short.
+func (c *Client) getSNSAccountData(ctx context.Context, account common.PublicKey, minSize int) ([]byte, error) {
+	info, err := c.GetAccountInfo(ctx, account.ToBase58(), AccountInfoOptions{})
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, ErrSNSDomainNotFound
+		}
+		return nil, err
+	}
+	if len(info.Data) < minSize {
+		return nil, ErrSNSDomainNotFound
+	}
+
+	return info.Data, nil
+}
@@ -0,0 +1,91 @@
+package client_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountsByAddress returns a getAccountInfo handler which serves the data of the given accounts.
+func accountsByAddress(t *testing.T, accounts map[common.PublicKey][]byte) func(params []json.RawMessage) interface{} {
+	return func(params []json.RawMessage) interface{} {
+		var addr string
+		require.NoError(t, json.Unmarshal(params[0], &addr))
+
+		data, ok := accounts[common.PublicKeyFromString(addr)]
+		if !ok {
+			return withContext(nil)
+		}
+		return withContext(accountData(data))
+	}
+}
+
+// nameRegistry returns the name registry account data with the given owner and name data.
+func nameRegistry(parent, owner, class common.PublicKey, data []byte) []byte {
+	result := append(append(append([]byte{}, parent.Bytes()...), owner.Bytes()...), class.Bytes()...)
+	return append(result, data...)
+}
+
+func TestResolveSNSDomain(t *testing.T) {
+	// bonfida.sol name registry account
+	nameAccount := common.PublicKeyFromString("Crf8hzfthWGbGbLTVCiqRqV5MVnbpHB1L9KQMd6gsinb")
+	owner := types.NewAccount().PublicKey
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": accountsByAddress(t, map[common.PublicKey][]byte{
+			nameAccount: nameRegistry(commonx.SNSRootDomain, owner, common.PublicKey{}, make([]byte, 1000)),
+		}),
+	})
+
+	resolved, err := sc.ResolveSNSDomain(context.Background(), "bonfida.sol")
+	require.NoError(t, err)
+	assert.Equal(t, owner, resolved)
+
+	_, err = sc.ResolveSNSDomain(context.Background(), "unregistered.sol")
+	require.ErrorIs(t, err, client.ErrResolveSNSDomain)
+	require.ErrorIs(t, err, client.ErrSNSDomainNotFound)
+
+	_, err = sc.ResolveSNSDomain(context.Background(), "a.b.c.sol")
+	require.ErrorIs(t, err, commonx.ErrInvalidSNSDomain)
+}
+
+func TestGetFavoriteDomain(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	nameAccount, err := commonx.DeriveSNSDomainKey("bonfida.sol")
+	require.NoError(t, err)
+
+	favoriteKey, err := commonx.DeriveSNSFavoriteDomainKey(owner)
+	require.NoError(t, err)
+	reverseKey, err := commonx.DeriveSNSReverseKey(nameAccount)
+	require.NoError(t, err)
+
+	name := make([]byte, 4, 4+len("bonfida"))
+	binary.LittleEndian.PutUint32(name, uint32(len("bonfida")))
+	name = append(name, "bonfida"...)
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": accountsByAddress(t, map[common.PublicKey][]byte{
+			favoriteKey: append([]byte{1}, nameAccount.Bytes()...),
+			reverseKey:  nameRegistry(common.PublicKey{}, owner, commonx.SNSReverseLookupClass, name),
+		}),
+	})
+
+	domain, err := sc.GetFavoriteDomain(context.Background(), owner.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, "bonfida.sol", domain)
+
+	_, err = sc.GetFavoriteDomain(context.Background(), types.NewAccount().PublicKey.ToBase58())
+	require.ErrorIs(t, err, client.ErrGetFavoriteDomain)
+	require.ErrorIs(t, err, client.ErrSNSDomainNotFound)
+
+	_, err = sc.GetFavoriteDomain(context.Background(), "invalid")
+	require.ErrorIs(t, err, client.ErrGetFavoriteDomain)
+}
@@ -5,24 +5,41 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
-	"strings"
+	"sort"
+	"strconv"
 
+	commonx "github.com/dmitrymomot/solana/common"
 	"github.com/dmitrymomot/solana/metadata"
 	"github.com/dmitrymomot/solana/token_metadata"
 	"github.com/dmitrymomot/solana/types"
 	"github.com/dmitrymomot/solana/utils"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/client"
 	"github.com/portto/solana-go-sdk/common"
 	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
 	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/rpc"
 )
 
 // GetTokenAccountInfo returns the token account information for a given token account address.
 // This is a wrapper around the GetTokenAccount function from the solana-go-sdk.
 // base58AtaAddr is the base58 encoded address of the associated token account.
 // The function returns the token account information or an error.
+// The error matches ErrTokenAccountNotFound if the account does not exist.
 func (c *Client) GetTokenAccountInfo(ctx context.Context, base58AtaAddr string) (token.TokenAccount, error) {
-	ta, err := c.rpcClient.GetTokenAccount(ctx, base58AtaAddr)
+	accInfo, err := c.rpcClient.GetAccountInfoWithConfig(ctx, base58AtaAddr, client.GetAccountInfoConfig{
+		Commitment: c.commitment,
+	})
+	if err != nil {
+		return token.TokenAccount{}, utils.StackErrors(ErrGetTokenAccount, err)
+	}
+	if accInfo.Owner == (common.PublicKey{}) {
+		return token.TokenAccount{}, utils.StackErrors(ErrGetTokenAccount, ErrTokenAccountNotFound)
+	}
+
+	ta, err := token.DeserializeTokenAccount(accInfo.Data, accInfo.Owner)
 	if err != nil {
 		return token.TokenAccount{}, utils.StackErrors(ErrGetTokenAccount, err)
 	}
@@ -31,8 +48,11 @@ func (c *Client) GetTokenAccountInfo(ctx context.Context, base58AtaAddr string)
 }
 
 // GetMintInfo returns the token mint information for a given mint address.
-func (c *Client) GetMintInfo(ctx context.Context, base58MintAddr string) (token.MintAccount, error) {
-	accInfo, err := c.rpcClient.GetAccountInfo(ctx, base58MintAddr)
+// The optional commitment overrides the client default commitment.
+func (c *Client) GetMintInfo(ctx context.Context, base58MintAddr string, commitment ...rpc.Commitment) (token.MintAccount, error) {
+	accInfo, err := c.rpcClient.GetAccountInfoWithConfig(ctx, base58MintAddr, client.GetAccountInfoConfig{
+		Commitment: c.getCommitment(commitment),
+	})
 	if err != nil {
 		return token.MintAccount{}, utils.StackErrors(ErrGetMintInfo, err)
 	}
@@ -45,12 +65,69 @@ func (c *Client) GetMintInfo(ctx context.Context, base58MintAddr string) (token.
 	return mintInfo, nil
 }
 
+// MintFullInfo is the token mint information including its authorities.
+type MintFullInfo struct {
+	Supply          types.TokenAmount // current token supply
+	Decimals        uint8             // number of decimals of the token
+	MintAuthority   *common.PublicKey // authority allowed to mint new tokens; nil if revoked, i.e. the supply is fixed
+	FreezeAuthority *common.PublicKey // authority allowed to freeze token accounts; nil if revoked or never set
+}
+
+// IsFixedSupply returns true if the mint authority is revoked, so no more tokens can be minted.
+func (i MintFullInfo) IsFixedSupply() bool {
+	return i.MintAuthority == nil
+}
+
+// GetMintFullInfo returns the token mint information for a given mint address,
+// including the mint and freeze authorities.
+// The optional commitment overrides the client default commitment.
+func (c *Client) GetMintFullInfo(ctx context.Context, base58MintAddr string, commitment ...rpc.Commitment) (MintFullInfo, error) {
+	mintInfo, err := c.GetMintInfo(ctx, base58MintAddr, commitment...)
+	if err != nil {
+		return MintFullInfo{}, err
+	}
+
+	return MintFullInfo{
+		Supply:          types.NewTokenAmountFromLamports(mintInfo.Supply, mintInfo.Decimals),
+		Decimals:        mintInfo.Decimals,
+		MintAuthority:   mintInfo.MintAuthority,
+		FreezeAuthority: mintInfo.FreezeAuthority,
+	}, nil
+}
+
+// GetTokenProgramForMint returns the token program which owns the given mint account:
+// the classic SPL Token program or the Token-2022 program.
+// The result is cached per mint for the client's lifetime, since the mint owner never changes.
+// Returns ErrUnsupportedTokenProgram if the account is owned by another program.
+func (c *Client) GetTokenProgramForMint(ctx context.Context, base58MintAddr string) (common.PublicKey, error) {
+	if programID, ok := c.tokenProgramCache.Load(base58MintAddr); ok {
+		return programID.(common.PublicKey), nil
+	}
+
+	accInfo, err := c.rpcClient.GetAccountInfoWithConfig(ctx, base58MintAddr, client.GetAccountInfoConfig{
+		Commitment: c.commitment,
+	})
+	if err != nil {
+		return common.PublicKey{}, utils.StackErrors(ErrGetMintInfo, err)
+	}
+	if !commonx.IsTokenProgramID(accInfo.Owner) {
+		return common.PublicKey{}, utils.StackErrors(ErrGetMintInfo, ErrUnsupportedTokenProgram)
+	}
+
+	c.tokenProgramCache.Store(base58MintAddr, accInfo.Owner)
+
+	return accInfo.Owner, nil
+}
+
 // GetTokenSupply returns the token supply for a given mint address.
 // This is a wrapper around the GetTokenSupply function from the solana-go-sdk.
 // base58MintAddr is the base58 encoded address of the token mint.
+// The optional commitment overrides the client default commitment.
 // The function returns the token supply and decimals or an error.
-func (c *Client) GetTokenSupply(ctx context.Context, base58MintAddr string) (types.TokenAmount, error) {
-	result, err := c.rpcClient.GetTokenSupply(ctx, base58MintAddr)
+func (c *Client) GetTokenSupply(ctx context.Context, base58MintAddr string, commitment ...rpc.Commitment) (types.TokenAmount, error) {
+	result, err := c.rpcClient.GetTokenSupplyWithConfig(ctx, base58MintAddr, client.GetTokenSupplyConfig{
+		Commitment: c.getCommitment(commitment),
+	})
 	if err != nil {
 		return types.TokenAmount{}, utils.StackErrors(ErrGetTokenSupply, err)
 	}
@@ -58,7 +135,9 @@ func (c *Client) GetTokenSupply(ctx context.Context, base58MintAddr string) (typ
 	return types.NewTokenAmountFromLamports(result.Amount, result.Decimals), nil
 }
 
-// GetMasterEditionSupply returns the current supply and max supply of a master edition
+// GetMasterEditionSupply returns the current supply and max supply of a master edition.
+// A fully printed master edition is a valid state: current equals max and no error is returned.
+// The max is math.MaxUint64 if the master edition supply is unlimited, see GetMasterEditionState.
 func (c *Client) GetMasterEditionSupply(ctx context.Context, masterMint common.PublicKey) (current, max uint64, err error) {
 	editionInfo, err := c.GetMasterEditionInfo(ctx, masterMint.ToBase58())
 	if err != nil || editionInfo == nil {
@@ -73,18 +152,86 @@ func (c *Client) GetMasterEditionSupply(ctx context.Context, masterMint common.P
 			ErrTokenIsNotMasterEdition,
 		)
 	}
-	if editionInfo.MaxSupply == 0 || editionInfo.Supply == editionInfo.MaxSupply {
-		return 0, 0, utils.StackErrors(
-			ErrGetMasterEditionCurrentSupply,
-			ErrMaxSupplyReached,
-		)
+
+	if editionInfo.UnlimitedSupply {
+		return editionInfo.Supply, math.MaxUint64, nil
 	}
 
 	return editionInfo.Supply, editionInfo.MaxSupply, nil
 }
 
+// MasterEditionState represents the printing state of a master edition.
+type MasterEditionState struct {
+	Supply    uint64  // number of the printed editions
+	MaxSupply *uint64 // max number of the printed editions; nil if the supply is unlimited
+	CanPrint  bool    // whether more editions can be printed
+}
+
+// GetMasterEditionState returns the printing state of a master edition.
+// Unlike GetMasterEditionSupply, it distinguishes an unlimited max supply from a zero one,
+// check MasterEditionState.CanPrint to find out whether more editions can be printed.
+// Note: the token metadata program has no instruction to change the max supply
+// of an existing master edition, so it is fixed once the master edition is created.
+func (c *Client) GetMasterEditionState(ctx context.Context, masterMint common.PublicKey) (MasterEditionState, error) {
+	masterEditionPubKey, err := token_metadata.DeriveEditionPubkey(masterMint)
+	if err != nil {
+		return MasterEditionState{}, utils.StackErrors(ErrGetMasterEditionState, err)
+	}
+
+	account, err := c.rpcClient.GetAccountInfo(ctx, masterEditionPubKey.ToBase58())
+	if err != nil {
+		return MasterEditionState{}, utils.StackErrors(ErrGetMasterEditionState, err)
+	}
+	if len(account.Data) == 0 {
+		return MasterEditionState{}, utils.StackErrors(ErrGetMasterEditionState, ErrTokenIsNotMasterEdition)
+	}
+
+	var masterEdition metaplex_token_metadata.MasterEditionV2
+	if err := borsh.Deserialize(&masterEdition, account.Data); err != nil {
+		return MasterEditionState{}, utils.StackErrors(ErrGetMasterEditionState, ErrTokenIsNotMasterEdition, err)
+	}
+	if masterEdition.Key != metaplex_token_metadata.KeyMasterEditionV1 &&
+		masterEdition.Key != metaplex_token_metadata.KeyMasterEditionV2 {
+		return MasterEditionState{}, utils.StackErrors(ErrGetMasterEditionState, ErrTokenIsNotMasterEdition)
+	}
+
+	return MasterEditionState{
+		Supply:    masterEdition.Supply,
+		MaxSupply: masterEdition.MaxSupply,
+		CanPrint:  masterEdition.MaxSupply == nil || masterEdition.Supply < *masterEdition.MaxSupply,
+	}, nil
+}
+
 // GetTokenMetadata returns the metadata of a token
+// The result is cached if the metadata cache is set, see SetMetadataCache;
+// each call returns a copy, so changing it doesn't affect the cache.
 func (c *Client) GetTokenMetadata(ctx context.Context, base58MintAddr string) (*token_metadata.Metadata, error) {
+	if c.metadataCache == nil {
+		return c.getTokenMetadata(ctx, base58MintAddr)
+	}
+
+	key := tokenMetadataCacheKey(base58MintAddr)
+	if cached, ok := c.metadataCache.Get(key); ok {
+		if md, ok := cached.(*token_metadata.Metadata); ok {
+			if md, err := cloneMetadata(md); err == nil {
+				return md, nil
+			}
+		}
+	}
+
+	md, err := c.getTokenMetadata(ctx, base58MintAddr)
+	if err != nil {
+		return nil, err
+	}
+	if cached, err := cloneMetadata(md); err == nil {
+		c.metadataCache.Set(key, cached, c.metadataCacheTTL)
+	}
+
+	return md, nil
+}
+
+// getTokenMetadata fetches the token metadata of the given mint address.
+func (c *Client) getTokenMetadata(ctx context.Context, base58MintAddr string) (*token_metadata.Metadata, error) {
 	if base58MintAddr == "" {
 		return nil, utils.StackErrors(
 			ErrInvalidPublicKey,
@@ -112,7 +259,7 @@ func (c *Client) GetTokenMetadata(ctx context.Context, base58MintAddr string) (*
 			errors.New("no metadata found"),
 		)
 	}
-	metadata, err := token_metadata.DeserializeMetadata(metadataAccountInfo.Data)
+	metadata, err := token_metadata.DeserializeMetadata(ctx, metadataAccountInfo.Data)
 	if err != nil {
 		return nil, utils.StackErrors(ErrGetTokenMetadata, err)
 	}
@@ -130,7 +277,7 @@ func (c *Client) GetTokenMetadata(ctx context.Context, base58MintAddr string) (*
 			return nil, utils.StackErrors(ErrGetTokenMetadata, err)
 		}
 
-		edition, err := token_metadata.DeserializeEdition(editionAccountInfo.Data, c.rpcClient.GetAccountInfo)
+		edition, err := token_metadata.DeserializeEdition(ctx, editionAccountInfo.Data, c.rpcClient.GetAccountInfo)
 		if err != nil {
 			return nil, utils.StackErrors(ErrGetTokenMetadata, err)
 		}
@@ -190,7 +337,7 @@ func (c *Client) GetEditionInfo(ctx context.Context, base58MintAddr string) (*to
 		)
 	}
 
-	edition, err := token_metadata.DeserializeEdition(editionData.Data, c.rpcClient.GetAccountInfo)
+	edition, err := token_metadata.DeserializeEdition(ctx, editionData.Data, c.rpcClient.GetAccountInfo)
 	if err != nil {
 		return nil, utils.StackErrors(
 			ErrGetEditionInfo,
@@ -201,9 +348,110 @@ func (c *Client) GetEditionInfo(ctx context.Context, base58MintAddr string) (*to
 	return edition, nil
 }
 
+// GetTokenRecord returns the token record of the programmable NFT held by the owner
+// in the associated token account, which stores the token delegate and lock state.
+// Returns ErrTokenRecordNotFound if the record does not exist, e.g. the token isn't a programmable NFT.
+func (c *Client) GetTokenRecord(ctx context.Context, base58MintAddr, base58OwnerAddr string) (*token_metadata.TokenRecord, error) {
+	if err := commonx.ValidateAccountAddr(base58MintAddr); err != nil {
+		return nil, utils.StackErrors(ErrGetTokenRecord, err)
+	}
+	if err := commonx.ValidateAccountAddr(base58OwnerAddr); err != nil {
+		return nil, utils.StackErrors(ErrGetTokenRecord, err)
+	}
+
+	mint := common.PublicKeyFromString(base58MintAddr)
+	ata, err := commonx.DeriveTokenAccountPubkey(common.PublicKeyFromString(base58OwnerAddr), mint)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenRecord, err)
+	}
+	recordPubkey, err := token_metadata.DeriveTokenRecordPubkey(mint, ata)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenRecord, err)
+	}
+
+	accInfo, err := c.rpcClient.GetAccountInfoWithConfig(ctx, recordPubkey.ToBase58(), client.GetAccountInfoConfig{
+		Commitment: c.commitment,
+	})
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenRecord, err)
+	}
+	if accInfo.Owner == (common.PublicKey{}) {
+		return nil, utils.StackErrors(ErrGetTokenRecord, ErrTokenRecordNotFound)
+	}
+
+	record, err := token_metadata.DeserializeTokenRecord(accInfo.Data)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenRecord, err)
+	}
+
+	return record, nil
+}
+
+// IsCollectionAuthorityApproved reports whether the given base58 encoded authority is approved
+// to manage the collection of the given base58 encoded collection mint,
+// i.e. the collection authority record exists, see instructions.ApproveCollectionAuthority.
+// The collection update authority doesn't need the record, so it's not reported as approved.
+func (c *Client) IsCollectionAuthorityApproved(ctx context.Context, base58CollectionMint, base58Authority string) (bool, error) {
+	if err := commonx.ValidateAccountAddr(base58CollectionMint); err != nil {
+		return false, utils.StackErrors(ErrCheckCollectionAuthority, err)
+	}
+	if err := commonx.ValidateAccountAddr(base58Authority); err != nil {
+		return false, utils.StackErrors(ErrCheckCollectionAuthority, err)
+	}
+
+	recordPubkey, err := token_metadata.DeriveCollectionAuthorityRecord(
+		common.PublicKeyFromString(base58CollectionMint),
+		common.PublicKeyFromString(base58Authority),
+	)
+	if err != nil {
+		return false, utils.StackErrors(ErrCheckCollectionAuthority, err)
+	}
+
+	accInfo, err := c.rpcClient.GetAccountInfoWithConfig(ctx, recordPubkey.ToBase58(), client.GetAccountInfoConfig{
+		Commitment: c.commitment,
+	})
+	if err != nil {
+		return false, utils.StackErrors(ErrCheckCollectionAuthority, err)
+	}
+
+	return accInfo.Owner == common.MetaplexTokenMetaProgramID &&
+		len(accInfo.Data) > 0 &&
+		metaplex_token_metadata.Key(accInfo.Data[0]) == metaplex_token_metadata.KeyCollectionAuthorityRecord, nil
+}
+
 // GetFungibleTokenMetadata returns the on-chain SPL token metadata by the given base58 encoded SPL token mint address.
 // Returns the token metadata or an error.
-func (c *Client) GetFungibleTokenMetadata(ctx context.Context, base58MintAddr string) (result *metadata.Metadata, err error) {
+// The result is cached if the metadata cache is set, see SetMetadataCache;
+// each call returns a copy, so changing it doesn't affect the cache.
+func (c *Client) GetFungibleTokenMetadata(ctx context.Context, base58MintAddr string) (*metadata.Metadata, error) {
+	if c.metadataCache == nil {
+		return c.getFungibleTokenMetadata(ctx, base58MintAddr)
+	}
+
+	key := fungibleTokenMetadataCacheKey(base58MintAddr)
+	if cached, ok := c.metadataCache.Get(key); ok {
+		if md, ok := cached.(*metadata.Metadata); ok {
+			if md, err := cloneMetadata(md); err == nil {
+				return md, nil
+			}
+		}
+	}
+
+	md, err := c.getFungibleTokenMetadata(ctx, base58MintAddr)
+	if err != nil {
+		return md, err
+	}
+	if md != nil {
+		if cached, err := cloneMetadata(md); err == nil {
+			c.metadataCache.Set(key, cached, c.metadataCacheTTL)
+		}
+	}
+
+	return md, nil
+}
+
+// getFungibleTokenMetadata fetches the fungible token metadata of the given mint address.
+func (c *Client) getFungibleTokenMetadata(ctx context.Context, base58MintAddr string) (result *metadata.Metadata, err error) {
 	// fallback to the deprecated metadata account if the given mint address has no on-chain metadata
 	defer func() {
 		if result == nil || err != nil || result.Name == "" || result.Symbol == "" || result.Image == "" {
@@ -251,8 +499,8 @@ func (c *Client) GetFungibleTokenMetadata(ctx context.Context, base58MintAddr st
 		Symbol: md.Data.Symbol,
 	}
 
-	if md.Data.Uri != "" && strings.HasPrefix(md.Data.Uri, "http") {
-		mde, err := metadata.MetadataFromURI(md.Data.Uri)
+	if metadata.IsSupportedURI(md.Data.Uri) {
+		mde, err := metadata.MetadataFromURIWithContext(ctx, nil, md.Data.Uri)
 		if err != nil {
 			return result, fmt.Errorf("failed to get additional metadata from uri: %w", err)
 		}
@@ -270,12 +518,16 @@ func (c *Client) GetFungibleTokenMetadata(ctx context.Context, base58MintAddr st
 // This is a temporary solution to support the deprecated metadata format.
 // Returns the token metadata or an error.
 // Works only with mainnet.
-func (c *Client) getDeprecatedTokenMetadata(_ context.Context, base58MintAddr string) (*metadata.Metadata, error) {
+func (c *Client) getDeprecatedTokenMetadata(ctx context.Context, base58MintAddr string) (*metadata.Metadata, error) {
 	if c.tokenListPath == "" || base58MintAddr == "" {
 		return nil, fmt.Errorf("failed to get token metadata: token list path or mint address is empty")
 	}
 
-	resp, err := http.Get(c.tokenListPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.tokenListPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download token list from uri: %w", err)
+	}
+	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download token list from uri: %w", err)
 	}
@@ -316,3 +568,43 @@ func (c *Client) getDeprecatedTokenMetadata(_ context.Context, base58MintAddr st
 
 	return &result, nil
 }
+
+// GetTokenLargestAccounts returns up to 20 largest token accounts of the given mint,
+// ordered by balance in descending order.
+// base58MintAddr is the base58 encoded address of the token mint.
+// Note: the owner of the token accounts is not populated, since the RPC method does not return it.
+func (c *Client) GetTokenLargestAccounts(ctx context.Context, base58MintAddr string) ([]types.TokenAccount, error) {
+	if err := commonx.ValidateSolanaWalletAddr(base58MintAddr); err != nil {
+		return nil, utils.StackErrors(ErrGetTokenLargestAccounts, err)
+	}
+
+	result, err := rpcCall[rpc.ValueWithContext[[]struct {
+		Address  string `json:"address"`
+		Amount   string `json:"amount"`
+		Decimals uint8  `json:"decimals"`
+	}]](ctx, c, "getTokenLargestAccounts", base58MintAddr)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenLargestAccounts, err)
+	}
+
+	mint := common.PublicKeyFromString(base58MintAddr)
+	accounts := make([]types.TokenAccount, 0, len(result.Value))
+	for _, v := range result.Value {
+		amount, err := strconv.ParseUint(v.Amount, 10, 64)
+		if err != nil {
+			return nil, utils.StackErrors(ErrGetTokenLargestAccounts, err)
+		}
+
+		accounts = append(accounts, types.TokenAccount{
+			Pubkey:  common.PublicKeyFromString(v.Address),
+			Mint:    mint,
+			Balance: types.NewTokenAmountFromLamports(amount, v.Decimals),
+		})
+	}
+
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[i].Balance.Amount > accounts[j].Balance.Amount
+	})
+
+	return accounts, nil
+}
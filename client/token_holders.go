@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	sdkcommon "github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// Token account layout:
+//
+//	offset 0:  mint, 32 bytes
+//	offset 32: owner, 32 bytes
+//	offset 64: amount, u64
+const (
+	tokenAccountMintOffset  = 0
+	tokenAccountOwnerOffset = 32
+)
+
+// GetTokenHoldersCount returns the number of wallets holding a non-zero balance of the given base58 encoded mint.
+// A wallet holding the token in several token accounts is counted once.
+// Only the owner and the amount of the token accounts are fetched, but the RPC node still scans
+// all the accounts of the token program, so the request is heavy and may be rejected or time out
+// on large mints or public RPC nodes; use the result as a best-effort estimate.
+func (c *Client) GetTokenHoldersCount(ctx context.Context, mint string) (int, error) {
+	if err := common.ValidateSolanaWalletAddr(mint); err != nil {
+		return 0, utils.StackErrors(ErrGetTokenHoldersCount, err)
+	}
+
+	programID, err := c.GetTokenProgramForMint(ctx, mint)
+	if err != nil {
+		return 0, utils.StackErrors(ErrGetTokenHoldersCount, err)
+	}
+
+	filters := []ProgramAccountFilter{
+		MemcmpFilter(tokenAccountMintOffset, sdkcommon.PublicKeyFromString(mint).Bytes()),
+	}
+	// Token-2022 accounts with extensions are larger than the classic ones
+	if programID == sdkcommon.TokenProgramID {
+		filters = append(filters, DataSizeFilter(token.TokenAccountSize))
+	}
+
+	accounts, err := c.GetProgramAccounts(ctx, programID.ToBase58(), filters, rpc.DataSlice{
+		Offset: tokenAccountOwnerOffset,
+		Length: sdkcommon.PublicKeyLength + 8,
+	})
+	if err != nil {
+		return 0, utils.StackErrors(ErrGetTokenHoldersCount, err)
+	}
+
+	holders := make(map[sdkcommon.PublicKey]struct{}, len(accounts))
+	for _, acc := range accounts {
+		data := acc.Account.Data
+		if len(data) != sdkcommon.PublicKeyLength+8 {
+			continue
+		}
+		if binary.LittleEndian.Uint64(data[sdkcommon.PublicKeyLength:]) == 0 {
+			continue
+		}
+		holders[sdkcommon.PublicKeyFromBytes(data[:sdkcommon.PublicKeyLength])] = struct{}{}
+	}
+
+	return len(holders), nil
+}
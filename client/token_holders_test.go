@@ -0,0 +1,97 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTokenHoldersCount(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner1 := types.NewAccount().PublicKey
+	owner2 := types.NewAccount().PublicKey
+
+	tokenAccount := func(mint, owner common.PublicKey, amount uint64) []byte {
+		data := make([]byte, token.TokenAccountSize)
+		copy(data[:32], mint.Bytes())
+		copy(data[32:64], owner.Bytes())
+		binary.LittleEndian.PutUint64(data[64:72], amount)
+		return data
+	}
+	accounts := [][]byte{
+		tokenAccount(mint, owner1, 100),
+		tokenAccount(mint, owner2, 1),
+		tokenAccount(mint, owner2, 5), // the second account of the same holder
+		tokenAccount(mint, types.NewAccount().PublicKey, 0),
+		tokenAccount(mint, types.NewAccount().PublicKey, 0),
+		tokenAccount(types.NewAccount().PublicKey, types.NewAccount().PublicKey, 10),
+	}
+
+	for name, programID := range map[string]common.PublicKey{
+		"spl token":  common.TokenProgramID,
+		"token-2022": commonx.Token2022ProgramID,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mintAccount := accountData(make([]byte, token.MintAccountSize))
+			mintAccount["owner"] = programID.ToBase58()
+
+			sc := newMockClient(t, map[string]interface{}{
+				"getAccountInfo": withContext(mintAccount),
+				"getProgramAccounts": func(params []json.RawMessage) interface{} {
+					var gotProgramID string
+					require.NoError(t, json.Unmarshal(params[0], &gotProgramID))
+					require.Equal(t, programID.ToBase58(), gotProgramID)
+
+					var cfg rpc.GetProgramAccountsConfig
+					require.NoError(t, json.Unmarshal(params[1], &cfg))
+					require.Equal(t, &rpc.DataSlice{Offset: 32, Length: 40}, cfg.DataSlice)
+
+					result := []map[string]interface{}{}
+				accountsLoop:
+					for _, data := range accounts {
+						for _, f := range cfg.Filters {
+							if f.DataSize != 0 && uint64(len(data)) != f.DataSize {
+								continue accountsLoop
+							}
+							if f.MemCmp == nil {
+								continue
+							}
+							b, err := base58.Decode(f.MemCmp.Bytes)
+							require.NoError(t, err)
+							if !bytes.HasPrefix(data[f.MemCmp.Offset:], b) {
+								continue accountsLoop
+							}
+						}
+						result = append(result, map[string]interface{}{
+							"pubkey":  types.NewAccount().PublicKey.ToBase58(),
+							"account": accountData(data[cfg.DataSlice.Offset : cfg.DataSlice.Offset+cfg.DataSlice.Length]),
+						})
+					}
+					return result
+				},
+			})
+
+			count, err := sc.GetTokenHoldersCount(context.Background(), mint.ToBase58())
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+		})
+	}
+
+	t.Run("invalid mint", func(t *testing.T) {
+		sc := newMockClient(t, nil)
+		_, err := sc.GetTokenHoldersCount(context.Background(), "invalid")
+		require.ErrorIs(t, err, client.ErrGetTokenHoldersCount)
+	})
+}
@@ -0,0 +1,430 @@
+package client_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTokenLargestAccounts(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	acc1 := types.NewAccount().PublicKey
+	acc2 := types.NewAccount().PublicKey
+	acc3 := types.NewAccount().PublicKey
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getTokenLargestAccounts": map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value": []map[string]interface{}{
+				{"address": acc1.ToBase58(), "amount": "500", "decimals": 2, "uiAmount": 5, "uiAmountString": "5"},
+				{"address": acc2.ToBase58(), "amount": "1500", "decimals": 2, "uiAmount": 15, "uiAmountString": "15"},
+				{"address": acc3.ToBase58(), "amount": "100", "decimals": 2, "uiAmount": 1, "uiAmountString": "1"},
+			},
+		},
+	})
+
+	accounts, err := sc.GetTokenLargestAccounts(context.Background(), mint.ToBase58())
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+
+	assert.Equal(t, acc2, accounts[0].Pubkey)
+	assert.Equal(t, acc1, accounts[1].Pubkey)
+	assert.Equal(t, acc3, accounts[2].Pubkey)
+	for i, acc := range accounts {
+		assert.Equal(t, mint, acc.Mint)
+		assert.Equal(t, uint8(2), acc.Balance.Decimals)
+		if i > 0 {
+			assert.GreaterOrEqual(t, accounts[i-1].Balance.Amount, acc.Balance.Amount)
+		}
+	}
+	assert.Equal(t, "15", accounts[0].Balance.UIAmountString)
+}
+
+func TestGetTokenLargestAccounts_Error(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{})
+
+	_, err := sc.GetTokenLargestAccounts(context.Background(), types.NewAccount().PublicKey.ToBase58())
+	require.ErrorIs(t, err, client.ErrGetTokenLargestAccounts)
+
+	_, err = sc.GetTokenLargestAccounts(context.Background(), "invalid")
+	require.ErrorIs(t, err, client.ErrGetTokenLargestAccounts)
+}
+
+func masterEditionResult(t *testing.T, supply uint64, maxSupply *uint64) map[string]interface{} {
+	data, err := borsh.Serialize(metaplex_token_metadata.MasterEditionV2{
+		Key:       metaplex_token_metadata.KeyMasterEditionV2,
+		Supply:    supply,
+		MaxSupply: maxSupply,
+	})
+	require.NoError(t, err)
+	return withContext(accountData(data))
+}
+
+func TestGetMasterEditionState(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+
+	tests := []struct {
+		name      string
+		supply    uint64
+		maxSupply *uint64
+		canPrint  bool
+	}{
+		{name: "can print", supply: 3, maxSupply: utils.Pointer(uint64(10)), canPrint: true},
+		{name: "at capacity", supply: 10, maxSupply: utils.Pointer(uint64(10)), canPrint: false},
+		{name: "no prints allowed", supply: 0, maxSupply: utils.Pointer(uint64(0)), canPrint: false},
+		{name: "unlimited", supply: 42, maxSupply: nil, canPrint: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := newMockClient(t, map[string]interface{}{
+				"getAccountInfo": masterEditionResult(t, tt.supply, tt.maxSupply),
+			})
+
+			state, err := sc.GetMasterEditionState(context.Background(), mint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.supply, state.Supply)
+			assert.Equal(t, tt.maxSupply, state.MaxSupply)
+			assert.Equal(t, tt.canPrint, state.CanPrint)
+		})
+	}
+}
+
+func TestGetMasterEditionState_NotMasterEdition(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": withContext(nil),
+	})
+	_, err := sc.GetMasterEditionState(context.Background(), mint)
+	require.ErrorIs(t, err, client.ErrGetMasterEditionState)
+	require.ErrorIs(t, err, client.ErrTokenIsNotMasterEdition)
+
+	sc = newMockClient(t, map[string]interface{}{
+		"getAccountInfo": withContext(accountData(serializedMetadata(t, mint, "NFT", metaplex_token_metadata.NonFungible))),
+	})
+	_, err = sc.GetMasterEditionState(context.Background(), mint)
+	require.ErrorIs(t, err, client.ErrTokenIsNotMasterEdition)
+}
+
+func TestGetTokenMetadata_TokenStandard(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+
+	// the token metadata program infers the standard on-chain, it's read back from the metadata account
+	tests := []struct {
+		standard metaplex_token_metadata.TokenStandard
+		want     token_metadata.TokenStandard
+	}{
+		{metaplex_token_metadata.Fungible, token_metadata.TokenStandardFungible},
+		{metaplex_token_metadata.FungibleAsset, token_metadata.TokenStandardFungibleAsset},
+		{metaplex_token_metadata.NonFungible, token_metadata.TokenStandardNonFungible},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			sc := newMockClient(t, map[string]interface{}{
+				"getAccountInfo": withContext(accountData(serializedMetadata(t, mint, "Token", tt.standard))),
+			})
+
+			md, err := sc.GetTokenMetadata(context.Background(), mint.ToBase58())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.String(), md.TokenStandard)
+		})
+	}
+}
+
+func TestGetMasterEditionSupply(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": masterEditionResult(t, 3, utils.Pointer(uint64(10))),
+	})
+	current, max, err := sc.GetMasterEditionSupply(context.Background(), mint)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, current)
+	assert.EqualValues(t, 10, max)
+
+	// a fully printed master edition is not an error
+	sc = newMockClient(t, map[string]interface{}{
+		"getAccountInfo": masterEditionResult(t, 10, utils.Pointer(uint64(10))),
+	})
+	current, max, err = sc.GetMasterEditionSupply(context.Background(), mint)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, current)
+	assert.EqualValues(t, 10, max)
+
+	// the master edition without max supply
+	sc = newMockClient(t, map[string]interface{}{
+		"getAccountInfo": masterEditionResult(t, 3, nil),
+	})
+	current, max, err = sc.GetMasterEditionSupply(context.Background(), mint)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, current)
+	assert.EqualValues(t, uint64(math.MaxUint64), max)
+}
+
+// tokenAccountData returns the token program account holding the given amount of tokens.
+func tokenAccountData(mint, owner common.PublicKey, amount uint64) map[string]interface{} {
+	data := make([]byte, token.TokenAccountSize)
+	copy(data[:32], mint.Bytes())
+	copy(data[32:64], owner.Bytes())
+	binary.LittleEndian.PutUint64(data[64:72], amount)
+	data[108] = byte(token.TokenAccountStateInitialized)
+
+	acc := accountData(data)
+	acc["owner"] = common.TokenProgramID.ToBase58()
+	return acc
+}
+
+func TestGetTokenAccountInfo(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	ata := types.NewAccount().PublicKey
+
+	t.Run("found", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(tokenAccountData(mint, owner, 42)),
+		})
+
+		info, err := sc.GetTokenAccountInfo(context.Background(), ata.ToBase58())
+		require.NoError(t, err)
+		assert.Equal(t, mint, info.Mint)
+		assert.Equal(t, owner, info.Owner)
+		assert.EqualValues(t, 42, info.Amount)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(nil),
+		})
+
+		_, err := sc.GetTokenAccountInfo(context.Background(), ata.ToBase58())
+		require.ErrorIs(t, err, client.ErrGetTokenAccount)
+		require.ErrorIs(t, err, client.ErrTokenAccountNotFound)
+	})
+
+	t.Run("rpc error", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": &rpc.JsonRpcError{Code: -32005, Message: "Node is behind"},
+		})
+
+		_, err := sc.GetTokenAccountInfo(context.Background(), ata.ToBase58())
+		require.ErrorIs(t, err, client.ErrGetTokenAccount)
+		assert.NotErrorIs(t, err, client.ErrTokenAccountNotFound)
+	})
+
+	t.Run("not a token account", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(accountData(make([]byte, token.TokenAccountSize))),
+		})
+
+		_, err := sc.GetTokenAccountInfo(context.Background(), ata.ToBase58())
+		require.ErrorIs(t, err, client.ErrGetTokenAccount)
+		assert.NotErrorIs(t, err, client.ErrTokenAccountNotFound)
+	})
+}
+
+// mintAccountData returns the token program mint account with the given supply and authorities.
+func mintAccountData(supply uint64, decimals uint8, mintAuthority, freezeAuthority *common.PublicKey) map[string]interface{} {
+	data := make([]byte, token.MintAccountSize)
+	if mintAuthority != nil {
+		data[0] = 1
+		copy(data[4:36], mintAuthority.Bytes())
+	}
+	binary.LittleEndian.PutUint64(data[36:44], supply)
+	data[44] = decimals
+	data[45] = 1
+	if freezeAuthority != nil {
+		data[46] = 1
+		copy(data[50:82], freezeAuthority.Bytes())
+	}
+
+	acc := accountData(data)
+	acc["owner"] = common.TokenProgramID.ToBase58()
+	return acc
+}
+
+func TestGetMintFullInfo(t *testing.T) {
+	mint := types.NewAccount().PublicKey.ToBase58()
+	authority := types.NewAccount().PublicKey
+	freezeAuthority := types.NewAccount().PublicKey
+
+	t.Run("fixed supply", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(mintAccountData(1_000_000, 6, nil, nil)),
+		})
+
+		info, err := sc.GetMintFullInfo(context.Background(), mint)
+		require.NoError(t, err)
+		assert.True(t, info.IsFixedSupply())
+		assert.Nil(t, info.MintAuthority)
+		assert.Nil(t, info.FreezeAuthority)
+		assert.EqualValues(t, 6, info.Decimals)
+		assert.EqualValues(t, 1_000_000, info.Supply.Amount)
+		assert.Equal(t, "1", info.Supply.UIAmountString)
+	})
+
+	t.Run("mintable", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(mintAccountData(5, 0, &authority, &freezeAuthority)),
+		})
+
+		info, err := sc.GetMintFullInfo(context.Background(), mint)
+		require.NoError(t, err)
+		assert.False(t, info.IsFixedSupply())
+		require.NotNil(t, info.MintAuthority)
+		assert.Equal(t, authority, *info.MintAuthority)
+		require.NotNil(t, info.FreezeAuthority)
+		assert.Equal(t, freezeAuthority, *info.FreezeAuthority)
+		assert.EqualValues(t, 5, info.Supply.Amount)
+	})
+
+	t.Run("rpc error", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": &rpc.JsonRpcError{Code: -32005, Message: "Node is behind"},
+		})
+
+		_, err := sc.GetMintFullInfo(context.Background(), mint)
+		require.ErrorIs(t, err, client.ErrGetMintInfo)
+	})
+}
+
+func TestGetTokenProgramForMint(t *testing.T) {
+	mint := types.NewAccount().PublicKey.ToBase58()
+
+	for _, programID := range []common.PublicKey{common.TokenProgramID, commonx.Token2022ProgramID} {
+		acc := mintAccountData(1, 0, nil, nil)
+		acc["owner"] = programID.ToBase58()
+		var requests int32
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": func() interface{} {
+				atomic.AddInt32(&requests, 1)
+				return withContext(acc)
+			},
+		})
+
+		// the second lookup hits the cache
+		for i := 0; i < 2; i++ {
+			tokenProgram, err := sc.GetTokenProgramForMint(context.Background(), mint)
+			require.NoError(t, err)
+			assert.Equal(t, programID, tokenProgram)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	}
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": withContext(accountData(make([]byte, 82))),
+	})
+	_, err := sc.GetTokenProgramForMint(context.Background(), mint)
+	require.ErrorIs(t, err, client.ErrUnsupportedTokenProgram)
+}
+
+func TestGetTokenRecord(t *testing.T) {
+	const (
+		mint   = "7Ucm1SKXbwfPZfxGbvXBqkpAiUq4ttqkJmbGpQkfLeYV"
+		owner  = "9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ"
+		record = "CmWEs3dpXQ4JGX8cq3B3MZ4QgPM9uEWpwA3qXYZVcJLP"
+	)
+	fixture, err := os.ReadFile("testdata/token_record.json")
+	require.NoError(t, err)
+
+	t.Run("locked with utility delegate", func(t *testing.T) {
+		var addr string
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": func(params []json.RawMessage) interface{} {
+				require.NoError(t, json.Unmarshal(params[0], &addr))
+				return json.RawMessage(fixture)
+			},
+		})
+
+		rec, err := sc.GetTokenRecord(context.Background(), mint, owner)
+		require.NoError(t, err)
+		assert.Equal(t, record, addr)
+		assert.Equal(t, token_metadata.TokenStateLocked, rec.State)
+		assert.True(t, rec.IsLocked())
+		assert.Equal(t, "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T", rec.Delegate)
+		assert.Equal(t, token_metadata.TokenDelegateRoleUtility, rec.DelegateRole)
+		assert.Nil(t, rec.RuleSetRevision)
+		assert.Empty(t, rec.LockedTransfer)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(nil),
+		})
+
+		_, err := sc.GetTokenRecord(context.Background(), mint, owner)
+		require.ErrorIs(t, err, client.ErrGetTokenRecord)
+		require.ErrorIs(t, err, client.ErrTokenRecordNotFound)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{})
+
+		_, err := sc.GetTokenRecord(context.Background(), "invalid", owner)
+		require.ErrorIs(t, err, client.ErrGetTokenRecord)
+	})
+}
+
+func TestIsCollectionAuthorityApproved(t *testing.T) {
+	collection := types.NewAccount().PublicKey
+	approved := types.NewAccount().PublicKey
+	notApproved := types.NewAccount().PublicKey
+	record, err := token_metadata.DeriveCollectionAuthorityRecord(collection, approved)
+	require.NoError(t, err)
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getAccountInfo": func(params []json.RawMessage) interface{} {
+			var addr string
+			require.NoError(t, json.Unmarshal(params[0], &addr))
+			if addr != record.ToBase58() {
+				return withContext(nil)
+			}
+			// key, bump, no update authority
+			return withContext(accountData([]byte{byte(metaplex_token_metadata.KeyCollectionAuthorityRecord), 255, 0}))
+		},
+	})
+
+	ok, err := sc.IsCollectionAuthorityApproved(context.Background(), collection.ToBase58(), approved.ToBase58())
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = sc.IsCollectionAuthorityApproved(context.Background(), collection.ToBase58(), notApproved.ToBase58())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = sc.IsCollectionAuthorityApproved(context.Background(), "invalid", approved.ToBase58())
+	require.ErrorIs(t, err, client.ErrCheckCollectionAuthority)
+}
+
+func TestIsCollectionAuthorityApproved_OtherAccount(t *testing.T) {
+	sc := newMockClient(t, map[string]interface{}{
+		// an account of another type can't be at the record address, but the key is checked anyway
+		"getAccountInfo": withContext(accountData([]byte{byte(metaplex_token_metadata.KeyMetadataV1)})),
+	})
+
+	ok, err := sc.IsCollectionAuthorityApproved(context.Background(),
+		types.NewAccount().PublicKey.ToBase58(), types.NewAccount().PublicKey.ToBase58())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	sc = newMockClient(t, nil)
+	_, err = sc.IsCollectionAuthorityApproved(context.Background(),
+		types.NewAccount().PublicKey.ToBase58(), types.NewAccount().PublicKey.ToBase58())
+	require.ErrorIs(t, err, client.ErrCheckCollectionAuthority)
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -17,17 +19,28 @@ import (
 
 // NewTransactionParams is the params for NewTransaction function.
 type NewTransactionParams struct {
-	FeePayer     common.PublicKey       // transaction fee payer
-	Instructions []sdktypes.Instruction // transaction instructions
-	Signers      []sdktypes.Account     // transaction signers
+	FeePayer            common.PublicKey                     // transaction fee payer
+	Instructions        []sdktypes.Instruction               // transaction instructions
+	Signers             []sdktypes.Account                   // transaction signers
+	AddressLookupTables []sdktypes.AddressLookupTableAccount // optional; builds a v0 message using these lookup tables; legacy message if empty
 }
 
 // NewTransaction creates a new transaction.
 // Returns the transaction or an error.
 func (c *Client) NewTransaction(ctx context.Context, params NewTransactionParams) (string, error) {
+	tx, _, err := c.NewTransactionWithLastValidBlockHeight(ctx, params)
+	return tx, err
+}
+
+// NewTransactionWithLastValidBlockHeight creates a new transaction, like NewTransaction,
+// and returns the last block height at which the transaction blockhash is still valid.
+// Pass it to SendAndConfirmTransaction via ConfirmOptions.LastValidBlockHeight
+// to stop retrying once the transaction can no longer land.
+// Returns the transaction and the last valid block height or an error.
+func (c *Client) NewTransactionWithLastValidBlockHeight(ctx context.Context, params NewTransactionParams) (string, uint64, error) {
 	latestBlockhash, err := c.rpcClient.GetLatestBlockhash(ctx)
 	if err != nil {
-		return "", utils.StackErrors(
+		return "", 0, utils.StackErrors(
 			ErrNewTransaction,
 			ErrGetLatestBlockhash,
 			err,
@@ -36,35 +49,37 @@ func (c *Client) NewTransaction(ctx context.Context, params NewTransactionParams
 
 	tx, err := sdktypes.NewTransaction(sdktypes.NewTransactionParam{
 		Message: sdktypes.NewMessage(sdktypes.NewMessageParam{
-			FeePayer:        params.FeePayer,
-			RecentBlockhash: latestBlockhash.Blockhash,
-			Instructions:    params.Instructions,
+			FeePayer:                   params.FeePayer,
+			RecentBlockhash:            latestBlockhash.Blockhash,
+			Instructions:               params.Instructions,
+			AddressLookupTableAccounts: params.AddressLookupTables,
 		}),
 		Signers: params.Signers,
 	})
 	if err != nil {
-		return "", utils.StackErrors(ErrNewTransaction, err)
+		return "", 0, utils.StackErrors(ErrNewTransaction, err)
 	}
 
 	txb, err := utils.EncodeTransaction(tx)
 	if err != nil {
-		return "", utils.StackErrors(
+		return "", 0, utils.StackErrors(
 			ErrNewTransaction,
 			ErrSerializeTransaction,
 			err,
 		)
 	}
 
-	return txb, nil
+	return txb, latestBlockhash.LatestValidBlockHeight, nil
 }
 
 // NewDurableTransactionParams are the parameters for NewDurableTransaction function.
 type NewDurableTransactionParams struct {
-	FeePayer     *common.PublicKey      // optional; if not provided, the fee payer will be the durable nonce
-	NonceAuth    common.PublicKey       // required; the nonce authority
-	DurableNonce common.PublicKey       // required; the durable nonce
-	Instructions []sdktypes.Instruction // required; the transaction instructions
-	Signers      []sdktypes.Account     // transaction signers
+	FeePayer            *common.PublicKey                    // optional; if not provided, the fee payer will be the durable nonce
+	NonceAuth           common.PublicKey                     // required; the nonce authority
+	DurableNonce        common.PublicKey                     // required; the durable nonce
+	Instructions        []sdktypes.Instruction               // required; the transaction instructions
+	Signers             []sdktypes.Account                   // transaction signers
+	AddressLookupTables []sdktypes.AddressLookupTableAccount // optional; builds a v0 message using these lookup tables; legacy message if empty
 }
 
 // NewDurableTransaction creates a new durable transaction.
@@ -100,9 +115,10 @@ func (c *Client) NewDurableTransaction(ctx context.Context, params NewDurableTra
 
 	tx, err := sdktypes.NewTransaction(sdktypes.NewTransactionParam{
 		Message: sdktypes.NewMessage(sdktypes.NewMessageParam{
-			FeePayer:        *params.FeePayer,
-			RecentBlockhash: nonce,
-			Instructions:    instr,
+			FeePayer:                   *params.FeePayer,
+			RecentBlockhash:            nonce,
+			Instructions:               instr,
+			AddressLookupTableAccounts: params.AddressLookupTables,
 		}),
 		Signers: params.Signers,
 	})
@@ -167,6 +183,24 @@ func (c *Client) SignTransaction(ctx context.Context, wallet sdktypes.Account, t
 	return result, nil
 }
 
+// SendOptions defines the options of sending the transaction.
+// The zero value preserves the node defaults: the transaction is simulated before sending
+// with the finalized commitment and the node retries it until the blockhash expires.
+type SendOptions struct {
+	SkipPreflight       bool           // optional; skip the preflight simulation, e.g. if the caller already simulated the transaction
+	PreflightCommitment rpc.Commitment // optional; the commitment level of the preflight simulation, default: finalized
+	MaxRetries          *uint64        // optional; how many times the node retries sending the transaction, 0 disables the node retries, default: until the blockhash expires
+}
+
+// sendTransactionConfig is the sendTransaction rpc config.
+// Unlike rpc.SendTransactionConfig, it keeps an explicit zero maxRetries.
+type sendTransactionConfig struct {
+	SkipPreflight       bool                              `json:"skipPreflight,omitempty"`
+	PreflightCommitment rpc.Commitment                    `json:"preflightCommitment,omitempty"`
+	Encoding            rpc.SendTransactionConfigEncoding `json:"encoding"`
+	MaxRetries          *uint64                           `json:"maxRetries,omitempty"`
+}
+
 // Send transaction
 // returns the transaction hash or an error
 func (c *Client) SendTransaction(ctx context.Context, txSource string, i ...uint8) (string, error) {
@@ -175,20 +209,63 @@ func (c *Client) SendTransaction(ctx context.Context, txSource string, i ...uint
 		tryN = i[0]
 	}
 
+	return c.sendTransactionWithRetries(ctx, txSource, SendOptions{}, tryN)
+}
+
+// SendTransactionWithOptions sends the transaction with the given options, like SendTransaction.
+// E.g. skip the preflight for the latency-sensitive sends of already simulated transactions.
+// Returns the transaction hash or an error.
+func (c *Client) SendTransactionWithOptions(ctx context.Context, txSource string, opts SendOptions) (string, error) {
+	if opts.PreflightCommitment != "" {
+		if _, ok := commitmentLevels[opts.PreflightCommitment]; !ok {
+			return "", utils.StackErrors(
+				ErrSendTransaction,
+				fmt.Errorf("unsupported preflight commitment: %s", opts.PreflightCommitment),
+			)
+		}
+	}
+
+	return c.sendTransactionWithRetries(ctx, txSource, opts, 0)
+}
+
+// sendTransactionWithRetries sends the transaction, retrying up to 3 times if the blockhash is not found.
+// Returns the transaction hash or an error.
+func (c *Client) sendTransactionWithRetries(ctx context.Context, txSource string, opts SendOptions, tryN uint8) (string, error) {
+	txhash, err := c.sendTransaction(ctx, txSource, opts)
+	if err != nil {
+		// retry if blockhash not found
+		if errors.Is(err, ErrBlockhashNotFound) && tryN < 3 {
+			return c.sendTransactionWithRetries(ctx, txSource, opts, tryN+1)
+		}
+
+		return "", err
+	}
+
+	return txhash, nil
+}
+
+// sendTransaction sends the transaction once.
+// Returns the transaction hash or an error, matching the known failure reason if any.
+func (c *Client) sendTransaction(ctx context.Context, txSource string, opts SendOptions) (string, error) {
 	tx, err := utils.DecodeTransaction(txSource)
 	if err != nil {
 		return "", utils.StackErrors(ErrSendTransaction, ErrDeserializeTransaction, err)
 	}
 
-	txhash, err := c.rpcClient.SendTransaction(ctx, tx)
+	rawTx, err := tx.Serialize()
 	if err != nil {
-		if strings.Contains(err.Error(), "without insufficient funds for rent") {
-			return "", utils.StackErrors(ErrSendTransaction, ErrWithoutInsufficientFound, err)
-		}
+		return "", utils.StackErrors(ErrSendTransaction, err)
+	}
 
-		// retry if blockhash not found
-		if strings.Contains(err.Error(), "BlockhashNotFound") && tryN < 3 {
-			return c.SendTransaction(ctx, txSource, tryN+1)
+	txhash, err := rpcCall[string](ctx, c, "sendTransaction", base64.StdEncoding.EncodeToString(rawTx), sendTransactionConfig{
+		SkipPreflight:       opts.SkipPreflight,
+		PreflightCommitment: opts.PreflightCommitment,
+		Encoding:            rpc.SendTransactionConfigEncodingBase64,
+		MaxRetries:          opts.MaxRetries,
+	})
+	if err != nil {
+		if reason := sendTransactionErrorReason(err); reason != nil {
+			return "", utils.StackErrors(ErrSendTransaction, reason, err)
 		}
 
 		return "", utils.StackErrors(ErrSendTransaction, err)
@@ -197,9 +274,117 @@ func (c *Client) SendTransaction(ctx context.Context, txSource string, i ...uint
 	return txhash, nil
 }
 
+// SendAndConfirmTransaction sends the transaction and waits for its confirmation with the given options,
+// see WaitForTransactionConfirmedWithOptions. The client has no websocket subscriptions,
+// so the transaction status is polled every opts.PollInterval.
+// The failed transaction results in TransactionStatusFailure and *TransactionError.
+// If opts.LastValidBlockHeight is set, the transaction is resent on BlockhashNotFound every poll interval
+// until the block height exceeds it, and the confirmation stops with ErrBlockhashExpired
+// once the transaction can no longer land. Otherwise, it's resent up to 3 times, like SendTransaction.
+// Returns the transaction hash and the final status or an error.
+func (c *Client) SendAndConfirmTransaction(ctx context.Context, txSource string, opts ConfirmOptions) (string, types.TransactionStatus, error) {
+	var (
+		txhash string
+		err    error
+	)
+	if opts.LastValidBlockHeight == 0 {
+		txhash, err = c.SendTransaction(ctx, txSource)
+	} else {
+		txhash, err = c.sendTransactionBeforeExpiry(ctx, txSource, opts)
+	}
+	if err != nil {
+		return "", types.TransactionStatusUnknown, utils.StackErrors(ErrSendAndConfirmTransaction, err)
+	}
+
+	status, err := c.WaitForTransactionConfirmedWithOptions(ctx, txhash, opts)
+	if err != nil {
+		return txhash, status, utils.StackErrors(ErrSendAndConfirmTransaction, err)
+	}
+
+	return txhash, status, nil
+}
+
+// sendTransactionBeforeExpiry sends the transaction, resending it on BlockhashNotFound every poll interval
+// until the block height exceeds opts.LastValidBlockHeight.
+// Returns the transaction hash or an error.
+func (c *Client) sendTransactionBeforeExpiry(ctx context.Context, txSource string, opts ConfirmOptions) (string, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+
+	for {
+		txhash, err := c.sendTransaction(ctx, txSource, SendOptions{})
+		if err == nil || !errors.Is(err, ErrBlockhashNotFound) {
+			return txhash, err
+		}
+
+		if expired, herr := c.blockhashExpired(ctx, opts.LastValidBlockHeight); herr != nil {
+			return "", utils.StackErrors(ErrSendTransaction, herr)
+		} else if expired {
+			return "", utils.StackErrors(ErrSendTransaction, ErrBlockhashExpired, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", utils.StackErrors(ErrSendTransaction, ErrContextDone, ctx.Err())
+		case <-c.clock.After(opts.PollInterval):
+		}
+	}
+}
+
+// blockhashExpired returns true if the current block height exceeds the last valid block height of the blockhash.
+func (c *Client) blockhashExpired(ctx context.Context, lastValidBlockHeight uint64) (bool, error) {
+	height, err := c.GetBlockHeight(ctx)
+	if err != nil {
+		return false, err
+	}
+	return height > lastValidBlockHeight, nil
+}
+
+// sendTransactionErrors maps the messages of the send transaction errors returned by the node
+// to the package errors. The order matters: the first matching message wins.
+var sendTransactionErrors = []struct {
+	message string
+	err     error
+}{
+	{"insufficient funds for rent", ErrWithoutInsufficientFound},
+	{"insufficient funds for fee", ErrInsufficientFunds},
+	{"insufficient lamports", ErrInsufficientFunds},
+	{"found no record of a prior credit", ErrInsufficientFunds}, // the fee payer account does not exist
+	{"blockhash not found", ErrBlockhashNotFound},
+	{"blockhashnotfound", ErrBlockhashNotFound},
+}
+
+// sendTransactionErrorReason returns the package error matching the send transaction error,
+// so callers can branch on it with errors.Is. Returns nil if the error is unknown.
+func sendTransactionErrorReason(err error) error {
+	msg := strings.ToLower(err.Error())
+	for _, e := range sendTransactionErrors {
+		if strings.Contains(msg, e.message) {
+			return e.err
+		}
+	}
+	return nil
+}
+
 // GetTransactionStatus gets the transaction status.
+// The transaction is successful once it reaches the commitment level: the optional override,
+// the client default commitment or finalized if none of them is set.
+// The failed transaction results in TransactionStatusFailure together with the *TransactionError,
+// so check the status before treating a non-nil error as an RPC failure.
 // Returns the transaction status or an error.
-func (c *Client) GetTransactionStatus(ctx context.Context, txhash string) (types.TransactionStatus, error) {
+func (c *Client) GetTransactionStatus(ctx context.Context, txhash string, commitment ...rpc.Commitment) (types.TransactionStatus, error) {
+	target := c.getCommitment(commitment)
+	if target == "" {
+		target = rpc.CommitmentFinalized
+	}
+	if _, ok := commitmentLevels[target]; !ok {
+		return types.TransactionStatusUnknown, utils.StackErrors(
+			ErrGetTransactionStatus,
+			fmt.Errorf("unsupported commitment: %s", target),
+		)
+	}
+
 	status, err := c.rpcClient.GetSignatureStatus(ctx, txhash)
 	if err != nil {
 		return types.TransactionStatusUnknown, utils.StackErrors(ErrGetTransactionStatus, err)
@@ -208,7 +393,7 @@ func (c *Client) GetTransactionStatus(ctx context.Context, txhash string) (types
 		return types.TransactionStatusUnknown, nil
 	}
 	if status.Err != nil {
-		return types.TransactionStatusFailure, fmt.Errorf("transaction failed: %v", status.Err)
+		return types.TransactionStatusFailure, NewTransactionError(status.Err)
 	}
 
 	result := types.TransactionStatusUnknown
@@ -217,93 +402,184 @@ func (c *Client) GetTransactionStatus(ctx context.Context, txhash string) (types
 	}
 	if status.ConfirmationStatus != nil {
 		result = types.ParseTransactionStatus(*status.ConfirmationStatus)
+		if commitmentReached(*status.ConfirmationStatus, target) {
+			result = types.TransactionStatusSuccess
+		}
 	}
 
 	return result, nil
 }
 
 // GetMinimumBalanceForRentExemption gets the minimum balance for rent exemption.
+// The result is cached per account size unless the cache is disabled with DisableRentExemptionCache.
 // Returns the minimum balance in lamports or an error.
 func (c *Client) GetMinimumBalanceForRentExemption(ctx context.Context, size uint64) (uint64, error) {
+	if c.rentExemptionCache != nil {
+		if rent, ok := c.rentExemptionCache.Load(size); ok {
+			return rent.(uint64), nil
+		}
+	}
+
 	mintAccountRent, err := c.rpcClient.GetMinimumBalanceForRentExemption(ctx, size)
 	if err != nil {
 		return 0, utils.StackErrors(ErrGetMinimumBalanceForRentExemption, err)
 	}
 
+	if c.rentExemptionCache != nil {
+		c.rentExemptionCache.Store(size, mintAccountRent)
+	}
+
 	return mintAccountRent, nil
 }
 
+// ConfirmOptions defines the options of waiting for the transaction confirmation.
+// The zero value waits for the finalized transaction, polling its status every 5 seconds for up to 5 minutes.
+type ConfirmOptions struct {
+	PollInterval time.Duration  // optional; how often to poll the transaction status, default: 5 seconds
+	MaxDuration  time.Duration  // optional; how long to wait for the confirmation, default: 5 minutes
+	Commitment   rpc.Commitment // optional; the commitment level to wait for, default: finalized
+
+	LastValidBlockHeight uint64 // optional; stop waiting with ErrBlockhashExpired once the block height exceeds it, see NewTransactionWithLastValidBlockHeight
+	ExpectedOwner        string // optional; base58 encoded program the account must be owned by, see WaitForAccount
+}
+
 // WaitForTransactionConfirmed waits for a transaction to be confirmed.
+// Like WaitForTransactionConfirmedWithOptions, it returns TransactionStatusFailure
+// together with a non-nil error for the failed transaction.
 // Returns the transaction status or an error.
 func (c *Client) WaitForTransactionConfirmed(ctx context.Context, txhash string, maxDuration time.Duration) (types.TransactionStatus, error) {
-	tick := time.NewTicker(5 * time.Second)
-	defer tick.Stop()
+	return c.WaitForTransactionConfirmedWithOptions(ctx, txhash, ConfirmOptions{MaxDuration: maxDuration})
+}
 
-	if maxDuration == 0 {
-		maxDuration = 5 * time.Minute
+// WaitForTransactionConfirmedWithOptions waits for a transaction to reach the given commitment level.
+// E.g. the confirmed commitment is enough for the fast devnet loops,
+// while the finalized one is the conservative choice for mainnet.
+// Breaking change: the failed transaction results in TransactionStatusFailure together with
+// ErrWaitForTransaction wrapping the *TransactionError, while earlier versions returned no error for it.
+// So check the status before treating a non-nil error as an RPC or wait failure,
+// or use errors.As to get the *TransactionError.
+// Returns the transaction status or an error.
+func (c *Client) WaitForTransactionConfirmedWithOptions(ctx context.Context, txhash string, opts ConfirmOptions) (types.TransactionStatus, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
 	}
-	ctx, cancel := context.WithTimeout(ctx, maxDuration)
-	defer cancel()
+	if opts.MaxDuration <= 0 {
+		opts.MaxDuration = 5 * time.Minute
+	}
+	if opts.Commitment == "" {
+		opts.Commitment = rpc.CommitmentFinalized
+	}
+	if _, ok := commitmentLevels[opts.Commitment]; !ok {
+		return types.TransactionStatusUnknown, utils.StackErrors(
+			ErrWaitForTransaction,
+			fmt.Errorf("unsupported commitment: %s", opts.Commitment),
+		)
+	}
+
+	tick, stop := c.clock.NewTicker(opts.PollInterval)
+	defer stop()
+	timeout := c.clock.After(opts.MaxDuration)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return types.TransactionStatusUnknown, utils.StackErrors(ErrWaitForTransaction, ErrContextDone)
-		case <-tick.C:
-			status, err := c.GetTransactionStatus(ctx, txhash)
+		case <-timeout:
+			return types.TransactionStatusUnknown, utils.StackErrors(ErrWaitForTransaction, ErrContextDone)
+		case <-tick:
+			status, err := c.rpcClient.GetSignatureStatus(ctx, txhash)
 			if err != nil {
-				return types.TransactionStatusUnknown, utils.StackErrors(ErrWaitForTransaction, err)
+				return types.TransactionStatusUnknown, utils.StackErrors(ErrWaitForTransaction, ErrGetTransactionStatus, err)
 			}
-			if status == types.TransactionStatusInProgress || status == types.TransactionStatusUnknown {
+			if status == nil {
+				if opts.LastValidBlockHeight > 0 {
+					expired, err := c.blockhashExpired(ctx, opts.LastValidBlockHeight)
+					if err != nil {
+						return types.TransactionStatusUnknown, utils.StackErrors(ErrWaitForTransaction, err)
+					}
+					if expired {
+						return types.TransactionStatusUnknown, utils.StackErrors(ErrWaitForTransaction, ErrBlockhashExpired)
+					}
+				}
 				continue
 			}
-			if status == types.TransactionStatusFailure || status == types.TransactionStatusSuccess {
-				return status, nil
+			if status.Err != nil {
+				return types.TransactionStatusFailure, utils.StackErrors(ErrWaitForTransaction, NewTransactionError(status.Err))
+			}
+			if status.ConfirmationStatus != nil && commitmentReached(*status.ConfirmationStatus, opts.Commitment) {
+				return types.TransactionStatusSuccess, nil
 			}
 		}
 	}
 }
 
-// GetOldestTransactionForWallet returns the oldest transaction by the given base58 encoded public key.
-// Returns the transaction or an error.
+// commitmentLevels orders the commitment levels from the weakest to the strongest.
+var commitmentLevels = map[rpc.Commitment]int{
+	rpc.CommitmentProcessed: 1,
+	rpc.CommitmentConfirmed: 2,
+	rpc.CommitmentFinalized: 3,
+}
+
+// commitmentReached returns true if the transaction confirmation status satisfies the target commitment.
+func commitmentReached(status, target rpc.Commitment) bool {
+	level, ok := commitmentLevels[status]
+	return ok && level >= commitmentLevels[target]
+}
+
+// maxOldestTransactionPages is the maximum number of signature pages GetOldestTransactionForWallet
+// walks through, so the very active wallets do not result in the endless pagination.
+const maxOldestTransactionPages = 100
+
+// GetOldestTransactionForWallet returns the oldest transaction by the given base58 encoded public key,
+// walking the signatures before offsetTxSignature page by page, up to maxOldestTransactionPages.
+// The empty history is not an error: the empty signature and nil transaction are returned.
+// Returns the transaction signature and the transaction or an error.
 func (c *Client) GetOldestTransactionForWallet(
 	ctx context.Context,
 	base58Addr string,
 	offsetTxSignature string,
 ) (string, *client.Transaction, error) {
 	limit := 1000
-	result, err := c.rpcClient.GetSignaturesForAddressWithConfig(ctx, base58Addr, client.GetSignaturesForAddressConfig{
-		Limit:      limit,
-		Before:     offsetTxSignature,
-		Commitment: rpc.CommitmentFinalized,
-	})
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to get signatures for address: %s: %w", base58Addr, err)
-	}
+	var oldest *rpc.SignatureWithStatus
+	for page := 0; ; page++ {
+		if page == maxOldestTransactionPages {
+			return "", nil, fmt.Errorf("failed to get oldest transaction for wallet: %s: %w", base58Addr, ErrTooManyTransactions)
+		}
 
-	if l := len(result); l == 0 {
-		return "", nil, ErrNoTransactionsFound
-	} else if l < limit {
-		tx := result[l-1]
-		if tx.Err != nil {
-			return "", nil, fmt.Errorf("transaction failed: %v", tx.Err)
+		result, err := c.rpcClient.GetSignaturesForAddressWithConfig(ctx, base58Addr, client.GetSignaturesForAddressConfig{
+			Limit:      limit,
+			Before:     offsetTxSignature,
+			Commitment: rpc.CommitmentFinalized,
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get signatures for address: %s: %w", base58Addr, err)
 		}
-		if tx.Signature == "" {
-			return "", nil, ErrNoTransactionsFound
+
+		if l := len(result); l > 0 {
+			oldest = &result[l-1]
+			offsetTxSignature = oldest.Signature
 		}
-		if tx.BlockTime == nil || *tx.BlockTime == 0 || *tx.BlockTime > time.Now().Unix() {
-			return "", nil, ErrTransactionNotConfirmed
+		if len(result) < limit {
+			break
 		}
+	}
 
-		resp, err := c.GetTransaction(ctx, tx.Signature)
-		if err != nil {
-			return "", nil, fmt.Errorf("failed to get oldest transaction for wallet: %s: %w", base58Addr, err)
-		}
+	if oldest == nil || oldest.Signature == "" {
+		return "", nil, nil
+	}
+	if oldest.Err != nil {
+		return "", nil, NewTransactionError(oldest.Err)
+	}
+	if oldest.BlockTime == nil || *oldest.BlockTime == 0 || *oldest.BlockTime > time.Now().Unix() {
+		return "", nil, ErrTransactionNotConfirmed
+	}
 
-		return tx.Signature, resp, nil
+	resp, err := c.GetTransaction(ctx, oldest.Signature)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get oldest transaction for wallet: %s: %w", base58Addr, err)
 	}
 
-	return c.GetOldestTransactionForWallet(ctx, base58Addr, result[limit-1].Signature)
+	return oldest.Signature, resp, nil
 }
 
 // GetTransaction returns the transaction by the given base58 encoded transaction signature.
@@ -317,30 +593,45 @@ func (c *Client) GetTransaction(ctx context.Context, txSignature string) (*clien
 		return nil, ErrTransactionNotFound
 	}
 	if tx.Meta.Err != nil {
-		return nil, fmt.Errorf("transaction failed: %v", tx.Meta.Err)
+		return nil, NewTransactionError(tx.Meta.Err)
 	}
 
 	return tx, nil
 }
 
 // ValidateTransactionByReference returns the transaction by the given reference.
-// Returns transaction signature or an error if the transaction is not found or the transaction failed.
-func (c *Client) ValidateTransactionByReference(ctx context.Context, reference, destination string, amount uint64, mint string) (string, error) {
+// The payment is valid if the destination has received at least amount minus amountTolerance,
+// so the overpayments are accepted; pass zero tolerance to require at least the exact amount.
+// Both SPL Token and Token-2022 transfers are validated for the token mints.
+// Returns the transaction signature and the actual transferred amount,
+// or an error if the transaction is not found, failed or transferred less than expected.
+// ErrNoTransactionsFound means there is no payment for the reference yet, so the caller may retry later.
+func (c *Client) ValidateTransactionByReference(ctx context.Context, reference, destination string, amount, amountTolerance uint64, mint string) (string, uint64, error) {
 	txSign, tx, err := c.GetOldestTransactionForWallet(ctx, reference, "")
 	if err != nil {
-		return "", fmt.Errorf("failed to validate transaction for reference %s: %w", reference, err)
+		return "", 0, fmt.Errorf("failed to validate transaction for reference %s: %w", reference, err)
+	}
+	if txSign == "" {
+		return "", 0, fmt.Errorf("failed to validate transaction for reference %s: %w", reference, ErrNoTransactionsFound)
 	}
 
+	var received uint64
 	if mint == "" || mint == "SOL" || mint == "So11111111111111111111111111111111111111112" {
-		if err := CheckSolTransferTransaction(tx.Meta, tx.Transaction, destination, amount); err != nil {
-			return "", fmt.Errorf("failed to validate transaction for reference %s: %w", reference, err)
+		received, err = solTransferAmount(tx.Meta, tx.Transaction, destination)
+	} else {
+		var tokenProgramID common.PublicKey
+		tokenProgramID, err = c.GetTokenProgramForMint(ctx, mint)
+		if err == nil {
+			received, err = tokenTransferAmount(tx.Meta, tokenProgramID, mint, destination)
 		}
-		return txSign, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to validate transaction for reference %s: %w", reference, err)
 	}
 
-	if err := CheckTokenTransferTransaction(tx.Meta, tx.Transaction, mint, destination, amount); err != nil {
-		return "", fmt.Errorf("failed to validate transaction for reference %s: %w", reference, err)
+	if err := checkTransferAmount(received, amount, amountTolerance); err != nil {
+		return "", received, fmt.Errorf("failed to validate transaction for reference %s: %w", reference, err)
 	}
 
-	return txSign, nil
+	return txSign, received, nil
 }
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/compute_budget"
+	"github.com/portto/solana-go-sdk/program/system"
+	sdktypes "github.com/portto/solana-go-sdk/types"
+)
+
+const (
+	// defaultInstructionComputeUnits is the compute unit limit of an instruction
+	// if the transaction doesn't set the limit explicitly; the runtime may allot less to the builtin programs,
+	// so the priority fee of such transactions is an upper bound.
+	defaultInstructionComputeUnits = 200_000
+	// maxComputeUnitLimit is the maximum number of compute units a transaction can consume.
+	maxComputeUnitLimit = 1_400_000
+	// microLamportsPerLamport is the compute unit price denomination.
+	microLamportsPerLamport = 1_000_000
+)
+
+// TransactionCost is the estimated cost of the transaction in lamports, see EstimateTransactionCost.
+type TransactionCost struct {
+	BaseFee     uint64 // signature fee
+	PriorityFee uint64 // compute unit price multiplied by the compute unit limit
+	Rent        uint64 // lamports funding the accounts created by the system program instructions
+	Total       uint64 // sum of the fees and the rent
+}
+
+// EstimateTransactionCost estimates the total cost of the given base64 encoded transaction for the fee payer
+// before signing it.
+// The fee is returned by the RPC node, which includes the priority fee set by the compute budget instructions.
+// The rent is the sum of the lamports transferred to the accounts created by the system CreateAccount
+// and CreateAccountWithSeed instructions; the accounts created by other programs, e.g. associated token accounts,
+// are funded via cross-program invocations and not included.
+func (c *Client) EstimateTransactionCost(ctx context.Context, txSource string) (TransactionCost, error) {
+	tx, err := utils.DecodeTransaction(txSource)
+	if err != nil {
+		return TransactionCost{}, utils.StackErrors(ErrEstimateTransactionCost, ErrDeserializeTransaction, err)
+	}
+
+	cost, err := parseTransactionCost(tx.Message)
+	if err != nil {
+		return TransactionCost{}, utils.StackErrors(ErrEstimateTransactionCost, err)
+	}
+
+	fee, err := c.rpcClient.GetFeeForMessage(ctx, tx.Message)
+	if err != nil {
+		return TransactionCost{}, utils.StackErrors(ErrEstimateTransactionCost, err)
+	}
+	if fee == nil {
+		return TransactionCost{}, utils.StackErrors(ErrEstimateTransactionCost, ErrBlockhashNotFound)
+	}
+
+	// the node fee includes the priority fee, unless the node is too old to charge it
+	cost.BaseFee = *fee
+	if *fee > cost.PriorityFee {
+		cost.BaseFee = *fee - cost.PriorityFee
+	}
+	cost.Total = cost.BaseFee + cost.PriorityFee + cost.Rent
+
+	return cost, nil
+}
+
+// parseTransactionCost calculates the priority fee and the rent of the message instructions.
+func parseTransactionCost(msg sdktypes.Message) (TransactionCost, error) {
+	var (
+		cost         TransactionCost
+		unitLimit    *uint64
+		unitPrice    uint64
+		instructions uint64
+	)
+	for i, instr := range msg.Instructions {
+		if instr.ProgramIDIndex >= len(msg.Accounts) {
+			return TransactionCost{}, fmt.Errorf("instruction #%d: program index %d is out of range", i, instr.ProgramIDIndex)
+		}
+
+		switch msg.Accounts[instr.ProgramIDIndex] {
+		case common.ComputeBudgetProgramID:
+			if len(instr.Data) == 0 {
+				continue
+			}
+			switch compute_budget.Instruction(instr.Data[0]) {
+			case compute_budget.InstructionSetComputeUnitLimit:
+				if len(instr.Data) < 5 {
+					return TransactionCost{}, fmt.Errorf("instruction #%d: invalid set compute unit limit data", i)
+				}
+				limit := uint64(binary.LittleEndian.Uint32(instr.Data[1:5]))
+				unitLimit = &limit
+			case compute_budget.InstructionSetComputeUnitPrice:
+				if len(instr.Data) < 9 {
+					return TransactionCost{}, fmt.Errorf("instruction #%d: invalid set compute unit price data", i)
+				}
+				unitPrice = binary.LittleEndian.Uint64(instr.Data[1:9])
+			}
+			// the compute budget instructions don't consume the default compute units
+			continue
+
+		case common.SystemProgramID:
+			lamports, err := createdAccountLamports(instr.Data)
+			if err != nil {
+				return TransactionCost{}, fmt.Errorf("instruction #%d: %w", i, err)
+			}
+			cost.Rent += lamports
+		}
+
+		instructions++
+	}
+
+	limit := instructions * defaultInstructionComputeUnits
+	if unitLimit != nil {
+		limit = *unitLimit
+	}
+	if limit > maxComputeUnitLimit {
+		limit = maxComputeUnitLimit
+	}
+	// ceil(price * limit / 1e6), saturated like the runtime does
+	hi, lo := bits.Mul64(unitPrice, limit)
+	lo, carry := bits.Add64(lo, microLamportsPerLamport-1, 0)
+	hi += carry
+	if hi >= microLamportsPerLamport {
+		cost.PriorityFee = math.MaxUint64
+	} else {
+		cost.PriorityFee, _ = bits.Div64(hi, lo, microLamportsPerLamport)
+	}
+
+	return cost, nil
+}
+
+// createdAccountLamports returns the lamports transferred to the new account
+// by the system CreateAccount or CreateAccountWithSeed instruction; zero for other instructions.
+func createdAccountLamports(data []byte) (uint64, error) {
+	if len(data) < 4 {
+		return 0, nil
+	}
+
+	switch system.Instruction(binary.LittleEndian.Uint32(data[:4])) {
+	case system.InstructionCreateAccount:
+		// instruction, lamports, space, owner
+		if len(data) < 12 {
+			return 0, fmt.Errorf("invalid create account data")
+		}
+		return binary.LittleEndian.Uint64(data[4:12]), nil
+
+	case system.InstructionCreateAccountWithSeed:
+		// instruction, base, seed (u64 length prefixed), lamports, space, owner
+		offset := 4 + common.PublicKeyLength
+		if len(data) < offset+8 {
+			return 0, fmt.Errorf("invalid create account with seed data")
+		}
+		seedLen := binary.LittleEndian.Uint64(data[offset:])
+		offset += 8
+		if seedLen > uint64(len(data)-offset) || len(data)-offset-int(seedLen) < 8 {
+			return 0, fmt.Errorf("invalid create account with seed data")
+		}
+		offset += int(seedLen)
+		return binary.LittleEndian.Uint64(data[offset:]), nil
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,115 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/compute_budget"
+	"github.com/portto/solana-go-sdk/program/system"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateTransactionCost(t *testing.T) {
+	payer := types.NewAccount()
+	mint := types.NewAccount()
+
+	encode := func(t *testing.T, signers []types.Account, instructions ...types.Instruction) string {
+		tx, err := types.NewTransaction(types.NewTransactionParam{
+			Message: types.NewMessage(types.NewMessageParam{
+				FeePayer:        payer.PublicKey,
+				RecentBlockhash: types.NewAccount().PublicKey.ToBase58(),
+				Instructions:    instructions,
+			}),
+			Signers: signers,
+		})
+		require.NoError(t, err)
+		txStr, err := utils.EncodeTransaction(tx)
+		require.NoError(t, err)
+		return txStr
+	}
+
+	t.Run("mint", func(t *testing.T) {
+		txStr := encode(t, []types.Account{payer, mint},
+			compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: 100_000}),
+			compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: 25_000}),
+			system.CreateAccount(system.CreateAccountParam{
+				From:     payer.PublicKey,
+				New:      mint.PublicKey,
+				Owner:    common.TokenProgramID,
+				Lamports: 1_461_600,
+				Space:    token.MintAccountSize,
+			}),
+			system.CreateAccountWithSeed(system.CreateAccountWithSeedParam{
+				From:     payer.PublicKey,
+				New:      types.NewAccount().PublicKey,
+				Base:     payer.PublicKey,
+				Owner:    common.TokenProgramID,
+				Seed:     "token",
+				Lamports: 2_039_280,
+				Space:    token.TokenAccountSize,
+			}),
+			token.InitializeMint2(token.InitializeMint2Param{
+				Decimals:   6,
+				Mint:       mint.PublicKey,
+				MintAuth:   payer.PublicKey,
+				FreezeAuth: nil,
+			}),
+		)
+		// two signatures plus the priority fee: 100k units at 0.025 lamports
+		c := newMockClient(t, map[string]interface{}{
+			"getFeeForMessage": withContext(10_000 + 2_500),
+		})
+
+		cost, err := c.EstimateTransactionCost(context.Background(), txStr)
+		require.NoError(t, err)
+		assert.Equal(t, client.TransactionCost{
+			BaseFee:     10_000,
+			PriorityFee: 2_500,
+			Rent:        1_461_600 + 2_039_280,
+			Total:       10_000 + 2_500 + 1_461_600 + 2_039_280,
+		}, cost)
+	})
+
+	t.Run("plain transfer", func(t *testing.T) {
+		txStr := encode(t, []types.Account{payer}, system.Transfer(system.TransferParam{
+			From:   payer.PublicKey,
+			To:     types.NewAccount().PublicKey,
+			Amount: 1_000_000,
+		}))
+		c := newMockClient(t, map[string]interface{}{
+			"getFeeForMessage": withContext(5_000),
+		})
+
+		cost, err := c.EstimateTransactionCost(context.Background(), txStr)
+		require.NoError(t, err)
+		assert.Equal(t, client.TransactionCost{BaseFee: 5_000, Total: 5_000}, cost)
+	})
+
+	t.Run("default compute unit limit", func(t *testing.T) {
+		txStr := encode(t, []types.Account{payer},
+			compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: 1}),
+			system.Transfer(system.TransferParam{From: payer.PublicKey, To: types.NewAccount().PublicKey, Amount: 1}),
+			system.Transfer(system.TransferParam{From: payer.PublicKey, To: types.NewAccount().PublicKey, Amount: 1}),
+		)
+		c := newMockClient(t, map[string]interface{}{
+			"getFeeForMessage": withContext(5_001),
+		})
+
+		// 2 * 200k units at 1 micro-lamport, rounded up
+		cost, err := c.EstimateTransactionCost(context.Background(), txStr)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), cost.PriorityFee)
+		assert.Equal(t, uint64(5_000), cost.BaseFee)
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		_, err := newMockClient(t, nil).EstimateTransactionCost(context.Background(), "invalid")
+		require.ErrorIs(t, err, client.ErrEstimateTransactionCost)
+	})
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionErrorInstructionError is the type of the transaction error caused by a failed instruction.
+const TransactionErrorInstructionError = "InstructionError"
+
+// TransactionError is the error of a failed transaction, as reported by the node,
+// e.g. {"InstructionError":[0,{"Custom":1}]} or "BlockhashNotFound".
+// Use errors.As to get it from the errors returned by the client.
+type TransactionError struct {
+	Type             string          // transaction error type, e.g. "InstructionError" or "InsufficientFundsForFee"
+	InstructionIndex int             // index of the failed instruction; set only if Type is TransactionErrorInstructionError
+	InstructionError string          // instruction error type, e.g. "Custom" or "InvalidAccountData"; empty if it's not an instruction error
+	CustomCode       *uint32         // custom program error code; nil if it's not a custom program error
+	Raw              json.RawMessage // the error as returned by the node
+}
+
+// NewTransactionError parses the transaction error returned by the node, e.g. the Err field of the signature status.
+// The error details are preserved in the Raw field if the error can't be parsed.
+func NewTransactionError(raw interface{}) *TransactionError {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return &TransactionError{Type: fmt.Sprintf("%v", raw)}
+	}
+
+	txErr := &TransactionError{}
+	if err := txErr.UnmarshalJSON(data); err != nil {
+		return &TransactionError{Raw: data}
+	}
+
+	return txErr
+}
+
+// UnmarshalJSON decodes the transaction error returned by the node.
+// Implements the json.Unmarshaler interface.
+func (e *TransactionError) UnmarshalJSON(data []byte) error {
+	*e = TransactionError{Raw: append(json.RawMessage(nil), data...)}
+
+	// unit variant, e.g. "BlockhashNotFound"
+	if err := json.Unmarshal(data, &e.Type); err == nil {
+		return nil
+	}
+
+	// variant with fields, e.g. {"InsufficientFundsForRent":{"account_index":0}}
+	var variant map[string]json.RawMessage
+	if err := json.Unmarshal(data, &variant); err != nil {
+		return fmt.Errorf("failed to decode transaction error: %w", err)
+	}
+	if len(variant) != 1 {
+		return fmt.Errorf("failed to decode transaction error: unexpected format: %s", data)
+	}
+	for typ, details := range variant {
+		e.Type = typ
+		if typ != TransactionErrorInstructionError {
+			return nil
+		}
+
+		// [index, "InvalidAccountData"] or [index, {"Custom": code}]
+		var instrErr []json.RawMessage
+		if err := json.Unmarshal(details, &instrErr); err != nil || len(instrErr) != 2 {
+			return fmt.Errorf("failed to decode instruction error: unexpected format: %s", details)
+		}
+		if err := json.Unmarshal(instrErr[0], &e.InstructionIndex); err != nil {
+			return fmt.Errorf("failed to decode instruction error index: %w", err)
+		}
+		if err := json.Unmarshal(instrErr[1], &e.InstructionError); err == nil {
+			return nil
+		}
+
+		var instrVariant map[string]json.RawMessage
+		if err := json.Unmarshal(instrErr[1], &instrVariant); err != nil || len(instrVariant) != 1 {
+			return fmt.Errorf("failed to decode instruction error: unexpected format: %s", instrErr[1])
+		}
+		for instrTyp, value := range instrVariant {
+			e.InstructionError = instrTyp
+			if instrTyp == "Custom" {
+				var code uint32
+				if err := json.Unmarshal(value, &code); err != nil {
+					return fmt.Errorf("failed to decode custom program error code: %w", err)
+				}
+				e.CustomCode = &code
+			}
+		}
+	}
+
+	return nil
+}
+
+// Error returns the error message, e.g. "transaction failed: instruction #0: custom program error: 0x1".
+// Implements the error interface.
+func (e *TransactionError) Error() string {
+	switch {
+	case e.CustomCode != nil:
+		return fmt.Sprintf("transaction failed: instruction #%d: custom program error: %#x", e.InstructionIndex, *e.CustomCode)
+	case e.Type == TransactionErrorInstructionError:
+		return fmt.Sprintf("transaction failed: instruction #%d: %s", e.InstructionIndex, e.InstructionError)
+	case e.Type != "":
+		return fmt.Sprintf("transaction failed: %s", e.Type)
+	default:
+		return fmt.Sprintf("transaction failed: %s", e.Raw)
+	}
+}
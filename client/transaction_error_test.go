@@ -0,0 +1,94 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionError_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		payload   string
+		typ       string
+		index     int
+		instrErr  string
+		code      *uint32
+		wantError string
+	}{
+		{
+			payload:   `{"InstructionError":[1,{"Custom":1}]}`,
+			typ:       client.TransactionErrorInstructionError,
+			index:     1,
+			instrErr:  "Custom",
+			code:      func() *uint32 { c := uint32(1); return &c }(),
+			wantError: "transaction failed: instruction #1: custom program error: 0x1",
+		},
+		{
+			payload:   `{"InstructionError":[0,"InvalidAccountData"]}`,
+			typ:       client.TransactionErrorInstructionError,
+			instrErr:  "InvalidAccountData",
+			wantError: "transaction failed: instruction #0: InvalidAccountData",
+		},
+		{
+			payload:   `{"InstructionError":[2,{"BorshIoError":"Unknown"}]}`,
+			typ:       client.TransactionErrorInstructionError,
+			index:     2,
+			instrErr:  "BorshIoError",
+			wantError: "transaction failed: instruction #2: BorshIoError",
+		},
+		{
+			payload:   `"BlockhashNotFound"`,
+			typ:       "BlockhashNotFound",
+			wantError: "transaction failed: BlockhashNotFound",
+		},
+		{
+			payload:   `{"InsufficientFundsForRent":{"account_index":0}}`,
+			typ:       "InsufficientFundsForRent",
+			wantError: "transaction failed: InsufficientFundsForRent",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			var txErr client.TransactionError
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &txErr))
+			assert.Equal(t, tt.typ, txErr.Type)
+			assert.Equal(t, tt.index, txErr.InstructionIndex)
+			assert.Equal(t, tt.instrErr, txErr.InstructionError)
+			assert.Equal(t, tt.code, txErr.CustomCode)
+			assert.JSONEq(t, tt.payload, string(txErr.Raw))
+			assert.EqualError(t, &txErr, tt.wantError)
+
+			// the same error decoded by the SDK into an interface value
+			var raw interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &raw))
+			assert.Equal(t, txErr.Error(), client.NewTransactionError(raw).Error())
+		})
+	}
+}
+
+func TestGetTransactionStatus_TransactionError(t *testing.T) {
+	// a failed metaplex token metadata instruction
+	sc := newMockClient(t, map[string]interface{}{
+		"getSignatureStatuses": withContext([]interface{}{map[string]interface{}{
+			"slot":               1,
+			"confirmations":      nil,
+			"err":                map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 0x1}}},
+			"confirmationStatus": "finalized",
+		}}),
+	})
+
+	status, err := sc.GetTransactionStatus(context.Background(), "txhash")
+	assert.Equal(t, types.TransactionStatusFailure, status)
+
+	var txErr *client.TransactionError
+	require.ErrorAs(t, err, &txErr)
+	assert.Equal(t, client.TransactionErrorInstructionError, txErr.Type)
+	assert.Equal(t, 0, txErr.InstructionIndex)
+	require.NotNil(t, txErr.CustomCode)
+	assert.Equal(t, uint32(1), *txErr.CustomCode)
+}
@@ -0,0 +1,570 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/types"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/program/system"
+	"github.com/portto/solana-go-sdk/rpc"
+	sdktypes "github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is the manually advanced clock.
+// Ticks are delivered one by one, so each tick results in exactly one poll.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Duration
+	tickers []*fakeTicker
+	timers  []*fakeTimer
+	started chan struct{}
+}
+
+type fakeTicker struct {
+	interval time.Duration
+	next     time.Duration
+	c        chan time.Time
+	stopped  chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Duration
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{started: make(chan struct{})}
+}
+
+func (f *fakeClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTicker{interval: d, next: f.now + d, c: make(chan time.Time), stopped: make(chan struct{})}
+	f.tickers = append(f.tickers, t)
+	return t.c, func() { close(t.stopped) }
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTimer{at: f.now + d, c: make(chan time.Time, 1)}
+	f.timers = append(f.timers, t)
+	close(f.started)
+	return t.c
+}
+
+// Advance moves the clock forward by the given duration, firing the tickers and timers in order.
+// The timers are expected to stop the polling loop, so no ticks are sent after a timer fires.
+func (f *fakeClock) Advance(d time.Duration) {
+	<-f.started
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	end := f.now + d
+	for _, t := range f.tickers {
+		for ; t.next <= end; t.next += t.interval {
+			for _, timer := range f.timers {
+				if timer.at < t.next {
+					timer.c <- time.Unix(0, 0).Add(timer.at)
+					return
+				}
+			}
+			select {
+			case t.c <- time.Unix(0, 0).Add(t.next):
+			case <-t.stopped:
+				return
+			}
+		}
+	}
+	for _, timer := range f.timers {
+		if timer.at <= end {
+			timer.c <- time.Unix(0, 0).Add(timer.at)
+			return
+		}
+	}
+	f.now = end
+}
+
+func signatureStatus(commitment rpc.Commitment) interface{} {
+	return map[string]interface{}{
+		"context": map[string]interface{}{"slot": 1},
+		"value": []interface{}{map[string]interface{}{
+			"slot":               1,
+			"confirmations":      nil,
+			"err":                nil,
+			"confirmationStatus": commitment,
+		}},
+	}
+}
+
+func TestWaitForTransactionConfirmedWithOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       client.ConfirmOptions
+		statuses   []rpc.Commitment
+		advance    time.Duration
+		wantStatus types.TransactionStatus
+		wantErr    bool
+		wantPolls  int
+	}{
+		{
+			name:       "default options wait for finalized",
+			opts:       client.ConfirmOptions{},
+			statuses:   []rpc.Commitment{rpc.CommitmentProcessed, rpc.CommitmentConfirmed, rpc.CommitmentFinalized},
+			advance:    time.Minute,
+			wantStatus: types.TransactionStatusSuccess,
+			wantPolls:  3,
+		},
+		{
+			name:       "confirmed commitment",
+			opts:       client.ConfirmOptions{PollInterval: time.Second, Commitment: rpc.CommitmentConfirmed},
+			statuses:   []rpc.Commitment{rpc.CommitmentProcessed, rpc.CommitmentProcessed, rpc.CommitmentConfirmed},
+			advance:    time.Minute,
+			wantStatus: types.TransactionStatusSuccess,
+			wantPolls:  3,
+		},
+		{
+			name:       "max duration",
+			opts:       client.ConfirmOptions{PollInterval: time.Second, MaxDuration: 10 * time.Second},
+			statuses:   []rpc.Commitment{rpc.CommitmentConfirmed},
+			advance:    time.Minute,
+			wantStatus: types.TransactionStatusUnknown,
+			wantErr:    true,
+			wantPolls:  10,
+		},
+		{
+			name:       "default max duration",
+			opts:       client.ConfirmOptions{},
+			statuses:   []rpc.Commitment{rpc.CommitmentProcessed},
+			advance:    10 * time.Minute,
+			wantStatus: types.TransactionStatusUnknown,
+			wantErr:    true,
+			wantPolls:  60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu    sync.Mutex
+				polls int
+			)
+			clk := newFakeClock()
+			c := newMockClient(t, map[string]interface{}{
+				"getSignatureStatuses": func() interface{} {
+					mu.Lock()
+					defer mu.Unlock()
+					status := tt.statuses[len(tt.statuses)-1]
+					if polls < len(tt.statuses) {
+						status = tt.statuses[polls]
+					}
+					polls++
+					return signatureStatus(status)
+				},
+			}, client.WithClock(clk))
+
+			go clk.Advance(tt.advance)
+
+			status, err := c.WaitForTransactionConfirmedWithOptions(context.Background(), "txhash", tt.opts)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantStatus, status)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, tt.wantPolls, polls)
+		})
+	}
+}
+
+func TestWaitForTransactionConfirmed_TransactionFailed(t *testing.T) {
+	clk := newFakeClock()
+	c := newMockClient(t, map[string]interface{}{
+		"getSignatureStatuses": withContext([]interface{}{map[string]interface{}{
+			"slot":               1,
+			"confirmations":      nil,
+			"err":                map[string]interface{}{"InstructionError": []interface{}{1, map[string]interface{}{"Custom": 6001}}},
+			"confirmationStatus": "confirmed",
+		}}),
+	}, client.WithClock(clk))
+
+	go clk.Advance(time.Minute)
+
+	// the failed transaction is reported by both the status and the error
+	status, err := c.WaitForTransactionConfirmed(context.Background(), "txhash", 0)
+	assert.Equal(t, types.TransactionStatusFailure, status)
+	require.ErrorIs(t, err, client.ErrWaitForTransaction)
+	require.NotErrorIs(t, err, client.ErrContextDone)
+
+	var txErr *client.TransactionError
+	require.ErrorAs(t, err, &txErr)
+	assert.Equal(t, client.TransactionErrorInstructionError, txErr.Type)
+	assert.Equal(t, 1, txErr.InstructionIndex)
+	require.NotNil(t, txErr.CustomCode)
+	assert.Equal(t, uint32(6001), *txErr.CustomCode)
+}
+
+func TestWaitForTransactionConfirmedWithOptions_UnsupportedCommitment(t *testing.T) {
+	c := newMockClient(t, nil, client.WithClock(newFakeClock()))
+	_, err := c.WaitForTransactionConfirmedWithOptions(context.Background(), "txhash", client.ConfirmOptions{Commitment: "recent"})
+	require.Error(t, err)
+}
+
+// transferTransaction returns the base64 encoded SOL transfer transaction signed by a random fee payer.
+func transferTransaction(t *testing.T) string {
+	t.Helper()
+
+	feePayer := sdktypes.NewAccount()
+	tx, err := sdktypes.NewTransaction(sdktypes.NewTransactionParam{
+		Message: sdktypes.NewMessage(sdktypes.NewMessageParam{
+			FeePayer: feePayer.PublicKey,
+			Instructions: []sdktypes.Instruction{system.Transfer(system.TransferParam{
+				From:   feePayer.PublicKey,
+				To:     sdktypes.NewAccount().PublicKey,
+				Amount: 1,
+			})},
+			RecentBlockhash: sdktypes.NewAccount().PublicKey.ToBase58(),
+		}),
+		Signers: []sdktypes.Account{feePayer},
+	})
+	require.NoError(t, err)
+	txSource, err := utils.EncodeTransaction(tx)
+	require.NoError(t, err)
+
+	return txSource
+}
+
+func TestSendTransaction_Errors(t *testing.T) {
+	txSource := transferTransaction(t)
+
+	tests := []struct {
+		name    string
+		message string
+		want    error
+		calls   int
+	}{
+		{"rent", "Transaction results in an account (1) with insufficient funds for rent", client.ErrWithoutInsufficientFound, 1},
+		{"fee", "Transaction simulation failed: Insufficient funds for fee", client.ErrInsufficientFunds, 1},
+		{"no fee payer", "Transaction simulation failed: Attempt to debit an account but found no record of a prior credit.", client.ErrInsufficientFunds, 1},
+		{"blockhash", "Transaction simulation failed: Blockhash not found", client.ErrBlockhashNotFound, 4}, // retried 3 times
+		{"unknown", "Transaction signature verification failure", nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			sc := newMockClient(t, map[string]interface{}{
+				"sendTransaction": func() interface{} {
+					calls++
+					return &rpc.JsonRpcError{Code: -32002, Message: tt.message}
+				},
+			})
+
+			_, err := sc.SendTransaction(context.Background(), txSource)
+			require.ErrorIs(t, err, client.ErrSendTransaction)
+			if tt.want != nil {
+				require.ErrorIs(t, err, tt.want)
+			}
+			for _, other := range []error{client.ErrWithoutInsufficientFound, client.ErrInsufficientFunds, client.ErrBlockhashNotFound} {
+				if other != tt.want {
+					assert.NotErrorIs(t, err, other)
+				}
+			}
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+}
+
+func TestSendTransactionWithOptions(t *testing.T) {
+	txSource := transferTransaction(t)
+	maxRetries, noRetries := uint64(2), uint64(0)
+
+	tests := []struct {
+		name string
+		opts client.SendOptions
+		want map[string]interface{}
+	}{
+		{"defaults", client.SendOptions{}, map[string]interface{}{
+			"encoding": "base64",
+		}},
+		{"skip preflight", client.SendOptions{SkipPreflight: true, MaxRetries: &maxRetries}, map[string]interface{}{
+			"encoding":      "base64",
+			"skipPreflight": true,
+			"maxRetries":    float64(2),
+		}},
+		{"no node retries", client.SendOptions{MaxRetries: &noRetries}, map[string]interface{}{
+			"encoding":   "base64",
+			"maxRetries": float64(0),
+		}},
+		{"preflight commitment", client.SendOptions{PreflightCommitment: rpc.CommitmentConfirmed}, map[string]interface{}{
+			"encoding":            "base64",
+			"preflightCommitment": "confirmed",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg map[string]interface{}
+			sc := newMockClient(t, map[string]interface{}{
+				"sendTransaction": func(params []json.RawMessage) interface{} {
+					require.Len(t, params, 2)
+					require.NoError(t, json.Unmarshal(params[1], &cfg))
+					return "txhash"
+				},
+			})
+
+			txhash, err := sc.SendTransactionWithOptions(context.Background(), txSource, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, "txhash", txhash)
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+
+	t.Run("unsupported preflight commitment", func(t *testing.T) {
+		sc := newMockClient(t, nil)
+
+		_, err := sc.SendTransactionWithOptions(context.Background(), txSource, client.SendOptions{PreflightCommitment: "latest"})
+		require.ErrorIs(t, err, client.ErrSendTransaction)
+	})
+}
+
+func TestNewTransactionWithLastValidBlockHeight(t *testing.T) {
+	feePayer := sdktypes.NewAccount()
+	sc := newMockClient(t, map[string]interface{}{
+		"getLatestBlockhash": withContext(map[string]interface{}{
+			"blockhash":            sdktypes.NewAccount().PublicKey.ToBase58(),
+			"lastValidBlockHeight": 150,
+		}),
+	})
+
+	tx, lastValidBlockHeight, err := sc.NewTransactionWithLastValidBlockHeight(context.Background(), client.NewTransactionParams{
+		FeePayer: feePayer.PublicKey,
+		Instructions: []sdktypes.Instruction{system.Transfer(system.TransferParam{
+			From:   feePayer.PublicKey,
+			To:     sdktypes.NewAccount().PublicKey,
+			Amount: 1,
+		})},
+		Signers: []sdktypes.Account{feePayer},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tx)
+	assert.Equal(t, uint64(150), lastValidBlockHeight)
+}
+
+func TestSendAndConfirmTransaction_BlockhashExpiry(t *testing.T) {
+	txSource := transferTransaction(t)
+
+	opts := client.ConfirmOptions{
+		PollInterval:         time.Millisecond,
+		MaxDuration:          time.Second,
+		LastValidBlockHeight: 100,
+	}
+	blockhashNotFound := &rpc.JsonRpcError{Code: -32002, Message: "Transaction simulation failed: Blockhash not found"}
+
+	t.Run("resend until the blockhash expires", func(t *testing.T) {
+		var (
+			mu     sync.Mutex
+			sends  int
+			height uint64 = 98
+		)
+		sc := newMockClient(t, map[string]interface{}{
+			"sendTransaction": func() interface{} {
+				mu.Lock()
+				defer mu.Unlock()
+				sends++
+				return blockhashNotFound
+			},
+			"getBlockHeight": func() interface{} {
+				mu.Lock()
+				defer mu.Unlock()
+				height++
+				return height
+			},
+		})
+
+		_, status, err := sc.SendAndConfirmTransaction(context.Background(), txSource, opts)
+		require.ErrorIs(t, err, client.ErrSendAndConfirmTransaction)
+		require.ErrorIs(t, err, client.ErrBlockhashExpired)
+		assert.Equal(t, types.TransactionStatusUnknown, status)
+
+		mu.Lock()
+		defer mu.Unlock()
+		// block heights 99 and 100 are still valid, 101 is not
+		assert.Equal(t, 3, sends)
+	})
+
+	t.Run("stop waiting once the blockhash expires", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"sendTransaction":      "txhash",
+			"getSignatureStatuses": withContext([]interface{}{nil}),
+			"getBlockHeight":       101,
+		})
+
+		txhash, status, err := sc.SendAndConfirmTransaction(context.Background(), txSource, opts)
+		require.ErrorIs(t, err, client.ErrWaitForTransaction)
+		require.ErrorIs(t, err, client.ErrBlockhashExpired)
+		assert.Equal(t, "txhash", txhash)
+		assert.Equal(t, types.TransactionStatusUnknown, status)
+	})
+}
+
+func TestSendAndConfirmTransaction(t *testing.T) {
+	txSource := transferTransaction(t)
+	opts := client.ConfirmOptions{PollInterval: time.Millisecond, MaxDuration: time.Second}
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			mu    sync.Mutex
+			polls int
+		)
+		statuses := []rpc.Commitment{rpc.CommitmentProcessed, rpc.CommitmentConfirmed, rpc.CommitmentFinalized}
+		sc := newMockClient(t, map[string]interface{}{
+			"sendTransaction": "txhash",
+			"getSignatureStatuses": func() interface{} {
+				mu.Lock()
+				defer mu.Unlock()
+				status := statuses[polls]
+				polls++
+				return signatureStatus(status)
+			},
+		})
+
+		txhash, status, err := sc.SendAndConfirmTransaction(context.Background(), txSource, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "txhash", txhash)
+		assert.Equal(t, types.TransactionStatusSuccess, status)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, len(statuses), polls)
+	})
+
+	t.Run("transaction failed", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"sendTransaction": "txhash",
+			"getSignatureStatuses": withContext([]interface{}{map[string]interface{}{
+				"slot":               1,
+				"confirmations":      nil,
+				"err":                map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 1}}},
+				"confirmationStatus": "confirmed",
+			}}),
+		})
+
+		txhash, status, err := sc.SendAndConfirmTransaction(context.Background(), txSource, opts)
+		require.ErrorIs(t, err, client.ErrSendAndConfirmTransaction)
+		assert.Equal(t, "txhash", txhash)
+		assert.Equal(t, types.TransactionStatusFailure, status)
+
+		var txErr *client.TransactionError
+		require.ErrorAs(t, err, &txErr)
+		require.NotNil(t, txErr.CustomCode)
+		assert.Equal(t, uint32(1), *txErr.CustomCode)
+	})
+
+	t.Run("send failed", func(t *testing.T) {
+		sc := newMockClient(t, map[string]interface{}{
+			"sendTransaction": &rpc.JsonRpcError{Code: -32002, Message: "Transaction simulation failed: Insufficient funds for fee"},
+		})
+
+		txhash, status, err := sc.SendAndConfirmTransaction(context.Background(), txSource, opts)
+		require.ErrorIs(t, err, client.ErrSendAndConfirmTransaction)
+		require.ErrorIs(t, err, client.ErrInsufficientFunds)
+		assert.Empty(t, txhash)
+		assert.Equal(t, types.TransactionStatusUnknown, status)
+	})
+}
+
+func TestGetOldestTransactionForWallet(t *testing.T) {
+	wallet := sdktypes.NewAccount().PublicKey.ToBase58()
+	rawTx := transferTransaction(t)
+
+	signatures := func(n int) []string {
+		result := make([]string, n)
+		for i := range result {
+			result[i] = fmt.Sprintf("sig%d", i)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name         string
+		signatures   []string
+		wantRequests int
+	}{
+		{"empty history", nil, 1},
+		{"single page", signatures(3), 1},
+		{"multiple pages", signatures(2500), 3},
+		{"full last page", signatures(2000), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			var fetched string
+			sc := newMockClient(t, map[string]interface{}{
+				"getSignaturesForAddress": signaturesPages(t, tt.signatures, &requests),
+				"getTransaction": func(params []json.RawMessage) interface{} {
+					require.NoError(t, json.Unmarshal(params[0], &fetched))
+					return map[string]interface{}{
+						"slot":        1,
+						"transaction": []string{rawTx, "base64"},
+						"meta":        rpc.TransactionMeta{},
+					}
+				},
+			})
+
+			txSign, tx, err := sc.GetOldestTransactionForWallet(context.Background(), wallet, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRequests, requests)
+
+			if len(tt.signatures) == 0 {
+				assert.Empty(t, txSign)
+				assert.Nil(t, tx)
+				assert.Empty(t, fetched)
+				return
+			}
+
+			oldest := tt.signatures[len(tt.signatures)-1]
+			assert.Equal(t, oldest, txSign)
+			assert.Equal(t, oldest, fetched)
+			assert.NotNil(t, tx)
+		})
+	}
+
+	t.Run("too many pages", func(t *testing.T) {
+		var requests int
+		sc := newMockClient(t, map[string]interface{}{
+			// every page is full, so the history never ends
+			"getSignaturesForAddress": func(params []json.RawMessage) interface{} {
+				requests++
+				blockTime := time.Now().Add(-time.Hour).Unix()
+				page := make([]rpc.SignatureWithStatus, 1000)
+				for i := range page {
+					page[i] = rpc.SignatureWithStatus{Signature: fmt.Sprintf("sig%d-%d", requests, i), BlockTime: &blockTime}
+				}
+				return page
+			},
+		})
+
+		_, _, err := sc.GetOldestTransactionForWallet(context.Background(), wallet, "")
+		require.ErrorIs(t, err, client.ErrTooManyTransactions)
+		assert.Equal(t, 100, requests)
+	})
+}
+
+func TestValidateTransactionByReference_NoPayment(t *testing.T) {
+	var requests int
+	sc := newMockClient(t, map[string]interface{}{
+		"getSignaturesForAddress": signaturesPages(t, nil, &requests),
+	})
+
+	_, _, err := sc.ValidateTransactionByReference(context.Background(), sdktypes.NewAccount().PublicKey.ToBase58(), sdktypes.NewAccount().PublicKey.ToBase58(), 1000, 0, "")
+	require.ErrorIs(t, err, client.ErrNoTransactionsFound)
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/types"
+	"github.com/dmitrymomot/solana/utils"
+)
+
+// GetTransferFee returns the fee withheld by the Token-2022 program on transferring the given amount of the mint,
+// so the recipient receives amount minus the fee. The fee effective at the current epoch is used,
+// rounded up and clamped to the maximum fee of the mint.
+// Returns zero if the mint has no transfer fee extension, e.g. it's a classic SPL Token mint.
+func (c *Client) GetTransferFee(ctx context.Context, mint string, amount uint64) (uint64, error) {
+	acc, err := c.GetAccountInfo(ctx, mint, AccountInfoOptions{})
+	if err != nil {
+		return 0, utils.StackErrors(ErrGetTransferFee, err)
+	}
+	if acc.Owner != commonx.Token2022ProgramID {
+		return 0, nil
+	}
+
+	config, err := types.ParseTransferFeeConfig(acc.Data)
+	if err != nil {
+		return 0, utils.StackErrors(ErrGetTransferFee, err)
+	}
+	if config == nil {
+		return 0, nil
+	}
+
+	epoch, err := c.GetEpochInfo(ctx)
+	if err != nil {
+		return 0, utils.StackErrors(ErrGetTransferFee, err)
+	}
+
+	return config.Fee(epoch.Epoch, amount), nil
+}
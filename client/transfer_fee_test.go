@@ -0,0 +1,90 @@
+package client_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transferFeeMintData returns a Token-2022 mint account with the transfer fee config extension:
+// 1% up to 5000 before epoch 30 and 2% up to 5000 since then.
+func transferFeeMintData() map[string]interface{} {
+	data := make([]byte, 165+1+4+108)
+	data[44] = 6  // decimals
+	data[45] = 1  // is initialized
+	data[165] = 1 // account type: mint
+
+	binary.LittleEndian.PutUint16(data[166:168], 1) // extension type: transfer fee config
+	binary.LittleEndian.PutUint16(data[168:170], 108)
+	config := data[170:]
+	copy(config[0:32], types.NewAccount().PublicKey.Bytes())
+	binary.LittleEndian.PutUint64(config[80:88], 5000) // older maximum fee
+	binary.LittleEndian.PutUint16(config[88:90], 100)  // older basis points
+	binary.LittleEndian.PutUint64(config[90:98], 30)   // newer epoch
+	binary.LittleEndian.PutUint64(config[98:106], 5000)
+	binary.LittleEndian.PutUint16(config[106:108], 200)
+
+	acc := accountData(data)
+	acc["owner"] = commonx.Token2022ProgramID.ToBase58()
+	return acc
+}
+
+func TestGetTransferFee(t *testing.T) {
+	mint := types.NewAccount().PublicKey.ToBase58()
+	epochInfo := func(epoch uint64) map[string]interface{} {
+		return map[string]interface{}{"absoluteSlot": 1, "blockHeight": 1, "epoch": epoch, "slotIndex": 1, "slotsInEpoch": 8192}
+	}
+
+	tests := []struct {
+		name   string
+		epoch  uint64
+		amount uint64
+		want   uint64
+	}{
+		{"older fee", 29, 10_000, 100},
+		{"newer fee", 30, 10_000, 200},
+		{"rounded up", 30, 101, 3},
+		{"clamped to maximum fee", 30, 1_000_000, 5000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMockClient(t, map[string]interface{}{
+				"getAccountInfo": withContext(transferFeeMintData()),
+				"getEpochInfo":   epochInfo(tt.epoch),
+			})
+
+			fee, err := c.GetTransferFee(context.Background(), mint, tt.amount)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fee)
+		})
+	}
+
+	t.Run("classic mint", func(t *testing.T) {
+		acc := accountData(make([]byte, token.MintAccountSize))
+		acc["owner"] = common.TokenProgramID.ToBase58()
+		c := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(acc),
+		})
+
+		fee, err := c.GetTransferFee(context.Background(), mint, 10_000)
+		require.NoError(t, err)
+		assert.Zero(t, fee)
+	})
+
+	t.Run("mint not found", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(nil),
+		})
+
+		_, err := c.GetTransferFee(context.Background(), mint, 10_000)
+		require.ErrorIs(t, err, client.ErrGetTransferFee)
+	})
+}
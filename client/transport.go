@@ -0,0 +1,36 @@
+package client
+
+import "net/http"
+
+// headerTransport adds the headers to the requests sent through the base transport.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+// RoundTrip adds the headers to a copy of the request and sends it through the base transport.
+// Implements the http.RoundTripper interface.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// withHeaders returns a copy of the http client which adds the headers to the requests.
+// Returns the client itself if there are no headers.
+func withHeaders(httpClient *http.Client, headers map[string]string) *http.Client {
+	if len(headers) == 0 {
+		return httpClient
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	result := *httpClient
+	result.Transport = &headerTransport{base: base, headers: headers}
+
+	return &result
+}
@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	sdktypes "github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// capturingTransport records the outbound requests and responds with the given JSON-RPC result.
+type capturingTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	result   string
+}
+
+func (t *capturingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":` + t.result + `}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestSetRPCHeaders(t *testing.T) {
+	addr := sdktypes.NewAccount().PublicKey.ToBase58()
+
+	t.Run("custom http client and headers", func(t *testing.T) {
+		transport := &capturingTransport{result: `{"context":{"slot":1},"value":100}`}
+		c := client.New(
+			client.SetSolanaEndpoint("https://rpc.example.com/"),
+			client.SetHTTPClient(&http.Client{Transport: transport}),
+			client.SetRPCHeaders(map[string]string{"x-api-key": "secret"}),
+			client.SetRPCHeaders(map[string]string{"X-Client": "test"}),
+		)
+
+		balance, err := c.GetSOLBalance(context.Background(), addr)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, balance)
+
+		require.Len(t, transport.requests, 1)
+		req := transport.requests[0]
+		require.Equal(t, "https://rpc.example.com/", req.URL.String())
+		require.Equal(t, "secret", req.Header.Get("X-Api-Key"))
+		require.Equal(t, "test", req.Header.Get("X-Client"))
+		require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	})
+
+	t.Run("no headers", func(t *testing.T) {
+		transport := &capturingTransport{result: `{"context":{"slot":1},"value":100}`}
+		c := client.New(
+			client.SetSolanaEndpoint("https://rpc.example.com/"),
+			client.SetHTTPClient(&http.Client{Transport: transport}),
+		)
+
+		_, err := c.GetSOLBalance(context.Background(), addr)
+		require.NoError(t, err)
+		require.Len(t, transport.requests, 1)
+		require.Empty(t, transport.requests[0].Header.Get("X-Api-Key"))
+	})
+
+	t.Run("websocket handshake", func(t *testing.T) {
+		apiKeys := make(chan string, 1)
+		ws := newMockWSServer(t, func(conn *websocket.Conn) {
+			apiKeys <- conn.Request().Header.Get("X-Api-Key")
+
+			var req wsRequest
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				return
+			}
+			_ = websocket.JSON.Send(conn, map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": 1})
+		})
+		c := newMockClient(t, map[string]interface{}{}, ws, client.SetRPCHeaders(map[string]string{"x-api-key": "secret"}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := c.SubscribeLogs(ctx, addr)
+		require.NoError(t, err)
+		require.Equal(t, "secret", <-apiKeys)
+	})
+}
+
+func TestGetFungibleTokenMetadata_TokenListHTTPClient(t *testing.T) {
+	mintPubkey := sdktypes.NewAccount().PublicKey
+	mint := mintPubkey.ToBase58()
+	tokenList := fmt.Sprintf(`{"tokens":[{"chainId":101,"address":%q,"name":"Token","symbol":"TKN","logoURI":"https://example.com/logo.png"}]}`, mint)
+	account, err := json.Marshal(withContext(accountData(serializedMetadata(t, mintPubkey, "Token", metaplex_token_metadata.Fungible))))
+	require.NoError(t, err)
+
+	// the on-chain metadata has no image, so the token list is downloaded through the custom http client
+	var tokenListRequests int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"jsonrpc":"2.0","id":1,"result":` + string(account) + `}`
+		if req.URL.Host == "tokens.example.com" {
+			tokenListRequests++
+			body = tokenList
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	c := client.New(
+		client.SetSolanaEndpoint("https://rpc.example.com/"),
+		client.SetHTTPClient(&http.Client{Transport: transport}),
+		client.SetTokenListPath("https://tokens.example.com/tokenlist.json"),
+	)
+
+	md, err := c.GetFungibleTokenMetadata(context.Background(), mint)
+	require.NoError(t, err)
+	require.Equal(t, 1, tokenListRequests)
+	require.Equal(t, "Token", md.Name)
+	require.Equal(t, "TST", md.Symbol)
+	require.Equal(t, "https://example.com/logo.png", md.Image)
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
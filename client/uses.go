@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+)
+
+// Accounts of the token metadata ApproveUseAuthority instruction.
+const (
+	approveUseAuthorityUserIndex = 3
+	approveUseAuthorityMintIndex = 6
+)
+
+// UseAuthority is an approved use authority of the token, see GetUseAuthorities.
+type UseAuthority struct {
+	Authority   string `json:"authority"`    // base58 encoded use authority
+	Record      string `json:"record"`       // base58 encoded use authority record
+	AllowedUses uint64 `json:"allowed_uses"` // number of uses the authority is still allowed to utilize
+}
+
+// GetTokenUses returns the uses of the token with the given base58 encoded mint address:
+// the use method, the total and the remaining number of uses.
+// Returns nil if the token has no uses.
+// Unlike GetTokenMetadata, it reads only the metadata account, so the result is always up to date.
+func (c *Client) GetTokenUses(ctx context.Context, mint string) (*token_metadata.Uses, error) {
+	md, err := c.getOnChainMetadata(ctx, mint)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetTokenUses, err)
+	}
+	if md.Uses == nil {
+		return nil, nil
+	}
+
+	return &token_metadata.Uses{
+		UseMethod: token_metadata.CastMetadataUseMethod(md.Uses.UseMethod).String(),
+		Total:     md.Uses.Total,
+		Remaining: md.Uses.Remaining,
+	}, nil
+}
+
+// GetUseAuthorities returns the use authorities approved for the token with the given base58 encoded mint address,
+// whose use authority records still exist, i.e. they haven't been revoked.
+// The records store neither the mint nor the authority, so they can't be filtered with getProgramAccounts;
+// the authorities are collected from the ApproveUseAuthority instructions in the mint transaction history instead.
+// It takes a request per transaction of the mint, so it's heavy for the tokens with a long history.
+func (c *Client) GetUseAuthorities(ctx context.Context, mint string) ([]UseAuthority, error) {
+	if err := commonx.ValidateAccountAddr(mint); err != nil {
+		return nil, utils.StackErrors(ErrGetUseAuthorities, err)
+	}
+	mintPubkey := common.PublicKeyFromString(mint)
+
+	var (
+		authorities []common.PublicKey
+		seen        = make(map[common.PublicKey]struct{})
+	)
+	// stops the iteration if the transaction fetching fails
+	iterCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	signatures, errs := c.IterateSignatures(iterCtx, mint, IterateOptions{})
+	for sig := range signatures {
+		if sig.Err != nil {
+			continue
+		}
+		tx, err := c.GetTransaction(ctx, sig.Signature)
+		if err != nil {
+			return nil, utils.StackErrors(ErrGetUseAuthorities, err)
+		}
+		for _, user := range approvedUseAuthorities(tx, mintPubkey) {
+			if _, ok := seen[user]; !ok {
+				seen[user] = struct{}{}
+				authorities = append(authorities, user)
+			}
+		}
+	}
+	if err := <-errs; err != nil {
+		return nil, utils.StackErrors(ErrGetUseAuthorities, err)
+	}
+	if len(authorities) == 0 {
+		return []UseAuthority{}, nil
+	}
+
+	records := make([]string, 0, len(authorities))
+	for _, user := range authorities {
+		record, err := metaplex_token_metadata.GetUseAuthorityRecord(mintPubkey, user)
+		if err != nil {
+			return nil, utils.StackErrors(ErrGetUseAuthorities, err)
+		}
+		records = append(records, record.ToBase58())
+	}
+
+	accounts, err := c.getMultipleAccounts(ctx, records)
+	if err != nil {
+		return nil, utils.StackErrors(ErrGetUseAuthorities, err)
+	}
+
+	result := make([]UseAuthority, 0, len(accounts))
+	for i, acc := range accounts {
+		if len(acc.Data) == 0 || acc.Owner != common.MetaplexTokenMetaProgramID {
+			continue
+		}
+		var record metaplex_token_metadata.UseAuthorityRecord
+		if err := borsh.Deserialize(&record, acc.Data); err != nil || record.Key != metaplex_token_metadata.KeyUseAuthorityRecord {
+			continue
+		}
+		result = append(result, UseAuthority{
+			Authority:   authorities[i].ToBase58(),
+			Record:      records[i],
+			AllowedUses: record.AllowedUses,
+		})
+	}
+
+	return result, nil
+}
+
+// approvedUseAuthorities returns the users approved by the ApproveUseAuthority instructions of the transaction
+// for the given mint.
+func approvedUseAuthorities(tx *client.Transaction, mint common.PublicKey) []common.PublicKey {
+	msg := tx.Transaction.Message
+	accounts := append([]common.PublicKey{}, msg.Accounts...)
+	for _, addr := range append(tx.Meta.LoadedAddresses.Writable, tx.Meta.LoadedAddresses.Readonly...) {
+		accounts = append(accounts, common.PublicKeyFromString(addr))
+	}
+	account := func(idx int) (common.PublicKey, bool) {
+		if idx < 0 || idx >= len(accounts) {
+			return common.PublicKey{}, false
+		}
+		return accounts[idx], true
+	}
+
+	var users []common.PublicKey
+	for _, instr := range msg.Instructions {
+		if programID, ok := account(instr.ProgramIDIndex); !ok || programID != common.MetaplexTokenMetaProgramID {
+			continue
+		}
+		if len(instr.Data) == 0 || metaplex_token_metadata.Instruction(instr.Data[0]) != metaplex_token_metadata.InstructionApproveUseAuthority {
+			continue
+		}
+		if len(instr.Accounts) <= approveUseAuthorityMintIndex {
+			continue
+		}
+		if m, ok := account(instr.Accounts[approveUseAuthorityMintIndex]); !ok || m != mint {
+			continue
+		}
+		if user, ok := account(instr.Accounts[approveUseAuthorityUserIndex]); ok {
+			users = append(users, user)
+		}
+	}
+
+	return users
+}
@@ -0,0 +1,186 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTokenUses(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+
+	newClient := func(t *testing.T, uses *metaplex_token_metadata.Uses) *client.Client {
+		data, err := borsh.Serialize(metaplex_token_metadata.Metadata{
+			Key:             metaplex_token_metadata.KeyMetadataV1,
+			UpdateAuthority: types.NewAccount().PublicKey,
+			Mint:            mint,
+			Data:            metaplex_token_metadata.Data{Name: "NFT", Symbol: "NFT"},
+			Uses:            uses,
+		})
+		require.NoError(t, err)
+
+		return newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(accountData(data)),
+		})
+	}
+
+	t.Run("multiple uses", func(t *testing.T) {
+		c := newClient(t, &metaplex_token_metadata.Uses{
+			UseMethod: metaplex_token_metadata.Multiple,
+			Remaining: 8,
+			Total:     10,
+		})
+
+		uses, err := c.GetTokenUses(context.Background(), mint.ToBase58())
+		require.NoError(t, err)
+		assert.Equal(t, &token_metadata.Uses{
+			UseMethod: token_metadata.TokenUseMethodMulti.String(),
+			Total:     10,
+			Remaining: 8,
+		}, uses)
+	})
+
+	t.Run("without uses", func(t *testing.T) {
+		uses, err := newClient(t, nil).GetTokenUses(context.Background(), mint.ToBase58())
+		require.NoError(t, err)
+		assert.Nil(t, uses)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{
+			"getAccountInfo": withContext(nil),
+		})
+
+		_, err := c.GetTokenUses(context.Background(), mint.ToBase58())
+		require.ErrorIs(t, err, client.ErrGetTokenUses)
+		require.ErrorIs(t, err, client.ErrAccountNotFound)
+	})
+}
+
+func TestGetUseAuthorities(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount()
+	approved := types.NewAccount().PublicKey
+	revoked := types.NewAccount().PublicKey
+	otherMint := types.NewAccount().PublicKey
+
+	record := func(mint, user common.PublicKey) common.PublicKey {
+		pk, err := metaplex_token_metadata.GetUseAuthorityRecord(mint, user)
+		require.NoError(t, err)
+		return pk
+	}
+	approve := func(mint, user common.PublicKey) types.Instruction {
+		return metaplex_token_metadata.ApproveUseAuthority(metaplex_token_metadata.ApproveUseAuthorityParam{
+			UseAuthorityRecord: record(mint, user),
+			Owner:              owner.PublicKey,
+			Payer:              owner.PublicKey,
+			User:               user,
+			OwnerTokenAccount:  types.NewAccount().PublicKey,
+			Metadata:           types.NewAccount().PublicKey,
+			Mint:               mint,
+			Burner:             types.NewAccount().PublicKey,
+			NumberOfUses:       2,
+		})
+	}
+	encode := func(instructions ...types.Instruction) string {
+		tx, err := types.NewTransaction(types.NewTransactionParam{
+			Message: types.NewMessage(types.NewMessageParam{
+				FeePayer:        owner.PublicKey,
+				RecentBlockhash: types.NewAccount().PublicKey.ToBase58(),
+				Instructions:    instructions,
+			}),
+			Signers: []types.Account{owner},
+		})
+		require.NoError(t, err)
+		raw, err := utils.EncodeTransaction(tx)
+		require.NoError(t, err)
+		return raw
+	}
+
+	// newest first; the authority approved twice is returned once
+	transactions := map[string]string{
+		"sig3": encode(approve(mint, approved)),
+		"sig2": encode(approve(otherMint, types.NewAccount().PublicKey), approve(mint, revoked)),
+		"sig1": encode(approve(mint, approved)),
+	}
+	signatures := []string{"sig3", "sig2", "sig1"}
+
+	recordData, err := borsh.Serialize(metaplex_token_metadata.UseAuthorityRecord{
+		Key:         metaplex_token_metadata.KeyUseAuthorityRecord,
+		AllowedUses: 2,
+		Bump:        utils.Pointer(uint8(255)),
+	})
+	require.NoError(t, err)
+	records := map[string][]byte{record(mint, approved).ToBase58(): recordData}
+
+	var requests int
+	c := newMockClient(t, map[string]interface{}{
+		"getSignaturesForAddress": signaturesPages(t, signatures, &requests),
+		"getTransaction": func(params []json.RawMessage) interface{} {
+			var sig string
+			require.NoError(t, json.Unmarshal(params[0], &sig))
+			return map[string]interface{}{
+				"slot":        1,
+				"transaction": []string{transactions[sig], "base64"},
+				"meta":        rpc.TransactionMeta{},
+			}
+		},
+		"getMultipleAccounts": func(params []json.RawMessage) interface{} {
+			var addrs []string
+			require.NoError(t, json.Unmarshal(params[0], &addrs))
+			assert.Equal(t, []string{record(mint, approved).ToBase58(), record(mint, revoked).ToBase58()}, addrs)
+
+			value := make([]interface{}, 0, len(addrs))
+			for _, addr := range addrs {
+				data, ok := records[addr]
+				if !ok {
+					value = append(value, nil)
+					continue
+				}
+				value = append(value, map[string]interface{}{
+					"lamports":   1_016_880,
+					"owner":      common.MetaplexTokenMetaProgramID.ToBase58(),
+					"executable": false,
+					"rentEpoch":  0,
+					"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+				})
+			}
+			return withContext(value)
+		},
+	})
+
+	authorities, err := c.GetUseAuthorities(context.Background(), mint.ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, []client.UseAuthority{{
+		Authority:   approved.ToBase58(),
+		Record:      record(mint, approved).ToBase58(),
+		AllowedUses: 2,
+	}}, authorities)
+
+	t.Run("no approvals", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{
+			"getSignaturesForAddress": signaturesPages(t, nil, &requests),
+		})
+
+		authorities, err := c.GetUseAuthorities(context.Background(), mint.ToBase58())
+		require.NoError(t, err)
+		assert.Empty(t, authorities)
+	})
+
+	t.Run("invalid mint", func(t *testing.T) {
+		_, err := newMockClient(t, nil).GetUseAuthorities(context.Background(), "invalid")
+		require.ErrorIs(t, err, client.ErrGetUseAuthorities)
+	})
+}
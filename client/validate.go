@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"strconv"
 
+	commonx "github.com/dmitrymomot/solana/common"
 	"github.com/dmitrymomot/solana/utils"
 	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
 	"github.com/portto/solana-go-sdk/types"
 )
 
@@ -36,33 +39,73 @@ func SignTransaction(txSource string, signer types.Account) (string, error) {
 // CheckSolTransferTransaction checks if a transaction is a SOL transfer transaction.
 // Verifies that destination account has been credited with the correct amount.
 func CheckSolTransferTransaction(meta *client.TransactionMeta, tx types.Transaction, destination string, amount uint64) error {
-	var destIdx int
+	received, err := solTransferAmount(meta, tx, destination)
+	if err != nil {
+		return err
+	}
+	if received != amount {
+		return fmt.Errorf("amount is not equal to the amount in the transaction: %d != %d", amount, received)
+	}
+
+	return nil
+}
+
+// solTransferAmount returns the amount of lamports the destination account has been credited with.
+// Returns zero if the destination balance didn't increase.
+func solTransferAmount(meta *client.TransactionMeta, tx types.Transaction, destination string) (uint64, error) {
+	destIdx := -1
 	for i, acc := range tx.Message.Accounts {
 		if acc.ToBase58() == destination {
 			destIdx = i
 			break
 		}
 	}
+	if destIdx < 0 || destIdx >= len(meta.PreBalances) || destIdx >= len(meta.PostBalances) {
+		return 0, fmt.Errorf("destination account %s is not found in the transaction", destination)
+	}
 
-	txAmount := meta.PostBalances[destIdx] - meta.PreBalances[destIdx]
-	if txAmount != int64(amount) {
-		return fmt.Errorf("amount is not equal to the amount in the transaction: %d != %d", amount, txAmount)
+	pre, post := meta.PreBalances[destIdx], meta.PostBalances[destIdx]
+	if post <= pre {
+		return 0, nil
 	}
 
-	return nil
+	return uint64(post - pre), nil
 }
 
 // CheckTokenTransferTransaction checks if a transaction is a token transfer transaction.
 // Verifies that destination account has been credited with the correct amount of the token.
+// Transfers made by either the SPL Token or the Token-2022 program are accepted.
 func CheckTokenTransferTransaction(meta *client.TransactionMeta, tx types.Transaction, mint, destination string, amount uint64) error {
+	return CheckTokenProgramTransferTransaction(meta, tx, common.PublicKey{}, mint, destination, amount)
+}
+
+// CheckTokenProgramTransferTransaction checks if a transaction is a token transfer transaction
+// made by the given token program (SPL Token or Token-2022).
+// Verifies that destination account has been credited with the correct amount of the token.
+// If tokenProgramID is empty, balances of any token program are matched.
+func CheckTokenProgramTransferTransaction(meta *client.TransactionMeta, tx types.Transaction, tokenProgramID common.PublicKey, mint, destination string, amount uint64) error {
+	received, err := tokenTransferAmount(meta, tokenProgramID, mint, destination)
+	if err != nil {
+		return err
+	}
+	if received != amount {
+		return fmt.Errorf("amount is not equal to the amount in the transaction: %d != %d", amount, received)
+	}
+
+	return nil
+}
+
+// tokenTransferAmount returns the amount of the token the destination wallet has been credited with
+// by the given token program. Returns zero if the destination balance didn't increase.
+func tokenTransferAmount(meta *client.TransactionMeta, tokenProgramID common.PublicKey, mint, destination string) (uint64, error) {
 	var preBalance uint64
 	var postBalance uint64
 
 	for _, balance := range meta.PreTokenBalances {
-		if balance.Mint == mint && balance.Owner == destination {
+		if isTokenBalanceMatch(balance, tokenProgramID, mint, destination) {
 			amount, err := strconv.ParseUint(balance.UITokenAmount.Amount, 10, 64)
 			if err != nil {
-				return fmt.Errorf("failed to parse pre balance: %w", err)
+				return 0, fmt.Errorf("failed to parse pre balance: %w", err)
 			}
 			preBalance = amount
 			break
@@ -70,19 +113,52 @@ func CheckTokenTransferTransaction(meta *client.TransactionMeta, tx types.Transa
 	}
 
 	for _, balance := range meta.PostTokenBalances {
-		if balance.Mint == mint && balance.Owner == destination {
+		if isTokenBalanceMatch(balance, tokenProgramID, mint, destination) {
 			amount, err := strconv.ParseUint(balance.UITokenAmount.Amount, 10, 64)
 			if err != nil {
-				return fmt.Errorf("failed to parse post balance: %w", err)
+				return 0, fmt.Errorf("failed to parse post balance: %w", err)
 			}
 			postBalance = amount
 			break
 		}
 	}
 
-	if postBalance-preBalance != amount {
-		return fmt.Errorf("amount is not equal to the amount in the transaction: %d != %d", amount, postBalance-preBalance)
+	if postBalance <= preBalance {
+		return 0, nil
+	}
+
+	return postBalance - preBalance, nil
+}
+
+// checkTransferAmount checks that the received amount is at least the expected amount minus the tolerance.
+// Overpayments are accepted.
+func checkTransferAmount(received, amount, amountTolerance uint64) error {
+	min := uint64(0)
+	if amount > amountTolerance {
+		min = amount - amountTolerance
+	}
+	if received < min {
+		return fmt.Errorf("amount in the transaction is less than the expected amount: %d < %d", received, amount)
 	}
 
 	return nil
 }
+
+// isTokenBalanceMatch returns true if the given token balance belongs to the destination wallet
+// and the given mint, and was changed by the expected token program.
+// Older RPC nodes do not return the program id, so an empty one is accepted.
+func isTokenBalanceMatch(balance rpc.TransactionMetaTokenBalance, tokenProgramID common.PublicKey, mint, destination string) bool {
+	if balance.Mint != mint || balance.Owner != destination {
+		return false
+	}
+	if balance.ProgramId == "" {
+		return true
+	}
+
+	programID := common.PublicKeyFromString(balance.ProgramId)
+	if tokenProgramID == (common.PublicKey{}) {
+		return commonx.IsTokenProgramID(programID)
+	}
+
+	return programID == tokenProgramID
+}
@@ -0,0 +1,135 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/solana/client"
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/utils"
+	sdkclient "github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/memo"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func tokenBalance(programID common.PublicKey, mint, owner, amount string) rpc.TransactionMetaTokenBalance {
+	return rpc.TransactionMetaTokenBalance{
+		AccountIndex:  1,
+		Mint:          mint,
+		Owner:         owner,
+		ProgramId:     programID.ToBase58(),
+		UITokenAmount: rpc.TokenAccountBalance{Amount: amount, Decimals: 6},
+	}
+}
+
+func TestCheckTokenTransferTransaction_Token2022(t *testing.T) {
+	mint := types.NewAccount().PublicKey.ToBase58()
+	destination := types.NewAccount().PublicKey.ToBase58()
+
+	meta := &sdkclient.TransactionMeta{
+		PreTokenBalances:  []rpc.TransactionMetaTokenBalance{tokenBalance(commonx.Token2022ProgramID, mint, destination, "100")},
+		PostTokenBalances: []rpc.TransactionMetaTokenBalance{tokenBalance(commonx.Token2022ProgramID, mint, destination, "600")},
+	}
+
+	require.NoError(t, client.CheckTokenTransferTransaction(meta, types.Transaction{}, mint, destination, 500))
+	require.NoError(t, client.CheckTokenProgramTransferTransaction(meta, types.Transaction{}, commonx.Token2022ProgramID, mint, destination, 500))
+	require.Error(t, client.CheckTokenProgramTransferTransaction(meta, types.Transaction{}, common.TokenProgramID, mint, destination, 500))
+	require.Error(t, client.CheckTokenTransferTransaction(meta, types.Transaction{}, mint, destination, 400))
+}
+
+func TestCheckTokenTransferTransaction_SPLToken(t *testing.T) {
+	mint := types.NewAccount().PublicKey.ToBase58()
+	destination := types.NewAccount().PublicKey.ToBase58()
+
+	meta := &sdkclient.TransactionMeta{
+		PostTokenBalances: []rpc.TransactionMetaTokenBalance{tokenBalance(common.TokenProgramID, mint, destination, "42")},
+	}
+
+	require.NoError(t, client.CheckTokenTransferTransaction(meta, types.Transaction{}, mint, destination, 42))
+	require.NoError(t, client.CheckTokenProgramTransferTransaction(meta, types.Transaction{}, common.TokenProgramID, mint, destination, 42))
+	require.Error(t, client.CheckTokenProgramTransferTransaction(meta, types.Transaction{}, commonx.Token2022ProgramID, mint, destination, 42))
+}
+
+func TestValidateTransactionByReference_Token2022(t *testing.T) {
+	payer := types.NewAccount()
+	reference := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey.ToBase58()
+	destination := types.NewAccount().PublicKey.ToBase58()
+
+	instr := memo.BuildMemo(memo.BuildMemoParam{Memo: []byte("payment")})
+	instr.Accounts = append(instr.Accounts, types.AccountMeta{PubKey: reference})
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        payer.PublicKey,
+			RecentBlockhash: types.NewAccount().PublicKey.ToBase58(),
+			Instructions:    []types.Instruction{instr},
+		}),
+		Signers: []types.Account{payer},
+	})
+	require.NoError(t, err)
+	rawTx, err := utils.EncodeTransaction(tx)
+	require.NoError(t, err)
+
+	signature := "5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv"
+	blockTime := time.Now().Add(-time.Minute).Unix()
+
+	sc := newMockClient(t, map[string]interface{}{
+		"getSignaturesForAddress": []rpc.SignatureWithStatus{{
+			Signature: signature,
+			Slot:      1,
+			BlockTime: &blockTime,
+		}},
+		"getTransaction": map[string]interface{}{
+			"slot":        1,
+			"blockTime":   blockTime,
+			"transaction": []string{rawTx, "base64"},
+			"meta": rpc.TransactionMeta{
+				Fee:               5000,
+				PreBalances:       []int64{10000, 0, 1},
+				PostBalances:      []int64{5000, 0, 1},
+				PreTokenBalances:  []rpc.TransactionMetaTokenBalance{},
+				PostTokenBalances: []rpc.TransactionMetaTokenBalance{tokenBalance(commonx.Token2022ProgramID, mint, destination, "1000000")},
+			},
+		},
+		"getAccountInfo": map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value": map[string]interface{}{
+				"lamports":   1461600,
+				"owner":      commonx.Token2022ProgramID.ToBase58(),
+				"executable": false,
+				"rentEpoch":  0,
+				"data":       []string{"", "base64"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name      string
+		amount    uint64
+		tolerance uint64
+		wantErr   bool
+	}{
+		{name: "exact payment", amount: 1000000},
+		{name: "overpayment", amount: 999000},
+		{name: "underpayment", amount: 1000001, wantErr: true},
+		{name: "underpayment within tolerance", amount: 1000500, tolerance: 500},
+		{name: "underpayment beyond tolerance", amount: 1000501, tolerance: 500, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txSign, received, err := sc.ValidateTransactionByReference(context.Background(), reference.ToBase58(), destination, tt.amount, tt.tolerance, mint)
+			// the actual amount is returned even if the payment is insufficient
+			require.Equal(t, uint64(1000000), received)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, signature, txSign)
+		})
+	}
+}
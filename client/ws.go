@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/portto/solana-go-sdk/rpc"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// wsReconnectDelay is the delay before the first attempt to restore the dropped subscription,
+	// it's doubled after each failed attempt up to wsMaxReconnectDelay.
+	wsReconnectDelay    = time.Second
+	wsMaxReconnectDelay = 30 * time.Second
+)
+
+// SetWSEndpoint sets the websocket endpoint of the solana RPC node, which is required by the subscriptions,
+// e.g. WatchBalance. It's usually the RPC endpoint with the ws or wss scheme,
+// e.g. wss://api.mainnet-beta.solana.com; the local validator listens on ws://localhost:8900.
+func SetWSEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		c.wsEndpoint = endpoint
+	}
+}
+
+// subscribe opens a websocket connection to the node, subscribes with the given method and params,
+// and sends the results of the subscription notifications to the returned channel.
+// The connection is closed, so the node drops the subscription, when the context is done;
+// the channel is closed when the context is done or the connection fails.
+func subscribe[T any](ctx context.Context, c *Client, method string, params ...interface{}) (<-chan T, error) {
+	if c.wsEndpoint == "" {
+		return nil, ErrWSEndpointNotSet
+	}
+
+	cfg, err := websocket.NewConfig(c.wsEndpoint, wsOrigin(c.wsEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid websocket endpoint: %w", method, err)
+	}
+	for key, value := range c.rpcHeaders {
+		cfg.Header.Set(key, value)
+	}
+	conn, err := websocket.DialConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to connect: %w", method, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	if err := websocket.JSON.Send(conn, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	}); err != nil {
+		cancel()
+		return nil, fmt.Errorf("%s: failed to subscribe: %w", method, err)
+	}
+
+	var resp rpc.JsonRpcResponse[uint64]
+	if err := websocket.JSON.Receive(conn, &resp); err != nil {
+		cancel()
+		return nil, fmt.Errorf("%s: failed to decode response: %w", method, err)
+	}
+	if resp.Error != nil {
+		cancel()
+		return nil, resp.Error
+	}
+	subscription := resp.Result
+
+	results := make(chan T)
+	go func() {
+		defer close(results)
+		defer cancel()
+
+		for {
+			var notification struct {
+				Params struct {
+					Result       json.RawMessage `json:"result"`
+					Subscription uint64          `json:"subscription"`
+				} `json:"params"`
+			}
+			if err := websocket.JSON.Receive(conn, &notification); err != nil {
+				return
+			}
+			if notification.Params.Subscription != subscription {
+				continue
+			}
+
+			var result T
+			if err := json.Unmarshal(notification.Params.Result, &result); err != nil {
+				return
+			}
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+// resubscribe is the same as subscribe, but it restores the subscription when the connection fails,
+// retrying with the exponential backoff. The channel is closed only when the context is done.
+// Notifications sent while the connection is down are missed.
+func resubscribe[T any](ctx context.Context, c *Client, method string, params ...interface{}) (<-chan T, error) {
+	sub, err := subscribe[T](ctx, c, method, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan T)
+	go func() {
+		defer close(results)
+
+		for {
+			for result := range sub {
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			for delay := wsReconnectDelay; ; delay *= 2 {
+				if delay > wsMaxReconnectDelay {
+					delay = wsMaxReconnectDelay
+				}
+				select {
+				case <-c.clock.After(delay):
+				case <-ctx.Done():
+					return
+				}
+				restored, err := subscribe[T](ctx, c, method, params...)
+				if err == nil {
+					sub = restored
+					break
+				}
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+// wsOrigin returns the origin of the websocket handshake for the given websocket endpoint.
+func wsOrigin(endpoint string) string {
+	origin := strings.Replace(endpoint, "wss://", "https://", 1)
+	return strings.Replace(origin, "ws://", "http://", 1)
+}
@@ -0,0 +1,160 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/solana/client"
+	sdktypes "github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// wsRequest is the subscription request received by the mock websocket server.
+type wsRequest struct {
+	ID     uint64            `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// newMockWSServer starts the websocket server handling the connections with the given handler
+// and returns the option setting its endpoint.
+func newMockWSServer(t *testing.T, handler func(conn *websocket.Conn)) client.ClientOption {
+	t.Helper()
+
+	srv := httptest.NewServer(websocket.Handler(handler))
+	t.Cleanup(srv.Close)
+
+	return client.SetWSEndpoint("ws://" + srv.Listener.Addr().String())
+}
+
+// accountNotification returns the account subscription notification with the given lamports.
+func accountNotification(subscription, lamports uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "accountNotification",
+		"params": map[string]interface{}{
+			"subscription": subscription,
+			"result":       withContext(map[string]interface{}{"lamports": lamports}),
+		},
+	}
+}
+
+func TestWatchBalance(t *testing.T) {
+	addr := sdktypes.NewAccount().PublicKey.ToBase58()
+
+	t.Run("initial balance and changes", func(t *testing.T) {
+		requests := make(chan wsRequest, 1)
+		ws := newMockWSServer(t, func(conn *websocket.Conn) {
+			var req wsRequest
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				return
+			}
+			requests <- req
+			_ = websocket.JSON.Send(conn, map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": 42})
+
+			_ = websocket.JSON.Send(conn, accountNotification(7, 1_000_000_000))  // another subscription
+			_ = websocket.JSON.Send(conn, accountNotification(42, 1_000_000_000)) // unchanged
+			_ = websocket.JSON.Send(conn, accountNotification(42, 1_500_000_000))
+			_ = websocket.JSON.Send(conn, accountNotification(42, 500_000_000))
+
+			// keep the connection open until the client closes it
+			var discard json.RawMessage
+			_ = websocket.JSON.Receive(conn, &discard)
+		})
+		c := newMockClient(t, map[string]interface{}{
+			"getBalance": withContext(1_000_000_000),
+		}, ws)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		balances, err := c.WatchBalance(ctx, addr)
+		require.NoError(t, err)
+
+		req := <-requests
+		require.Equal(t, "accountSubscribe", req.Method)
+		require.Len(t, req.Params, 2)
+		require.JSONEq(t, `"`+addr+`"`, string(req.Params[0]))
+		require.JSONEq(t, `{"encoding":"base64"}`, string(req.Params[1]))
+
+		for _, expected := range []uint64{1_000_000_000, 1_500_000_000, 500_000_000} {
+			select {
+			case balance := <-balances:
+				require.EqualValues(t, expected, balance.Amount)
+				require.EqualValues(t, 9, balance.Decimals)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for the balance")
+			}
+		}
+
+		cancel()
+		select {
+		case _, ok := <-balances:
+			require.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the channel isn't closed after the context is done")
+		}
+	})
+
+	t.Run("closed connection", func(t *testing.T) {
+		ws := newMockWSServer(t, func(conn *websocket.Conn) {
+			var req wsRequest
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				return
+			}
+			_ = websocket.JSON.Send(conn, map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": 1})
+		})
+		c := newMockClient(t, map[string]interface{}{
+			"getBalance": withContext(100),
+		}, ws)
+
+		balances, err := c.WatchBalance(context.Background(), addr)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, (<-balances).Amount)
+
+		select {
+		case _, ok := <-balances:
+			require.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the channel isn't closed after the connection is closed")
+		}
+	})
+
+	t.Run("subscription error", func(t *testing.T) {
+		ws := newMockWSServer(t, func(conn *websocket.Conn) {
+			var req wsRequest
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				return
+			}
+			_ = websocket.JSON.Send(conn, map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"error":   map[string]interface{}{"code": -32602, "message": "Invalid params"},
+			})
+		})
+		c := newMockClient(t, map[string]interface{}{}, ws)
+
+		_, err := c.WatchBalance(context.Background(), addr)
+		require.ErrorIs(t, err, client.ErrWatchBalance)
+		require.ErrorContains(t, err, "Invalid params")
+	})
+
+	t.Run("endpoint not set", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{})
+
+		_, err := c.WatchBalance(context.Background(), addr)
+		require.ErrorIs(t, err, client.ErrWatchBalance)
+		require.ErrorIs(t, err, client.ErrWSEndpointNotSet)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		c := newMockClient(t, map[string]interface{}{})
+
+		_, err := c.WatchBalance(context.Background(), "invalid")
+		require.ErrorIs(t, err, client.ErrWatchBalance)
+	})
+}
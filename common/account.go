@@ -1,7 +1,12 @@
 package common
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"filippo.io/edwards25519"
 	"github.com/dmitrymomot/solana/utils"
@@ -18,6 +23,10 @@ const (
 	MnemonicLength24 MnemonicLength = 256 // 256 bits of entropy
 )
 
+// Bip44PathTemplate is the derivation path template used by the BIP44 helpers (phantom wallet),
+// where %d is the account index.
+const Bip44PathTemplate = "m/44'/501'/%d'/0'"
+
 // Mnemonic length type
 type MnemonicLength int
 
@@ -36,9 +45,35 @@ func NewMnemonic(len MnemonicLength) (string, error) {
 	return mnemonic, nil
 }
 
+// ValidateMnemonic validates a BIP39 mnemonic phrase:
+// the number of words, the words themselves (english word list) and the checksum.
+// Returns an error if the mnemonic is invalid, nil otherwise.
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	if n := len(words); n%3 != 0 || n < 12 || n > 24 {
+		return utils.StackErrors(ErrInvalidMnemonic, ErrInvalidMnemonicWordCount, fmt.Errorf("got %d words", n))
+	}
+
+	for i, word := range words {
+		if _, ok := bip39.GetWordIndex(word); !ok {
+			return utils.StackErrors(ErrInvalidMnemonic, ErrInvalidMnemonicWord, fmt.Errorf("word #%d %q", i+1, word))
+		}
+	}
+
+	if _, err := bip39.EntropyFromMnemonic(mnemonic); err != nil {
+		return utils.StackErrors(ErrInvalidMnemonic, ErrInvalidMnemonicChecksum)
+	}
+
+	return nil
+}
+
 // DeriveAccountFromMnemonicBip44 derives an Solana account from a mnemonic phrase
 // Compatible with BIP44 (phantom wallet)
 func DeriveAccountFromMnemonicBip44(mnemonic string) (types.Account, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return types.Account{}, utils.StackErrors(ErrDeriveAccountFromMnemonicBip44, err)
+	}
+
 	acc, err := deriveFromMnemonicBip44(mnemonic, 0)
 	if err != nil {
 		return types.Account{}, utils.StackErrors(ErrDeriveAccountFromMnemonicBip44, err)
@@ -50,6 +85,10 @@ func DeriveAccountFromMnemonicBip44(mnemonic string) (types.Account, error) {
 // DeriveAccountsListFromMnemonicBip44 derives a list of Solana accounts from a mnemonic phrase
 // Compatible with BIP44 (phantom wallet)
 func DeriveAccountsListFromMnemonicBip44(mnemonic string, count int) ([]types.Account, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, utils.StackErrors(ErrDeriveAccountsListFromMnemonicBip44, err)
+	}
+
 	accounts := make([]types.Account, count)
 
 	for i := 0; i < count; i++ {
@@ -64,9 +103,59 @@ func DeriveAccountsListFromMnemonicBip44(mnemonic string, count int) ([]types.Ac
 	return accounts, nil
 }
 
+// DeriveAccountFromPath derives an Solana account from a mnemonic phrase
+// using an arbitrary derivation path, e.g. "m/44'/501'/0'/0'" (phantom wallet)
+// or "m/44'/501'/0'/0'/1'".
+// Solana keys are ed25519 (SLIP-0010), so every path segment must be hardened.
+func DeriveAccountFromPath(mnemonic, path string) (types.Account, error) {
+	if err := ValidateDerivationPath(path); err != nil {
+		return types.Account{}, utils.StackErrors(ErrDeriveAccountFromPath, err)
+	}
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return types.Account{}, utils.StackErrors(ErrDeriveAccountFromPath, err)
+	}
+
+	acc, err := deriveFromPath(mnemonic, path)
+	if err != nil {
+		return types.Account{}, utils.StackErrors(ErrDeriveAccountFromPath, err)
+	}
+
+	return acc, nil
+}
+
+// ValidateDerivationPath validates the format of a derivation path:
+// it must start with "m" followed by at least one hardened index, e.g. "m/44'/501'/0'/0'".
+// Each index must be less than 2^31.
+// Returns an error if the path is invalid, nil otherwise.
+func ValidateDerivationPath(path string) error {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" || len(segments) < 2 {
+		return utils.StackErrors(ErrInvalidDerivationPath, fmt.Errorf("path %q must start with \"m/\"", path))
+	}
+
+	for _, segment := range segments[1:] {
+		if !strings.HasSuffix(segment, "'") {
+			return utils.StackErrors(ErrInvalidDerivationPath, ErrNonHardenedDerivationPath, fmt.Errorf("segment %q", segment))
+		}
+		index := strings.TrimSuffix(segment, "'")
+		if index == "" || strings.TrimLeft(index, "0123456789") != "" {
+			return utils.StackErrors(ErrInvalidDerivationPath, fmt.Errorf("segment %q is not a number", segment))
+		}
+		if _, err := strconv.ParseUint(index, 10, 31); err != nil {
+			return utils.StackErrors(ErrInvalidDerivationPath, fmt.Errorf("segment %q is out of range", segment))
+		}
+	}
+
+	return nil
+}
+
 // DeriveAccountFromMnemonicBip39 derives an Solana account from a mnemonic phrase
 // Compatible with BIP39 (solana cli tool)
 func DeriveAccountFromMnemonicBip39(mnemonic string) (types.Account, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return types.Account{}, utils.StackErrors(ErrDeriveAccountFromMnemonicBip39, err)
+	}
+
 	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
 	if err != nil {
 		return types.Account{}, utils.StackErrors(ErrDeriveAccountFromMnemonicBip39, ErrCreateBip39SeedFromMnemonic, err)
@@ -105,6 +194,53 @@ func AccountFromBase58(s string) (types.Account, error) {
 	return types.AccountFromBytes(b)
 }
 
+// AccountToCLIJSON converts an Solana account to the Solana CLI keypair format,
+// which is a JSON array of 64 bytes (private key followed by public key).
+func AccountToCLIJSON(a types.Account) ([]byte, error) {
+	if len(a.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, utils.StackErrors(ErrEncodeAccountToCLIJSON, ErrInvalidCLIKeypairLength)
+	}
+
+	keypair := make([]uint16, 0, ed25519.PrivateKeySize)
+	for _, b := range a.PrivateKey {
+		keypair = append(keypair, uint16(b))
+	}
+
+	result, err := json.Marshal(keypair)
+	if err != nil {
+		return nil, utils.StackErrors(ErrEncodeAccountToCLIJSON, err)
+	}
+
+	return result, nil
+}
+
+// AccountFromCLIJSON creates an Solana account from the Solana CLI keypair format,
+// e.g. a keypair file generated by solana-keygen.
+func AccountFromCLIJSON(data []byte) (types.Account, error) {
+	var keypair []uint16
+	if err := json.Unmarshal(data, &keypair); err != nil {
+		return types.Account{}, utils.StackErrors(ErrDecodeCLIJSONToAccount, err)
+	}
+
+	if len(keypair) != ed25519.PrivateKeySize {
+		return types.Account{}, utils.StackErrors(ErrDecodeCLIJSONToAccount, ErrInvalidCLIKeypairLength)
+	}
+
+	key := make([]byte, 0, ed25519.PrivateKeySize)
+	for _, v := range keypair {
+		if v > 255 {
+			return types.Account{}, utils.StackErrors(ErrDecodeCLIJSONToAccount, fmt.Errorf("invalid byte value: %d", v))
+		}
+		key = append(key, byte(v))
+	}
+
+	if !bytes.Equal(ed25519.NewKeyFromSeed(key[:ed25519.SeedSize]), key) {
+		return types.Account{}, utils.StackErrors(ErrDecodeCLIJSONToAccount, ErrInvalidPublicKey)
+	}
+
+	return types.AccountFromBytes(key)
+}
+
 // AccountFromString creates an Solana account from a base58 encoded string.
 // Alias for AccountFromBase58
 func AccountFromString(s string) (types.Account, error) {
@@ -126,6 +262,22 @@ func PublicKeyFromString(s string) common.PublicKey {
 // ValidateSolanaWalletAddr validates a Solana wallet address.
 // Returns an error if the address is invalid, nil otherwise.
 func ValidateSolanaWalletAddr(addr string) error {
+	if err := ValidateAccountAddr(addr); err != nil {
+		return err
+	}
+
+	d, _ := base58.Decode(addr) // already validated
+	if _, err := new(edwards25519.Point).SetBytes(d); err != nil {
+		return utils.StackErrors(ErrInvalidPublicKey, err)
+	}
+
+	return nil
+}
+
+// ValidateAccountAddr validates a Solana account address.
+// Unlike ValidateSolanaWalletAddr, it accepts program derived addresses, which are off the ed25519 curve.
+// Returns an error if the address is invalid, nil otherwise.
+func ValidateAccountAddr(addr string) error {
 	if addr == "" {
 		return ErrInvalidWalletAddress
 	}
@@ -139,10 +291,6 @@ func ValidateSolanaWalletAddr(addr string) error {
 		return ErrInvalidPublicKeyLength
 	}
 
-	if _, err := new(edwards25519.Point).SetBytes(d); err != nil {
-		return utils.StackErrors(ErrInvalidPublicKey, err)
-	}
-
 	return nil
 }
 
@@ -169,6 +317,21 @@ func DeriveTokenAccountPubkey(wallet, mint common.PublicKey) (common.PublicKey,
 	return ata, nil
 }
 
+// DeriveTokenAccountPubkeyWithProgram derives the associated token account of the wallet for the mint
+// owned by the given token program, e.g. the Token-2022 program.
+// The token program is one of the seeds, so the address differs from DeriveTokenAccountPubkey for Token-2022 mints.
+func DeriveTokenAccountPubkeyWithProgram(wallet, mint, tokenProgram common.PublicKey) (common.PublicKey, error) {
+	ata, _, err := common.FindProgramAddress(
+		[][]byte{wallet.Bytes(), tokenProgram.Bytes(), mint.Bytes()},
+		common.SPLAssociatedTokenAccountProgramID,
+	)
+	if err != nil {
+		return common.PublicKey{}, utils.StackErrors(ErrDeriveTokenAccount, err)
+	}
+
+	return ata, nil
+}
+
 // DeriveTokenLockAccount derives an associated token holder account from a Solana account and a mint address.
 func DeriveTokenLockAccount(walletAddress, tokenMintAddress common.PublicKey) (common.PublicKey, error) {
 	seeds := [][]byte{}
@@ -184,12 +347,18 @@ func DeriveTokenLockAccount(walletAddress, tokenMintAddress common.PublicKey) (c
 // deriveFromMnemonicBip44 derives an Solana account from a mnemonic phrase
 // Compatible with BIP44 (phantom wallet)
 func deriveFromMnemonicBip44(mnemonic string, path int) (types.Account, error) {
+	return deriveFromPath(mnemonic, fmt.Sprintf(Bip44PathTemplate, path))
+}
+
+// deriveFromPath derives an Solana account from a mnemonic phrase using the given derivation path.
+// The path must be validated before calling this function.
+func deriveFromPath(mnemonic, path string) (types.Account, error) {
 	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
 	if err != nil {
 		return types.Account{}, utils.StackErrors(ErrCreateBip39SeedFromMnemonic, err)
 	}
 
-	derivedKey, err := hdwallet.Derived(fmt.Sprintf("m/44'/501'/%d'/0'", path), seed)
+	derivedKey, err := hdwallet.Derived(path, seed)
 	if err != nil {
 		return types.Account{}, utils.StackErrors(ErrDeriveKeyFromSeed, err)
 	}
@@ -3,9 +3,12 @@ package common_test
 import (
 	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/dmitrymomot/solana/common"
+	sdkcommon "github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/types"
 	"github.com/stretchr/testify/require"
 )
@@ -150,3 +153,185 @@ func TestValidateSolanaWalletAddr(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAccountAddr(t *testing.T) {
+	// bonfida.sol name account, a program derived address
+	pda := "Crf8hzfthWGbGbLTVCiqRqV5MVnbpHB1L9KQMd6gsinb"
+	require.NoError(t, common.ValidateAccountAddr(pda))
+	require.Error(t, common.ValidateSolanaWalletAddr(pda))
+
+	require.NoError(t, common.ValidateAccountAddr(types.NewAccount().PublicKey.ToBase58()))
+	require.ErrorIs(t, common.ValidateAccountAddr(""), common.ErrInvalidWalletAddress)
+	require.ErrorIs(t, common.ValidateAccountAddr("invalid"), common.ErrInvalidPublicKey)
+	require.ErrorIs(t, common.ValidateAccountAddr("3yZe7d"), common.ErrInvalidPublicKeyLength)
+}
+
+func TestDeriveTokenAccountPubkeyWithProgram(t *testing.T) {
+	wallet := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+
+	classic, err := common.DeriveTokenAccountPubkeyWithProgram(wallet, mint, sdkcommon.TokenProgramID)
+	require.NoError(t, err)
+	expected, err := common.DeriveTokenAccountPubkey(wallet, mint)
+	require.NoError(t, err)
+	require.Equal(t, expected, classic)
+
+	token2022, err := common.DeriveTokenAccountPubkeyWithProgram(wallet, mint, common.Token2022ProgramID)
+	require.NoError(t, err)
+	require.NotEqual(t, classic, token2022)
+
+	expected, _, err = sdkcommon.FindProgramAddress(
+		[][]byte{wallet.Bytes(), common.Token2022ProgramID.Bytes(), mint.Bytes()},
+		sdkcommon.SPLAssociatedTokenAccountProgramID,
+	)
+	require.NoError(t, err)
+	require.Equal(t, expected, token2022)
+}
+
+func TestAccountCLIJSON(t *testing.T) {
+	acc := types.NewAccount()
+
+	data, err := common.AccountToCLIJSON(acc)
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+
+	account2, err := common.AccountFromCLIJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, acc, account2)
+}
+
+func TestAccountFromCLIJSON_Fixture(t *testing.T) {
+	data, err := os.ReadFile("testdata/cli_keypair.json")
+	require.NoError(t, err)
+
+	account, err := common.AccountFromCLIJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, "8Xp3CxmnwTbjYNKwsKEqgCSozqGWcDZHCWtAnxWb86oc", account.PublicKey.ToBase58())
+
+	encoded, err := common.AccountToCLIJSON(account)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(encoded))
+}
+
+func TestAccountFromCLIJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not a json", data: "invalid"},
+		{name: "too short", data: "[1,2,3]"},
+		{name: "byte overflow", data: "[" + strings.Repeat("256,", 63) + "256]"},
+		{name: "public key mismatch", data: "[" + strings.Repeat("1,", 63) + "1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := common.AccountFromCLIJSON([]byte(tt.data))
+			require.ErrorIs(t, err, common.ErrDecodeCLIJSONToAccount)
+		})
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	for _, length := range []common.MnemonicLength{common.MnemonicLength12, common.MnemonicLength24} {
+		mnemonic, err := common.NewMnemonic(length)
+		require.NoError(t, err)
+		require.NoError(t, common.ValidateMnemonic(mnemonic))
+	}
+
+	valid := "response photo senior language wave property trip purse bench arena casual noodle"
+	require.NoError(t, common.ValidateMnemonic(valid))
+
+	tests := []struct {
+		name     string
+		mnemonic string
+		wantErr  error
+	}{
+		{name: "empty", mnemonic: "", wantErr: common.ErrInvalidMnemonicWordCount},
+		{name: "too short", mnemonic: "response photo senior language wave property", wantErr: common.ErrInvalidMnemonicWordCount},
+		{name: "13 words", mnemonic: valid + " noodle", wantErr: common.ErrInvalidMnemonicWordCount},
+		{name: "unknown word", mnemonic: strings.Replace(valid, "senior", "seniors", 1), wantErr: common.ErrInvalidMnemonicWord},
+		{name: "uppercase word", mnemonic: strings.Replace(valid, "photo", "Photo", 1), wantErr: common.ErrInvalidMnemonicWord},
+		{name: "bad checksum", mnemonic: strings.Replace(valid, "noodle", "response", 1), wantErr: common.ErrInvalidMnemonicChecksum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := common.ValidateMnemonic(tt.mnemonic)
+			require.ErrorIs(t, err, common.ErrInvalidMnemonic)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			_, err = common.DeriveAccountFromMnemonicBip44(tt.mnemonic)
+			require.ErrorIs(t, err, tt.wantErr)
+			_, err = common.DeriveAccountFromMnemonicBip39(tt.mnemonic)
+			require.ErrorIs(t, err, tt.wantErr)
+			_, err = common.DeriveAccountsListFromMnemonicBip44(tt.mnemonic, 2)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDeriveAccountFromPath(t *testing.T) {
+	mnemonic := "response photo senior language wave property trip purse bench arena casual noodle"
+
+	tests := []struct {
+		name string
+		path string
+		addr string
+	}{
+		// m/44'/501'/%d'/0' layout (phantom wallet)
+		{name: "account 0", path: "m/44'/501'/0'/0'", addr: "8Xp3CxmnwTbjYNKwsKEqgCSozqGWcDZHCWtAnxWb86oc"},
+		{name: "account 1", path: "m/44'/501'/1'/0'", addr: "5tAVfFUUyvq6eNakNcRdRNSapgoutyVyPP48vfFFfY8x"},
+		// m/44'/501'/0'/0'/%d' layout
+		{name: "address 0", path: "m/44'/501'/0'/0'/0'", addr: "2cqYXWM6QyNL6fMhkrrDM8sGMECCTuhXzrhvY8aB8euH"},
+		{name: "address 1", path: "m/44'/501'/0'/0'/1'", addr: "AYTev87WiwSVWec99WSjhH3ArzyEC3iFVqrAnFrHgsF7"},
+		// m/44'/501'/%d' layout
+		{name: "no change", path: "m/44'/501'/0'", addr: "CR59wrjPgQmZxAiA8cvD8LTvB6QVAoB9hmY7Zvvybc4b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := common.DeriveAccountFromPath(mnemonic, tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.addr, account.PublicKey.ToBase58())
+		})
+	}
+
+	accounts, err := common.DeriveAccountsListFromMnemonicBip44(mnemonic, 2)
+	require.NoError(t, err)
+	require.Equal(t, tests[0].addr, accounts[0].PublicKey.ToBase58())
+	require.Equal(t, tests[1].addr, accounts[1].PublicKey.ToBase58())
+
+	_, err = common.DeriveAccountFromPath("invalid mnemonic", "m/44'/501'/0'/0'")
+	require.ErrorIs(t, err, common.ErrInvalidMnemonic)
+}
+
+func TestValidateDerivationPath(t *testing.T) {
+	require.NoError(t, common.ValidateDerivationPath("m/44'/501'/0'/0'"))
+	require.NoError(t, common.ValidateDerivationPath("m/44'/501'/2147483647'"))
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{name: "empty", path: ""},
+		{name: "root only", path: "m"},
+		{name: "missing root", path: "44'/501'/0'/0'"},
+		{name: "trailing slash", path: "m/44'/501'/"},
+		{name: "not a number", path: "m/44'/abc'/0'"},
+		{name: "signed index", path: "m/44'/+501'/0'"},
+		{name: "out of range", path: "m/44'/501'/2147483648'"},
+		{name: "non-hardened", path: "m/44'/501'/0'/0'/1", wantErr: common.ErrNonHardenedDerivationPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := common.ValidateDerivationPath(tt.path)
+			require.ErrorIs(t, err, common.ErrInvalidDerivationPath)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+
+			_, err = common.DeriveAccountFromPath("response photo senior language wave property trip purse bench arena casual noodle", tt.path)
+			require.ErrorIs(t, err, common.ErrDeriveAccountFromPath)
+			require.ErrorIs(t, err, common.ErrInvalidDerivationPath)
+		})
+	}
+}
@@ -17,5 +17,19 @@ var (
 	ErrDeriveAccountsListFromMnemonicBip44 = errors.New("failed to derive accounts list from mnemonic bip44")
 	ErrDeriveAccountFromMnemonicBip39      = errors.New("failed to derive account from mnemonic bip39")
 	ErrDeriveTokenAccount                  = errors.New("failed to derive associated token account")
+	ErrEncodeAccountToCLIJSON              = errors.New("failed to encode account to solana cli keypair json")
+	ErrDecodeCLIJSONToAccount              = errors.New("failed to decode solana cli keypair json to account")
+	ErrInvalidCLIKeypairLength             = errors.New("invalid solana cli keypair length: must be 64 bytes")
 	ErrInvalidWalletAddress                = errors.New("invalid wallet address: must be a base58 encoded public key")
+	ErrFindProgramAddress                  = errors.New("failed to find program address")
+	ErrCreateProgramAddress                = errors.New("failed to create program address")
+	ErrInvalidMnemonic                     = errors.New("invalid mnemonic")
+	ErrInvalidMnemonicWordCount            = errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+	ErrInvalidMnemonicWord                 = errors.New("mnemonic contains a word which is not in the bip39 english word list")
+	ErrInvalidMnemonicChecksum             = errors.New("invalid mnemonic checksum")
+	ErrDeriveAccountFromPath               = errors.New("failed to derive account from derivation path")
+	ErrInvalidDerivationPath               = errors.New("invalid derivation path")
+	ErrInvalidSNSDomain                    = errors.New("invalid solana name service domain")
+	ErrDeriveSNSKey                        = errors.New("failed to derive solana name service account")
+	ErrNonHardenedDerivationPath           = errors.New("ed25519 supports only hardened derivation: each path segment must end with '")
 )
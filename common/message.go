@@ -0,0 +1,25 @@
+package common
+
+import (
+	"crypto/ed25519"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// SignMessage signs an arbitrary message with the given account.
+// Produces an off-chain ed25519 signature, e.g. to prove the wallet ownership for authentication.
+// The result is not a transaction and can't be sent to the network.
+func SignMessage(a types.Account, msg []byte) []byte {
+	return ed25519.Sign(a.PrivateKey, msg)
+}
+
+// VerifySignature verifies an off-chain ed25519 signature of the message made by the given public key.
+// Returns true if the signature is valid, false otherwise.
+func VerifySignature(pubkey common.PublicKey, msg, sig []byte) bool {
+	if len(sig) != ed25519.SignatureSize {
+		return false
+	}
+
+	return ed25519.Verify(pubkey.Bytes(), msg, sig)
+}
@@ -0,0 +1,33 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	acc := types.NewAccount()
+	msg := []byte("Sign in to example.com: nonce 123456")
+
+	sig := common.SignMessage(acc, msg)
+	require.Len(t, sig, 64)
+
+	t.Run("valid signature", func(t *testing.T) {
+		require.True(t, common.VerifySignature(acc.PublicKey, msg, sig))
+	})
+
+	t.Run("tampered message", func(t *testing.T) {
+		require.False(t, common.VerifySignature(acc.PublicKey, []byte("Sign in to example.com: nonce 654321"), sig))
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		require.False(t, common.VerifySignature(types.NewAccount().PublicKey, msg, sig))
+	})
+
+	t.Run("invalid signature length", func(t *testing.T) {
+		require.False(t, common.VerifySignature(acc.PublicKey, msg, sig[:32]))
+	})
+}
@@ -0,0 +1,30 @@
+package common
+
+import (
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// FindProgramAddress derives a program derived address (PDA) from the seeds and the program ID.
+// It searches for the first bump seed, starting from 255, which produces an address off the ed25519 curve.
+// Returns the address and the bump seed or an error.
+func FindProgramAddress(seeds [][]byte, programID common.PublicKey) (common.PublicKey, uint8, error) {
+	pda, bump, err := common.FindProgramAddress(seeds, programID)
+	if err != nil {
+		return common.PublicKey{}, 0, utils.StackErrors(ErrFindProgramAddress, err)
+	}
+
+	return pda, bump, nil
+}
+
+// CreateProgramAddress creates a program derived address (PDA) from the seeds and the program ID.
+// The seeds must include the bump seed; use FindProgramAddress if it's unknown.
+// Returns an error if the seeds produce an address on the ed25519 curve.
+func CreateProgramAddress(seeds [][]byte, programID common.PublicKey) (common.PublicKey, error) {
+	pda, err := common.CreateProgramAddress(seeds, programID)
+	if err != nil {
+		return common.PublicKey{}, utils.StackErrors(ErrCreateProgramAddress, err)
+	}
+
+	return pda, nil
+}
@@ -0,0 +1,64 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/token_metadata"
+	sdkcommon "github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProgramAddress(t *testing.T) {
+	mint := sdkcommon.PublicKeyFromString("9ARngHhVaCtH5JFieRdSS5Y8cdZk2TMF4tfGSWFB9iSK")
+	seeds := [][]byte{
+		[]byte("metadata"),
+		sdkcommon.MetaplexTokenMetaProgramID.Bytes(),
+		mint.Bytes(),
+	}
+
+	pda, bump, err := common.FindProgramAddress(seeds, sdkcommon.MetaplexTokenMetaProgramID)
+	require.NoError(t, err)
+
+	expected, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+	require.NoError(t, err)
+	assert.Equal(t, expected, pda)
+
+	// the same address is created with the found bump seed
+	created, err := common.CreateProgramAddress(append(seeds, []byte{bump}), sdkcommon.MetaplexTokenMetaProgramID)
+	require.NoError(t, err)
+	assert.Equal(t, pda, created)
+
+	edition, _, err := common.FindProgramAddress(append(seeds, []byte("edition")), sdkcommon.MetaplexTokenMetaProgramID)
+	require.NoError(t, err)
+	expected, err = token_metadata.DeriveEditionPubkey(mint)
+	require.NoError(t, err)
+	assert.Equal(t, expected, edition)
+}
+
+func TestFindProgramAddress_AssociatedTokenAccount(t *testing.T) {
+	wallet := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+
+	pda, bump, err := common.FindProgramAddress([][]byte{
+		wallet.Bytes(),
+		sdkcommon.TokenProgramID.Bytes(),
+		mint.Bytes(),
+	}, sdkcommon.SPLAssociatedTokenAccountProgramID)
+	require.NoError(t, err)
+
+	expected, expectedBump, err := sdkcommon.FindAssociatedTokenAddress(wallet, mint)
+	require.NoError(t, err)
+	assert.Equal(t, expected, pda)
+	assert.Equal(t, expectedBump, bump)
+}
+
+func TestProgramAddress_InvalidSeeds(t *testing.T) {
+	_, _, err := common.FindProgramAddress([][]byte{make([]byte, 33)}, sdkcommon.SystemProgramID)
+	assert.ErrorIs(t, err, common.ErrFindProgramAddress)
+
+	_, err = common.CreateProgramAddress(make([][]byte, 17), sdkcommon.SystemProgramID)
+	assert.ErrorIs(t, err, common.ErrCreateProgramAddress)
+}
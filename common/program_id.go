@@ -0,0 +1,20 @@
+package common
+
+import "github.com/portto/solana-go-sdk/common"
+
+// Predefined program IDs which are not provided by the solana-go-sdk
+var (
+	// Token2022ProgramID is the SPL Token-2022 (token extensions) program ID
+	Token2022ProgramID = common.PublicKeyFromString("TokenzQdBNbLqP5VEhdkAxSS5cPy3e5iBk8NtHhsFxEb")
+	// Ed25519ProgramID is the native program which verifies ed25519 signatures
+	Ed25519ProgramID = common.PublicKeyFromString("Ed25519SigVerify111111111111111111111111111")
+	// TokenAuthRulesProgramID is the Metaplex token authorization rules program ID,
+	// which enforces the rule sets of programmable NFTs
+	TokenAuthRulesProgramID = common.PublicKeyFromString("auth9SigNpDKz4sJJ1DfCTuZrZNSAgh9sFD3rboVmgg")
+)
+
+// IsTokenProgramID returns true if the given public key is one of the SPL token program IDs:
+// the classic SPL Token program or the Token-2022 program.
+func IsTokenProgramID(programID common.PublicKey) bool {
+	return programID == common.TokenProgramID || programID == Token2022ProgramID
+}
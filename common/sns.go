@@ -0,0 +1,90 @@
+package common
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// Solana Name Service (SNS) accounts.
+var (
+	SNSProgramID           = common.PublicKeyFromString("namesLPneVptA9Z5rqUDD9tMTWEJwofgaYwp8cawRkX")
+	SNSRootDomain          = common.PublicKeyFromString("58PwtjSDuFHuUkYjH9BYnnQKHfwo9reZhC2zMJv9JPkx") // .sol top level domain
+	SNSReverseLookupClass  = common.PublicKeyFromString("33m47vH6Eav6jr5Ry86XjhRft2jRBLDnDgPSHoquXi2Z")
+	SNSNameOffersProgramID = common.PublicKeyFromString("85iDfUvr3HJyLM2zcq5BXSiDvUWfw6cSE1FfNBo8Ap29")
+)
+
+// SNSNameRegistryHeaderSize is the size of the name registry account header:
+// the parent name, the owner and the class public keys; the name data follows it.
+const SNSNameRegistryHeaderSize = 96
+
+const (
+	snsHashPrefix      = "SPL Name Service"
+	snsFavoriteSeed    = "favourite_domain"
+	snsTLD             = ".sol"
+	snsSubdomainPrefix = "\x00"
+)
+
+// DeriveSNSDomainKey returns the name registry account of the given .sol domain,
+// e.g. "bonfida.sol", "bonfida" or "dex.bonfida.sol" (subdomain).
+func DeriveSNSDomainKey(domain string) (common.PublicKey, error) {
+	name := strings.TrimSuffix(strings.TrimSpace(domain), snsTLD)
+	labels := strings.Split(name, ".")
+	if len(labels) > 2 {
+		return common.PublicKey{}, utils.StackErrors(ErrInvalidSNSDomain, fmt.Errorf("%q: only one subdomain level is supported", domain))
+	}
+	for _, label := range labels {
+		if label == "" {
+			return common.PublicKey{}, utils.StackErrors(ErrInvalidSNSDomain, fmt.Errorf("%q: empty domain name", domain))
+		}
+	}
+
+	parent, err := deriveSNSNameKey(labels[len(labels)-1], nil, &SNSRootDomain)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	if len(labels) == 1 {
+		return parent, nil
+	}
+
+	return deriveSNSNameKey(snsSubdomainPrefix+labels[0], nil, &parent)
+}
+
+// DeriveSNSReverseKey returns the reverse lookup account of the given name registry account,
+// which stores the domain name.
+func DeriveSNSReverseKey(nameAccount common.PublicKey) (common.PublicKey, error) {
+	return deriveSNSNameKey(nameAccount.ToBase58(), &SNSReverseLookupClass, nil)
+}
+
+// DeriveSNSFavoriteDomainKey returns the account which stores the favorite (primary) domain of the given wallet.
+func DeriveSNSFavoriteDomainKey(owner common.PublicKey) (common.PublicKey, error) {
+	key, _, err := FindProgramAddress([][]byte{[]byte(snsFavoriteSeed), owner.Bytes()}, SNSNameOffersProgramID)
+	if err != nil {
+		return common.PublicKey{}, utils.StackErrors(ErrDeriveSNSKey, err)
+	}
+
+	return key, nil
+}
+
+// deriveSNSNameKey returns the name registry account of the name with the optional class and parent.
+func deriveSNSNameKey(name string, class, parent *common.PublicKey) (common.PublicKey, error) {
+	hashed := sha256.Sum256([]byte(snsHashPrefix + name))
+
+	seeds := [][]byte{hashed[:], make([]byte, 32), make([]byte, 32)}
+	if class != nil {
+		seeds[1] = class.Bytes()
+	}
+	if parent != nil {
+		seeds[2] = parent.Bytes()
+	}
+
+	key, _, err := FindProgramAddress(seeds, SNSProgramID)
+	if err != nil {
+		return common.PublicKey{}, utils.StackErrors(ErrDeriveSNSKey, err)
+	}
+
+	return key, nil
+}
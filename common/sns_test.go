@@ -0,0 +1,29 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/solana/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveSNSDomainKey(t *testing.T) {
+	// the name registry account of bonfida.sol
+	const bonfida = "Crf8hzfthWGbGbLTVCiqRqV5MVnbpHB1L9KQMd6gsinb"
+
+	for _, domain := range []string{"bonfida.sol", "bonfida", " bonfida.sol "} {
+		key, err := common.DeriveSNSDomainKey(domain)
+		require.NoError(t, err)
+		assert.Equal(t, bonfida, key.ToBase58(), domain)
+	}
+
+	sub, err := common.DeriveSNSDomainKey("dex.bonfida.sol")
+	require.NoError(t, err)
+	assert.NotEqual(t, bonfida, sub.ToBase58())
+
+	for _, domain := range []string{"", ".sol", "a.b.c.sol", "dex..sol"} {
+		_, err := common.DeriveSNSDomainKey(domain)
+		require.ErrorIs(t, err, common.ErrInvalidSNSDomain, domain)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	commonx "github.com/dmitrymomot/solana/common"
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/program/associated_token_account"
+	"github.com/portto/solana-go-sdk/program/system"
 	"github.com/portto/solana-go-sdk/program/token"
 	"github.com/portto/solana-go-sdk/types"
 )
@@ -40,6 +42,8 @@ func CreateAssociatedTokenAccount(params CreateAssociatedTokenAccountParam) Inst
 
 // CreateAssociatedTokenAccountIfNotExists creates an associated token account for
 // the given owner and mint if it does not exist.
+// It uses the idempotent create instruction, which is a no-op on-chain if the account already exists,
+// so no RPC read is made and there is no race with another transaction creating the same account.
 func CreateAssociatedTokenAccountIfNotExists(params CreateAssociatedTokenAccountParam) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
 		ata, _, err := common.FindAssociatedTokenAddress(params.Owner, params.Mint)
@@ -47,15 +51,9 @@ func CreateAssociatedTokenAccountIfNotExists(params CreateAssociatedTokenAccount
 			return nil, fmt.Errorf("failed to find associated token address: %w", err)
 		}
 
-		if info, err := c.GetTokenAccountInfo(ctx, ata.ToBase58()); err == nil {
-			if info.Mint.ToBase58() == params.Mint.ToBase58() {
-				return nil, nil
-			}
-		}
-
 		return []types.Instruction{
-			associated_token_account.CreateAssociatedTokenAccount(
-				associated_token_account.CreateAssociatedTokenAccountParam{
+			associated_token_account.CreateIdempotent(
+				associated_token_account.CreateIdempotentParam{
 					Funder:                 params.Funder,
 					Owner:                  params.Owner,
 					Mint:                   params.Mint,
@@ -66,12 +64,49 @@ func CreateAssociatedTokenAccountIfNotExists(params CreateAssociatedTokenAccount
 	}
 }
 
+// CreateAssociatedTokenAccountWithExtensions creates an associated token account for the given owner and mint
+// through the token program which owns the mint: the classic SPL Token program or the Token-2022 program.
+// For Token-2022 mints, the associated token account program initializes the account
+// with the immutable owner extension, so the account owner can't be reassigned,
+// e.g. to redirect the deposits of an exchange.
+// The client must be able to detect the token program of the mint, like client.Client does.
+func CreateAssociatedTokenAccountWithExtensions(params CreateAssociatedTokenAccountParam) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		resolver, ok := c.(tokenProgramResolver)
+		if !ok {
+			return nil, fmt.Errorf("client can't detect the token program of the mint")
+		}
+
+		tokenProgram, err := resolver.GetTokenProgramForMint(ctx, params.Mint.ToBase58())
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect token program: %w", err)
+		}
+
+		ata, err := commonx.DeriveTokenAccountPubkeyWithProgram(params.Owner, params.Mint, tokenProgram)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find associated token address: %w", err)
+		}
+
+		instruction := associated_token_account.Create(associated_token_account.CreateParam{
+			Funder:                 params.Funder,
+			Owner:                  params.Owner,
+			Mint:                   params.Mint,
+			AssociatedTokenAccount: ata,
+		})
+		// the sdk always passes the classic token program as the 6th account
+		instruction.Accounts[5].PubKey = tokenProgram
+
+		return []types.Instruction{instruction}, nil
+	}
+}
+
 // CloseTokenAccountParams are the parameters for the CloseTokenAccount instruction.
 type CloseTokenAccountParams struct {
 	Owner             common.PublicKey  // required; the owner of the token account
 	CloseTokenAccount *common.PublicKey // required if Mint is empty; the public key of account to close
 	Mint              *common.PublicKey // required if CloseTokenAccount is empty; the mint of the token account
 	FeePayer          *common.PublicKey // optional; the fee payer of the transaction, if not set, the owner will be used; if set, the rent exemption balance will be transferred to it.
+	TokenProgram      *common.PublicKey // optional; the token program which owns the token account, e.g. the Token-2022 program; default is detected from Mint if it's set and the client supports it, otherwise the classic SPL Token program
 }
 
 // Validate checks that the required fields of the params are set.
@@ -91,6 +126,9 @@ func (p CloseTokenAccountParams) Validate() error {
 	if p.FeePayer != nil && *p.FeePayer == (common.PublicKey{}) {
 		return fmt.Errorf("invalid fee payer public key")
 	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -101,25 +139,171 @@ func CloseTokenAccount(params CloseTokenAccountParams) InstructionFunc {
 			return nil, fmt.Errorf("failed to validate params: %w", err)
 		}
 
-		if params.CloseTokenAccount == nil && params.Mint != nil {
-			ata, _, err := common.FindAssociatedTokenAddress(params.Owner, *params.Mint)
+		if params.TokenProgram == nil && params.Mint != nil {
+			tokenProgram, err := resolveTokenProgram(ctx, c, nil, *params.Mint)
 			if err != nil {
-				return nil, fmt.Errorf("failed to find associated token address: %w", err)
+				return nil, err
 			}
-			params.CloseTokenAccount = &ata
+			params.TokenProgram = &tokenProgram
 		}
 
-		if params.FeePayer == nil {
-			params.FeePayer = &params.Owner
+		account, err := params.tokenAccount()
+		if err != nil {
+			return nil, err
 		}
 
-		return []types.Instruction{
-			token.CloseAccount(token.CloseAccountParam{
-				Account: *params.CloseTokenAccount,
-				Auth:    params.Owner,
-				To:      *params.FeePayer,
-			}),
-		}, nil
+		return []types.Instruction{closeTokenAccount(params, account)}, nil
+	}
+}
+
+// CloseTokenAccountIfEmpty closes the specified token account if its balance is zero.
+// Returns no instructions if the account still holds tokens or does not exist,
+// so it's safe to use in cleanup pipelines unconditionally.
+// The missing account is detected via the client, which must implement AccountExists like client.Client does,
+// otherwise it results in an error like the other failures of fetching the account, e.g. RPC errors.
+func CloseTokenAccountIfEmpty(params CloseTokenAccountParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
+
+		if params.TokenProgram == nil && params.Mint != nil {
+			tokenProgram, err := resolveTokenProgram(ctx, c, nil, *params.Mint)
+			if err != nil {
+				return nil, err
+			}
+			params.TokenProgram = &tokenProgram
+		}
+
+		account, err := params.tokenAccount()
+		if err != nil {
+			return nil, err
+		}
+
+		if checker, ok := c.(accountChecker); ok {
+			exists, err := checker.AccountExists(ctx, account.ToBase58())
+			if err != nil {
+				return nil, fmt.Errorf("failed to check token account: %w", err)
+			}
+			if !exists {
+				return nil, nil
+			}
+		}
+
+		info, err := c.GetTokenAccountInfo(ctx, account.ToBase58())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token account info: %w", err)
+		}
+		if info.Amount > 0 {
+			return nil, nil
+		}
+
+		return []types.Instruction{closeTokenAccount(params, account)}, nil
+	}
+}
+
+// tokenAccount returns the token account to close: CloseTokenAccount if set,
+// otherwise the associated token account of the owner and mint.
+func (p CloseTokenAccountParams) tokenAccount() (common.PublicKey, error) {
+	if p.CloseTokenAccount != nil {
+		return *p.CloseTokenAccount, nil
+	}
+
+	ata, err := findTokenProgramAssociatedAddress(p.Owner, *p.Mint, tokenProgramOrDefault(p.TokenProgram))
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to find associated token address: %w", err)
+	}
+	return ata, nil
+}
+
+// closeTokenAccount returns the instruction closing the token account,
+// which transfers the rent exemption balance to the fee payer or the owner.
+func closeTokenAccount(params CloseTokenAccountParams, account common.PublicKey) types.Instruction {
+	to := params.Owner
+	if params.FeePayer != nil {
+		to = *params.FeePayer
+	}
+
+	return withTokenProgram(token.CloseAccount(token.CloseAccountParam{
+		Account: account,
+		Auth:    params.Owner,
+		To:      to,
+	}), tokenProgramOrDefault(params.TokenProgram))
+}
+
+// MaxCloseTokenAccounts is the maximum number of token accounts closed by CloseTokenAccounts.
+// Each close instruction adds about 39 bytes to the transaction (the account public key and the compiled instruction),
+// so up to 24 accounts fit into a single transaction with a separate fee payer.
+const MaxCloseTokenAccounts = 24
+
+// CloseTokenAccountsParams are the parameters for the CloseTokenAccounts instruction.
+type CloseTokenAccountsParams struct {
+	Owner         common.PublicKey   // required; the owner of the token accounts
+	Mints         []common.PublicKey // required if TokenAccounts is empty; the mints of the associated token accounts to close
+	TokenAccounts []common.PublicKey // required if Mints is empty; the public keys of the token accounts to close
+	FeePayer      *common.PublicKey  // optional; the fee payer of the transaction, if not set, the owner will be used; if set, the rent exemption balance will be transferred to it.
+	TokenProgram  *common.PublicKey  // optional; the token program which owns the token accounts, e.g. the Token-2022 program; default is detected per mint, see CloseTokenAccountParams.TokenProgram
+}
+
+// Validate checks that the required fields of the params are set.
+func (p CloseTokenAccountsParams) Validate() error {
+	if p.Owner == (common.PublicKey{}) {
+		return fmt.Errorf("owner is required")
+	}
+	if len(p.Mints) == 0 && len(p.TokenAccounts) == 0 {
+		return fmt.Errorf("at least one mint or token account must be set")
+	}
+	if n := len(p.Mints) + len(p.TokenAccounts); n > MaxCloseTokenAccounts {
+		return fmt.Errorf("too many token accounts to close in a single transaction: %d; max is %d", n, MaxCloseTokenAccounts)
+	}
+	if p.FeePayer != nil && *p.FeePayer == (common.PublicKey{}) {
+		return fmt.Errorf("invalid fee payer public key")
+	}
+	return nil
+}
+
+// CloseTokenAccounts closes multiple token accounts of the owner in a single transaction.
+// The token accounts must have zero balance.
+func CloseTokenAccounts(params CloseTokenAccountsParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
+
+		closeParams := make([]CloseTokenAccountParams, 0, len(params.Mints)+len(params.TokenAccounts))
+		for i := range params.Mints {
+			closeParams = append(closeParams, CloseTokenAccountParams{
+				Owner:        params.Owner,
+				Mint:         &params.Mints[i],
+				FeePayer:     params.FeePayer,
+				TokenProgram: params.TokenProgram,
+			})
+		}
+		for i := range params.TokenAccounts {
+			closeParams = append(closeParams, CloseTokenAccountParams{
+				Owner:             params.Owner,
+				CloseTokenAccount: &params.TokenAccounts[i],
+				FeePayer:          params.FeePayer,
+				TokenProgram:      params.TokenProgram,
+			})
+		}
+
+		instructions := make([]types.Instruction, 0, len(closeParams))
+		closed := make(map[common.PublicKey]struct{}, len(closeParams))
+		for _, p := range closeParams {
+			instr, err := CloseTokenAccount(p)(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			account := instr[0].Accounts[0].PubKey
+			if _, ok := closed[account]; ok {
+				return nil, fmt.Errorf("token account %s is set more than once", account.ToBase58())
+			}
+			closed[account] = struct{}{}
+			instructions = append(instructions, instr...)
+		}
+
+		return instructions, nil
 	}
 }
 
@@ -129,6 +313,7 @@ type FreezeTokenAccountParams struct {
 	Mint              common.PublicKey  // required; the mint of the token account
 	TokenAccount      *common.PublicKey // optional; the public key of account to freeze; if not set, the associated token account will be derived from the mint and token account owner.
 	TokenAccountOwner *common.PublicKey // optional; the owner of the token account;
+	TokenProgram      *common.PublicKey // optional; the token program which owns the mint, e.g. the Token-2022 program; default is detected from the mint if the client supports it, otherwise the classic SPL Token program
 }
 
 // Validate checks that the required fields of the params are set.
@@ -148,6 +333,9 @@ func (p FreezeTokenAccountParams) Validate() error {
 	if p.TokenAccount == nil && p.TokenAccountOwner == nil {
 		return fmt.Errorf("must be set at least one of token account or token account owner")
 	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -158,8 +346,13 @@ func FreezeTokenAccount(params FreezeTokenAccountParams) InstructionFunc {
 			return nil, fmt.Errorf("failed to validate params: %w", err)
 		}
 
+		tokenProgram, err := resolveTokenProgram(ctx, c, params.TokenProgram, params.Mint)
+		if err != nil {
+			return nil, err
+		}
+
 		if params.TokenAccount == nil && params.TokenAccountOwner != nil {
-			ata, _, err := common.FindAssociatedTokenAddress(*params.TokenAccountOwner, params.Mint)
+			ata, err := findTokenProgramAssociatedAddress(*params.TokenAccountOwner, params.Mint, tokenProgram)
 			if err != nil {
 				return nil, fmt.Errorf("failed to find associated token address: %w", err)
 			}
@@ -167,11 +360,11 @@ func FreezeTokenAccount(params FreezeTokenAccountParams) InstructionFunc {
 		}
 
 		return []types.Instruction{
-			token.FreezeAccount(token.FreezeAccountParam{
+			withTokenProgram(token.FreezeAccount(token.FreezeAccountParam{
 				Account: *params.TokenAccount,
 				Mint:    params.Mint,
 				Auth:    params.FreezeAuth,
-			}),
+			}), tokenProgram),
 		}, nil
 	}
 }
@@ -182,6 +375,7 @@ type UnfreezeTokenAccountParams struct {
 	Mint              common.PublicKey  // required; the mint of the token account
 	TokenAccount      *common.PublicKey // optional; the public key of account to freeze; if not set, the associated token account will be derived from the mint and token account owner.
 	TokenAccountOwner *common.PublicKey // optional; the owner of the token account;
+	TokenProgram      *common.PublicKey // optional; the token program which owns the mint, e.g. the Token-2022 program; default is detected from the mint if the client supports it, otherwise the classic SPL Token program
 }
 
 // Validate checks that the required fields of the params are set.
@@ -201,6 +395,9 @@ func (p UnfreezeTokenAccountParams) Validate() error {
 	if p.TokenAccount == nil && p.TokenAccountOwner == nil {
 		return fmt.Errorf("must be set at least one of token account or token account owner")
 	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -211,8 +408,13 @@ func UnfreezeTokenAccount(params UnfreezeTokenAccountParams) InstructionFunc {
 			return nil, fmt.Errorf("failed to validate params: %w", err)
 		}
 
+		tokenProgram, err := resolveTokenProgram(ctx, c, params.TokenProgram, params.Mint)
+		if err != nil {
+			return nil, err
+		}
+
 		if params.TokenAccount == nil && params.TokenAccountOwner != nil {
-			ata, _, err := common.FindAssociatedTokenAddress(*params.TokenAccountOwner, params.Mint)
+			ata, err := findTokenProgramAssociatedAddress(*params.TokenAccountOwner, params.Mint, tokenProgram)
 			if err != nil {
 				return nil, fmt.Errorf("failed to find associated token address: %w", err)
 			}
@@ -220,10 +422,74 @@ func UnfreezeTokenAccount(params UnfreezeTokenAccountParams) InstructionFunc {
 		}
 
 		return []types.Instruction{
-			token.ThawAccount(token.ThawAccountParam{
+			withTokenProgram(token.ThawAccount(token.ThawAccountParam{
 				Account: *params.TokenAccount,
 				Mint:    params.Mint,
 				Auth:    params.FreezeAuth,
+			}), tokenProgram),
+		}, nil
+	}
+}
+
+// MaxSeedLength is the maximum length of the seed of an account address derived with seed.
+const MaxSeedLength = 32
+
+// CreateAccountWithSeedParams are the parameters for the CreateAccountWithSeed instruction.
+type CreateAccountWithSeedParams struct {
+	Base   common.PublicKey // required; the base public key of the derived address; must sign the transaction
+	Seed   string           // required; the seed of the derived address; up to MaxSeedLength bytes
+	Owner  common.PublicKey // required; the program which will own the new account
+	Space  uint64           // optional; the size of the account data in bytes
+	Funder common.PublicKey // required; the account which pays the rent exemption balance; must sign the transaction
+}
+
+// Validate checks that the required fields of the params are set.
+func (p CreateAccountWithSeedParams) Validate() error {
+	if p.Base == (common.PublicKey{}) {
+		return fmt.Errorf("base is required")
+	}
+	if p.Seed == "" {
+		return fmt.Errorf("seed is required")
+	}
+	if len(p.Seed) > MaxSeedLength {
+		return fmt.Errorf("seed is too long: %d bytes; max is %d", len(p.Seed), MaxSeedLength)
+	}
+	if p.Owner == (common.PublicKey{}) {
+		return fmt.Errorf("owner program is required")
+	}
+	if p.Funder == (common.PublicKey{}) {
+		return fmt.Errorf("funder is required")
+	}
+	return nil
+}
+
+// Address returns the address of the account derived from the base, seed and owner program.
+func (p CreateAccountWithSeedParams) Address() common.PublicKey {
+	return common.CreateWithSeed(p.Base, p.Seed, p.Owner)
+}
+
+// CreateAccountWithSeed creates a rent exempt account at the address derived from the base, seed and owner program,
+// see CreateAccountWithSeedParams.Address.
+func CreateAccountWithSeed(params CreateAccountWithSeedParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
+
+		rentExemption, err := c.GetMinimumBalanceForRentExemption(ctx, params.Space)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get minimum balance for rent exemption: %w", err)
+		}
+
+		return []types.Instruction{
+			system.CreateAccountWithSeed(system.CreateAccountWithSeedParam{
+				From:     params.Funder,
+				New:      params.Address(),
+				Base:     params.Base,
+				Owner:    params.Owner,
+				Seed:     params.Seed,
+				Lamports: rentExemption,
+				Space:    params.Space,
 			}),
 		}, nil
 	}
@@ -0,0 +1,274 @@
+package instructions_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/transaction"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/associated_token_account"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseTokenAccounts(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	feePayer := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+	tokenAccount := types.NewAccount().PublicKey
+
+	instr, err := instructions.CloseTokenAccounts(instructions.CloseTokenAccountsParams{
+		Owner:         owner,
+		Mints:         []common.PublicKey{mint},
+		TokenAccounts: []common.PublicKey{tokenAccount},
+		FeePayer:      &feePayer,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 2)
+
+	ata, _, err := common.FindAssociatedTokenAddress(owner, mint)
+	require.NoError(t, err)
+
+	for i, account := range []common.PublicKey{ata, tokenAccount} {
+		assert.Equal(t, common.TokenProgramID, instr[i].ProgramID)
+		assert.Equal(t, []byte{byte(token.InstructionCloseAccount)}, instr[i].Data)
+		assert.Equal(t, account, instr[i].Accounts[0].PubKey)
+		assert.Equal(t, feePayer, instr[i].Accounts[1].PubKey)
+		assert.Equal(t, owner, instr[i].Accounts[2].PubKey)
+	}
+
+	// the same account set twice
+	_, err = instructions.CloseTokenAccounts(instructions.CloseTokenAccountsParams{
+		Owner:         owner,
+		Mints:         []common.PublicKey{mint},
+		TokenAccounts: []common.PublicKey{ata},
+	})(context.Background(), &mockClient{})
+	assert.Error(t, err)
+}
+
+func TestCloseTokenAccounts_Overflow(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	feePayer := types.NewAccount().PublicKey
+
+	accounts := make([]common.PublicKey, instructions.MaxCloseTokenAccounts)
+	for i := range accounts {
+		accounts[i] = types.NewAccount().PublicKey
+	}
+
+	instr, err := instructions.CloseTokenAccounts(instructions.CloseTokenAccountsParams{
+		Owner:         owner,
+		TokenAccounts: accounts,
+		FeePayer:      &feePayer,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, instructions.MaxCloseTokenAccounts)
+
+	size, err := transaction.EstimateSize(instr, feePayer)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, size, transaction.MaxTransactionSize)
+
+	_, err = instructions.CloseTokenAccounts(instructions.CloseTokenAccountsParams{
+		Owner:         owner,
+		Mints:         []common.PublicKey{types.NewAccount().PublicKey},
+		TokenAccounts: accounts,
+	})(context.Background(), &mockClient{})
+	assert.Error(t, err)
+}
+
+func TestCreateAssociatedTokenAccountWithExtensions(t *testing.T) {
+	funder := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+	params := instructions.CreateAssociatedTokenAccountParam{Funder: funder, Owner: owner, Mint: mint}
+
+	t.Run("classic mint", func(t *testing.T) {
+		instr, err := instructions.CreateAssociatedTokenAccountWithExtensions(params)(context.Background(), &mockClient{})
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+
+		ata, _, err := common.FindAssociatedTokenAddress(owner, mint)
+		require.NoError(t, err)
+		assert.Equal(t, common.SPLAssociatedTokenAccountProgramID, instr[0].ProgramID)
+		assert.Equal(t, ata, instr[0].Accounts[1].PubKey)
+		assert.Equal(t, common.TokenProgramID, instr[0].Accounts[5].PubKey)
+	})
+
+	t.Run("token-2022 mint", func(t *testing.T) {
+		instr, err := instructions.CreateAssociatedTokenAccountWithExtensions(params)(context.Background(), &mockClient{
+			tokenPrograms: map[string]common.PublicKey{mint.ToBase58(): commonx.Token2022ProgramID},
+		})
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+
+		ata, err := commonx.DeriveTokenAccountPubkeyWithProgram(owner, mint, commonx.Token2022ProgramID)
+		require.NoError(t, err)
+		assert.Equal(t, common.SPLAssociatedTokenAccountProgramID, instr[0].ProgramID)
+		assert.Equal(t, funder, instr[0].Accounts[0].PubKey)
+		assert.Equal(t, ata, instr[0].Accounts[1].PubKey)
+		assert.Equal(t, owner, instr[0].Accounts[2].PubKey)
+		assert.Equal(t, mint, instr[0].Accounts[3].PubKey)
+		// the associated token account program initializes the immutable owner extension
+		// when it creates the account through the token-2022 program
+		assert.Equal(t, commonx.Token2022ProgramID, instr[0].Accounts[5].PubKey)
+	})
+
+	t.Run("client can't detect the token program", func(t *testing.T) {
+		c := struct{ instructions.Client }{&mockClient{}}
+		_, err := instructions.CreateAssociatedTokenAccountWithExtensions(params)(context.Background(), c)
+		assert.Error(t, err)
+	})
+}
+
+func TestCloseTokenAccountIfEmpty(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+	ata, _, err := common.FindAssociatedTokenAddress(owner, mint)
+	require.NoError(t, err)
+
+	params := instructions.CloseTokenAccountParams{Owner: owner, Mint: &mint}
+
+	t.Run("empty account", func(t *testing.T) {
+		instr, err := instructions.CloseTokenAccountIfEmpty(params)(context.Background(), &mockClient{
+			tokenAccounts: map[string]token.TokenAccount{ata.ToBase58(): {Mint: mint, Owner: owner}},
+		})
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+		assert.Equal(t, []byte{byte(token.InstructionCloseAccount)}, instr[0].Data)
+		assert.Equal(t, ata, instr[0].Accounts[0].PubKey)
+		assert.Equal(t, owner, instr[0].Accounts[1].PubKey)
+		assert.Equal(t, owner, instr[0].Accounts[2].PubKey)
+	})
+
+	t.Run("non-empty account", func(t *testing.T) {
+		instr, err := instructions.CloseTokenAccountIfEmpty(params)(context.Background(), &mockClient{
+			tokenAccounts: map[string]token.TokenAccount{ata.ToBase58(): {Mint: mint, Owner: owner, Amount: 1}},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, instr)
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		instr, err := instructions.CloseTokenAccountIfEmpty(params)(context.Background(), &mockClient{})
+		require.NoError(t, err)
+		assert.Empty(t, instr)
+	})
+
+	t.Run("client can't check the account", func(t *testing.T) {
+		c := struct{ instructions.Client }{&mockClient{}}
+		_, err := instructions.CloseTokenAccountIfEmpty(params)(context.Background(), c)
+		require.Error(t, err)
+
+		c = struct{ instructions.Client }{&mockClient{
+			tokenAccounts: map[string]token.TokenAccount{ata.ToBase58(): {Mint: mint, Owner: owner}},
+		}}
+		instr, err := instructions.CloseTokenAccountIfEmpty(params)(context.Background(), c)
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+	})
+
+	t.Run("rpc failure", func(t *testing.T) {
+		rpcErr := errors.New("429 too many requests")
+		_, err := instructions.CloseTokenAccountIfEmpty(params)(context.Background(), &mockClient{tokenAccErr: rpcErr})
+		require.ErrorIs(t, err, rpcErr)
+
+		_, err = instructions.CloseTokenAccountIfEmpty(params)(context.Background(), &mockClient{tokenProgErr: rpcErr})
+		require.ErrorIs(t, err, rpcErr)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, err := instructions.CloseTokenAccountIfEmpty(instructions.CloseTokenAccountParams{Owner: owner})(context.Background(), &mockClient{})
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateAccountWithSeed(t *testing.T) {
+	base := types.NewAccount().PublicKey
+	funder := types.NewAccount().PublicKey
+	params := instructions.CreateAccountWithSeedParams{
+		Base:   base,
+		Seed:   "vault",
+		Owner:  common.TokenProgramID,
+		Space:  token.TokenAccountSize,
+		Funder: funder,
+	}
+
+	// sha256(base || seed || owner)
+	hash := sha256.Sum256(append(append(base.Bytes(), "vault"...), common.TokenProgramID.Bytes()...))
+	expected := common.PublicKeyFromBytes(hash[:])
+	assert.Equal(t, expected, params.Address())
+
+	instr, err := instructions.CreateAccountWithSeed(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+	assert.Equal(t, common.SystemProgramID, instr[0].ProgramID)
+	assert.Equal(t, []common.PublicKey{funder, expected, base}, accountsOf(instr[0]))
+	assert.True(t, instr[0].Accounts[2].IsSigner)
+
+	// instruction (u32), base, seed (u64 length prefixed), lamports, space, owner
+	data := instr[0].Data
+	require.Len(t, data, 4+32+8+len("vault")+8+8+32)
+	assert.Equal(t, uint32(3), binary.LittleEndian.Uint32(data[0:4]))
+	assert.Equal(t, base.Bytes(), data[4:36])
+	assert.Equal(t, uint64(len("vault")), binary.LittleEndian.Uint64(data[36:44]))
+	assert.Equal(t, "vault", string(data[44:49]))
+	assert.Equal(t, uint64(1_000_000), binary.LittleEndian.Uint64(data[49:57])) // rent from the client
+	assert.Equal(t, uint64(token.TokenAccountSize), binary.LittleEndian.Uint64(data[57:65]))
+	assert.Equal(t, common.TokenProgramID.Bytes(), data[65:97])
+
+	// the funder is the base
+	params.Funder = base
+	instr, err = instructions.CreateAccountWithSeed(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	assert.Equal(t, []common.PublicKey{base, expected}, accountsOf(instr[0]))
+
+	params.Seed = strings.Repeat("s", instructions.MaxSeedLength+1)
+	_, err = instructions.CreateAccountWithSeed(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
+
+// noTokenAccountReadClient fails the test if the token account is read.
+type noTokenAccountReadClient struct {
+	*mockClient
+	t *testing.T
+}
+
+func (c noTokenAccountReadClient) GetTokenAccountInfo(ctx context.Context, base58AtaAddr string) (token.TokenAccount, error) {
+	c.t.Errorf("unexpected token account read: %s", base58AtaAddr)
+	return token.TokenAccount{}, nil
+}
+
+func TestCreateAssociatedTokenAccountIfNotExists(t *testing.T) {
+	funder := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+	ata, _, err := common.FindAssociatedTokenAddress(owner, mint)
+	require.NoError(t, err)
+
+	instr, err := instructions.CreateAssociatedTokenAccountIfNotExists(instructions.CreateAssociatedTokenAccountParam{
+		Funder: funder,
+		Owner:  owner,
+		Mint:   mint,
+	})(context.Background(), noTokenAccountReadClient{mockClient: &mockClient{}, t: t})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	assert.Equal(t, common.SPLAssociatedTokenAccountProgramID, instr[0].ProgramID)
+	assert.Equal(t, []byte{byte(associated_token_account.InstructionCreateIdempotent)}, instr[0].Data)
+	assert.Equal(t, []common.PublicKey{
+		funder,
+		ata,
+		owner,
+		mint,
+		common.SystemProgramID,
+		common.TokenProgramID,
+		common.SysVarRentPubkey,
+	}, accountsOf(instr[0]))
+}
@@ -164,6 +164,8 @@ type BurnTokenParams struct {
 	Mint              common.PublicKey // optional; the mint to burn
 	TokenAccountOwner common.PublicKey // optional; the token account owner
 	Amount            uint64           // optional; the amount to burn in token units
+
+	TokenProgram *common.PublicKey // optional; the token program which owns the mint, e.g. the Token-2022 program; default is detected from the mint if the client supports it, otherwise the classic SPL Token program
 }
 
 // Validate checks that the required fields of the params are set.
@@ -174,6 +176,9 @@ func (p BurnTokenParams) Validate() error {
 	if p.TokenAccountOwner == (common.PublicKey{}) {
 		return fmt.Errorf("token account owner is required")
 	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -184,18 +189,23 @@ func BurnToken(params BurnTokenParams) InstructionFunc {
 			return nil, fmt.Errorf("failed to validate params: %w", err)
 		}
 
-		ata, _, err := common.FindAssociatedTokenAddress(params.TokenAccountOwner, params.Mint)
+		tokenProgram, err := resolveTokenProgram(ctx, c, params.TokenProgram, params.Mint)
+		if err != nil {
+			return nil, err
+		}
+
+		ata, err := findTokenProgramAssociatedAddress(params.TokenAccountOwner, params.Mint, tokenProgram)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find associated token address: %w", err)
 		}
 
 		return []types.Instruction{
-			token.Burn(token.BurnParam{
+			withTokenProgram(token.Burn(token.BurnParam{
 				Account: ata,
 				Mint:    params.Mint,
 				Auth:    params.TokenAccountOwner,
 				Amount:  params.Amount,
-			}),
+			}), tokenProgram),
 		}, nil
 	}
 }
@@ -0,0 +1,85 @@
+package instructions_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+)
+
+// mockClient is a stub implementation of the instructions.Client interface.
+type mockClient struct {
+	metadata      *token_metadata.Metadata
+	masterEdition *token_metadata.Edition
+	tokenAccounts map[string]token.TokenAccount // token accounts by address
+	tokenAccErr   error                         // returned by GetTokenAccountInfo if set
+	tokenPrograms map[string]common.PublicKey   // token programs by mint; the classic token program by default
+	tokenProgErr  error                         // returned by GetTokenProgramForMint if set
+	accounts      map[string]bool               // existing accounts by address
+	decimals      *uint8                        // default decimals; 9 if not set
+}
+
+func (m *mockClient) DefaultDecimals() uint8 {
+	if m.decimals != nil {
+		return *m.decimals
+	}
+	return 9
+}
+
+func (m *mockClient) GetMinimumBalanceForRentExemption(ctx context.Context, size uint64) (uint64, error) {
+	return 1_000_000, nil
+}
+
+func (m *mockClient) GetTokenAccountInfo(ctx context.Context, base58AtaAddr string) (token.TokenAccount, error) {
+	if m.tokenAccErr != nil {
+		return token.TokenAccount{}, m.tokenAccErr
+	}
+	if acc, ok := m.tokenAccounts[base58AtaAddr]; ok {
+		return acc, nil
+	}
+	return token.TokenAccount{}, fmt.Errorf("token account not found")
+}
+
+func (m *mockClient) GetTokenMetadata(ctx context.Context, base58MintAddr string) (*token_metadata.Metadata, error) {
+	if m.metadata == nil {
+		return nil, fmt.Errorf("metadata not found")
+	}
+	return m.metadata, nil
+}
+
+func (m *mockClient) GetMasterEditionSupply(ctx context.Context, masterMint common.PublicKey) (current, max uint64, err error) {
+	if m.masterEdition == nil {
+		return 0, 0, fmt.Errorf("not a master edition")
+	}
+	if m.masterEdition.UnlimitedSupply {
+		return m.masterEdition.Supply, math.MaxUint64, nil
+	}
+	return m.masterEdition.Supply, m.masterEdition.MaxSupply, nil
+}
+
+func (m *mockClient) GetEditionInfo(ctx context.Context, base58MintAddr string) (*token_metadata.Edition, error) {
+	return nil, fmt.Errorf("edition not found")
+}
+
+func (m *mockClient) GetTokenProgramForMint(ctx context.Context, base58MintAddr string) (common.PublicKey, error) {
+	if m.tokenProgErr != nil {
+		return common.PublicKey{}, m.tokenProgErr
+	}
+	if programID, ok := m.tokenPrograms[base58MintAddr]; ok {
+		return programID, nil
+	}
+	return common.TokenProgramID, nil
+}
+
+func (m *mockClient) AccountExists(ctx context.Context, base58Addr string) (bool, error) {
+	if m.tokenAccErr != nil {
+		return false, m.tokenAccErr
+	}
+	if _, ok := m.tokenAccounts[base58Addr]; ok {
+		return true, nil
+	}
+	return m.accounts[base58Addr], nil
+}
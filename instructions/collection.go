@@ -119,7 +119,7 @@ func RevokeCollectionAuthority(params RevokeCollectionAuthorityParams) Instructi
 	}
 }
 
-// CreateCollectionParams is the params for CreateCollection
+// VerifyCollectionItemParams is the params for VerifyCollectionItem
 type VerifyCollectionItemParams struct {
 	Mint                common.PublicKey  // required; The mint of the token
 	CollectionMint      common.PublicKey  // required; The mint of the collection
@@ -432,6 +432,32 @@ func VerifySizedCollectionItem(params VerifySizedCollectionItemParams) Instructi
 	}
 }
 
+// VerifyCollectionAuto verifies the collection item with VerifySizedCollectionItem or VerifyCollectionItem,
+// depending on whether the collection is sized, which is detected from the collection metadata.
+func VerifyCollectionAuto(params VerifyCollectionItemParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
+
+		collection, err := c.GetTokenMetadata(ctx, params.CollectionMint.ToBase58())
+		if err != nil {
+			return nil, fmt.Errorf("get collection metadata: %w", err)
+		}
+
+		if collection.CollectionDetails != nil {
+			return VerifySizedCollectionItem(VerifySizedCollectionItemParams{
+				Mint:                params.Mint,
+				CollectionMint:      params.CollectionMint,
+				CollectionAuthority: params.CollectionAuthority,
+				FeePayer:            params.FeePayer,
+			})(ctx, c)
+		}
+
+		return VerifyCollectionItem(params)(ctx, c)
+	}
+}
+
 // UnverifySizedCollectionItemParams is the params for UnverifySizedCollectionItem
 type UnverifySizedCollectionItemParams struct {
 	Mint                common.PublicKey  // required; The mint of the token
@@ -572,3 +598,53 @@ func SetAndVerifySizedCollectionItem(params SetAndVerifySizedCollectionItemParam
 		}, nil
 	}
 }
+
+// CreateCollectionParams is the params for CreateCollection
+type CreateCollectionParams struct {
+	Mint     common.PublicKey  // required; The collection mint public key
+	Owner    common.PublicKey  // required; The collection owner and update authority
+	FeePayer *common.PublicKey // optional; The wallet to pay the fees from; default is Owner
+	Size     *uint64           // required; The initial size of the collection, usually 0 for a new collection
+	Creators *[]Creator        // optional; The creators of the collection; default is Owner:100
+
+	MetadataURI          string // optional; URI of the collection metadata; can be set later
+	TokenName            string // optional; Name of the collection; used if MetadataURI is not set.
+	TokenSymbol          string // optional; Symbol of the collection; used if MetadataURI is not set.
+	SellerFeeBasisPoints uint16 // optional; The seller fee basis points; default is 0
+}
+
+// Validate validates the params.
+func (p CreateCollectionParams) Validate() error {
+	if p.Size == nil {
+		return fmt.Errorf("collection size is required")
+	}
+	return p.mintParams().Validate()
+}
+
+// mintParams converts the params to the MintNonFungible params.
+func (p CreateCollectionParams) mintParams() MintNonFungibleParam {
+	return MintNonFungibleParam{
+		Mint:                 p.Mint,
+		Owner:                p.Owner,
+		FeePayer:             p.FeePayer,
+		Creators:             p.Creators,
+		MetadataURI:          p.MetadataURI,
+		TokenName:            p.TokenName,
+		TokenSymbol:          p.TokenSymbol,
+		SellerFeeBasisPoints: p.SellerFeeBasisPoints,
+		CollectionSize:       p.Size,
+	}
+}
+
+// CreateCollection mints a sized collection NFT.
+// The collection details with the given size are set in the collection metadata,
+// so the collection items must be verified with VerifySizedCollectionItem.
+func CreateCollection(params CreateCollectionParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
+
+		return MintNonFungible(params.mintParams())(ctx, c)
+	}
+}
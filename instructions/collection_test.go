@@ -0,0 +1,106 @@
+package instructions_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCollection(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+
+	for _, size := range []uint64{0, 42} {
+		instr, err := instructions.CreateCollection(instructions.CreateCollectionParams{
+			Mint:        mint,
+			Owner:       owner,
+			Size:        utils.Pointer(size),
+			TokenName:   "Collection",
+			TokenSymbol: "COL",
+		})(context.Background(), &mockClient{})
+		require.NoError(t, err)
+
+		metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+		require.NoError(t, err)
+
+		var createMetadata *types.Instruction
+		for i := range instr {
+			if instr[i].ProgramID == common.MetaplexTokenMetaProgramID &&
+				instr[i].Data[0] == byte(metaplex_token_metadata.InstructionCreateMetadataAccountV3) {
+				createMetadata = &instr[i]
+			}
+		}
+		require.NotNil(t, createMetadata)
+		assert.Equal(t, metadataPubkey, createMetadata.Accounts[0].PubKey)
+
+		// collection details: some, V1, size (u64 little endian)
+		details := createMetadata.Data[len(createMetadata.Data)-10:]
+		assert.Equal(t, []byte{1, 0}, details[:2])
+		assert.Equal(t, size, binary.LittleEndian.Uint64(details[2:]))
+	}
+}
+
+func TestCreateCollection_Validate(t *testing.T) {
+	params := instructions.CreateCollectionParams{
+		Mint:        types.NewAccount().PublicKey,
+		Owner:       types.NewAccount().PublicKey,
+		TokenName:   "Collection",
+		TokenSymbol: "COL",
+	}
+	require.ErrorContains(t, params.Validate(), "size is required")
+
+	params.Size = utils.Pointer(uint64(0))
+	require.NoError(t, params.Validate())
+
+	params.Owner = common.PublicKey{}
+	_, err := instructions.CreateCollection(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
+
+func TestVerifyCollectionAuto(t *testing.T) {
+	params := instructions.VerifyCollectionItemParams{
+		Mint:                types.NewAccount().PublicKey,
+		CollectionMint:      types.NewAccount().PublicKey,
+		CollectionAuthority: types.NewAccount().PublicKey,
+	}
+
+	tests := []struct {
+		name        string
+		collection  *token_metadata.Metadata
+		instruction metaplex_token_metadata.Instruction
+	}{
+		{
+			name:        "sized collection",
+			collection:  &token_metadata.Metadata{CollectionDetails: &token_metadata.CollectionDetails{Size: 10}},
+			instruction: metaplex_token_metadata.InstructionVerifySizedCollectionItem,
+		},
+		{
+			name:        "unsized collection",
+			collection:  &token_metadata.Metadata{},
+			instruction: metaplex_token_metadata.InstructionVerifyCollection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instr, err := instructions.VerifyCollectionAuto(params)(context.Background(), &mockClient{metadata: tt.collection})
+			require.NoError(t, err)
+			require.Len(t, instr, 1)
+			assert.Equal(t, common.MetaplexTokenMetaProgramID, instr[0].ProgramID)
+			assert.Equal(t, byte(tt.instruction), instr[0].Data[0])
+		})
+	}
+
+	t.Run("collection not found", func(t *testing.T) {
+		_, err := instructions.VerifyCollectionAuto(params)(context.Background(), &mockClient{})
+		require.Error(t, err)
+	})
+}
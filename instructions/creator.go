@@ -91,3 +91,39 @@ func RemoveCreatorVerification(params RemoveCreatorVerificationParams) Instructi
 		}, nil
 	}
 }
+
+// UnverifyCreator removes the creator verification of the token metadata.
+// It's a counterpart of VerifyCreator and an alias for RemoveCreatorVerification.
+func UnverifyCreator(params VerifyCreatorParams) InstructionFunc {
+	return RemoveCreatorVerification(RemoveCreatorVerificationParams(params))
+}
+
+// MaxCreators is the maximum number of the token metadata creators.
+const MaxCreators = 5
+
+// validateCreators checks that there are at most MaxCreators creators,
+// they are unique and their shares sum up to 100.
+func validateCreators(creators []Creator) error {
+	if len(creators) > MaxCreators {
+		return fmt.Errorf("creators list must contain at most %d creators, got %d", MaxCreators, len(creators))
+	}
+
+	totalShare := 0
+	seen := make(map[common.PublicKey]bool, len(creators))
+	for _, creator := range creators {
+		if creator.Address == (common.PublicKey{}) {
+			return fmt.Errorf("invalid creator public key")
+		}
+		if seen[creator.Address] {
+			return fmt.Errorf("duplicate creator %s", creator.Address.ToBase58())
+		}
+		seen[creator.Address] = true
+		totalShare += int(creator.Share)
+	}
+
+	if totalShare != 100 {
+		return fmt.Errorf("creators share must be 100, got %d", totalShare)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package instructions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCreator(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	creator := types.NewAccount().PublicKey
+
+	instr, err := instructions.VerifyCreator(instructions.VerifyCreatorParams{
+		Mint:    mint,
+		Creator: creator,
+	})(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.MetaplexTokenMetaProgramID, instr[0].ProgramID)
+	assert.Equal(t, []byte{byte(metaplex_token_metadata.InstructionSignMetadata)}, instr[0].Data)
+	require.Len(t, instr[0].Accounts, 2)
+	assert.Equal(t, metadataPubkey, instr[0].Accounts[0].PubKey)
+	assert.Equal(t, creator, instr[0].Accounts[1].PubKey)
+	assert.True(t, instr[0].Accounts[1].IsSigner)
+
+	_, err = instructions.VerifyCreator(instructions.VerifyCreatorParams{Mint: mint})(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestUnverifyCreator(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	creator := types.NewAccount().PublicKey
+
+	instr, err := instructions.UnverifyCreator(instructions.VerifyCreatorParams{
+		Mint:    mint,
+		Creator: creator,
+	})(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.MetaplexTokenMetaProgramID, instr[0].ProgramID)
+	assert.Equal(t, []byte{byte(metaplex_token_metadata.InstructionRemoveCreatorVerification)}, instr[0].Data)
+	require.Len(t, instr[0].Accounts, 2)
+	assert.Equal(t, metadataPubkey, instr[0].Accounts[0].PubKey)
+	assert.Equal(t, creator, instr[0].Accounts[1].PubKey)
+	assert.True(t, instr[0].Accounts[1].IsSigner)
+
+	_, err = instructions.UnverifyCreator(instructions.VerifyCreatorParams{Creator: creator})(context.Background(), nil)
+	assert.Error(t, err)
+}
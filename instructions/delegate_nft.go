@@ -0,0 +1,145 @@
+package instructions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/associated_token_account"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// NFT amount and decimals, used to make the token program check
+// that the delegated token is a non-fungible one.
+const (
+	nftAmount   uint64 = 1
+	nftDecimals uint8  = 0
+)
+
+// DelegateNFTParams defines the parameters for the DelegateNFT instruction.
+type DelegateNFTParams struct {
+	Mint     common.PublicKey // required; The NFT mint public key
+	Owner    common.PublicKey // required; The NFT owner wallet
+	Delegate common.PublicKey // required; The delegate wallet, e.g. a marketplace authority
+}
+
+// Validate checks the parameters for the DelegateNFT instruction.
+func (p DelegateNFTParams) Validate() error {
+	if p.Mint == (common.PublicKey{}) {
+		return fmt.Errorf("mint is required")
+	}
+	if p.Owner == (common.PublicKey{}) {
+		return fmt.Errorf("owner is required")
+	}
+	if p.Delegate == (common.PublicKey{}) {
+		return fmt.Errorf("delegate is required")
+	}
+	if p.Delegate == p.Owner {
+		return fmt.Errorf("delegate must be different from owner")
+	}
+	return nil
+}
+
+// DelegateNFT approves the delegate to transfer the NFT from the owner's associated token account,
+// so the NFT can be listed without transferring it to an escrow.
+// The token program rejects the instruction if the token is not an NFT (amount 1, decimals 0).
+func DelegateNFT(params DelegateNFTParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+
+		ownerAta, _, err := common.FindAssociatedTokenAddress(params.Owner, params.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find associated token address for owner wallet: %w", err)
+		}
+
+		return []types.Instruction{
+			token.ApproveChecked(token.ApproveCheckedParam{
+				From:     ownerAta,
+				Mint:     params.Mint,
+				To:       params.Delegate,
+				Auth:     params.Owner,
+				Signers:  []common.PublicKey{},
+				Amount:   nftAmount,
+				Decimals: nftDecimals,
+			}),
+		}, nil
+	}
+}
+
+// TransferDelegatedNFTParams defines the parameters for the TransferDelegatedNFT instruction.
+type TransferDelegatedNFTParams struct {
+	Mint      common.PublicKey  // required; The NFT mint public key
+	Owner     common.PublicKey  // required; The current NFT owner wallet
+	Delegate  common.PublicKey  // required; The delegate wallet approved by DelegateNFT
+	Recipient common.PublicKey  // required; The wallet to send the NFT to
+	FeePayer  *common.PublicKey // optional; The wallet to pay for the recipient token account; default is Delegate
+}
+
+// Validate checks the parameters for the TransferDelegatedNFT instruction.
+func (p TransferDelegatedNFTParams) Validate() error {
+	if p.Mint == (common.PublicKey{}) {
+		return fmt.Errorf("mint is required")
+	}
+	if p.Owner == (common.PublicKey{}) {
+		return fmt.Errorf("owner is required")
+	}
+	if p.Delegate == (common.PublicKey{}) {
+		return fmt.Errorf("delegate is required")
+	}
+	if p.Recipient == (common.PublicKey{}) {
+		return fmt.Errorf("recipient is required")
+	}
+	if p.Recipient == p.Owner {
+		return fmt.Errorf("recipient must be different from owner")
+	}
+	if p.FeePayer != nil && *p.FeePayer == (common.PublicKey{}) {
+		return fmt.Errorf("invalid fee payer public key")
+	}
+	return nil
+}
+
+// TransferDelegatedNFT transfers the NFT from the owner to the recipient on behalf of the delegate.
+// The recipient's associated token account is created if it does not exist.
+// The delegate must be approved with DelegateNFT beforehand; the delegation is consumed by the transfer.
+func TransferDelegatedNFT(params TransferDelegatedNFTParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+
+		if params.FeePayer == nil {
+			params.FeePayer = &params.Delegate
+		}
+
+		ownerAta, _, err := common.FindAssociatedTokenAddress(params.Owner, params.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find associated token address for owner wallet: %w", err)
+		}
+
+		recipientAta, _, err := common.FindAssociatedTokenAddress(params.Recipient, params.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find associated token address for recipient wallet: %w", err)
+		}
+
+		return []types.Instruction{
+			associated_token_account.CreateIdempotent(associated_token_account.CreateIdempotentParam{
+				Funder:                 *params.FeePayer,
+				Owner:                  params.Recipient,
+				Mint:                   params.Mint,
+				AssociatedTokenAccount: recipientAta,
+			}),
+			token.TransferChecked(token.TransferCheckedParam{
+				From:     ownerAta,
+				To:       recipientAta,
+				Mint:     params.Mint,
+				Auth:     params.Delegate,
+				Signers:  []common.PublicKey{},
+				Amount:   nftAmount,
+				Decimals: nftDecimals,
+			}),
+		}, nil
+	}
+}
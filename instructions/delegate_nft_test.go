@@ -0,0 +1,103 @@
+package instructions_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegateNFT(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	delegate := types.NewAccount().PublicKey
+
+	instr, err := instructions.DelegateNFT(instructions.DelegateNFTParams{
+		Mint:     mint,
+		Owner:    owner,
+		Delegate: delegate,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	ownerAta, _, err := common.FindAssociatedTokenAddress(owner, mint)
+	require.NoError(t, err)
+
+	approve := instr[0]
+	assert.Equal(t, common.TokenProgramID, approve.ProgramID)
+	assert.Equal(t, []common.PublicKey{ownerAta, mint, delegate, owner}, accountsOf(approve))
+	assert.True(t, approve.Accounts[3].IsSigner)
+	// instruction, amount: 1, decimals: 0
+	require.Len(t, approve.Data, 10)
+	assert.Equal(t, byte(token.InstructionApproveChecked), approve.Data[0])
+	assert.Equal(t, uint64(1), binary.LittleEndian.Uint64(approve.Data[1:9]))
+	assert.Equal(t, byte(0), approve.Data[9])
+
+	_, err = instructions.DelegateNFT(instructions.DelegateNFTParams{
+		Mint:     mint,
+		Owner:    owner,
+		Delegate: owner,
+	})(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
+
+func TestTransferDelegatedNFT(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	delegate := types.NewAccount().PublicKey
+	recipient := types.NewAccount().PublicKey
+
+	instr, err := instructions.TransferDelegatedNFT(instructions.TransferDelegatedNFTParams{
+		Mint:      mint,
+		Owner:     owner,
+		Delegate:  delegate,
+		Recipient: recipient,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 2)
+
+	ownerAta, _, err := common.FindAssociatedTokenAddress(owner, mint)
+	require.NoError(t, err)
+	recipientAta, _, err := common.FindAssociatedTokenAddress(recipient, mint)
+	require.NoError(t, err)
+
+	// create recipient token account, funded by the delegate
+	assert.Equal(t, common.SPLAssociatedTokenAccountProgramID, instr[0].ProgramID)
+	assert.Equal(t, []common.PublicKey{delegate, recipientAta, recipient, mint}, accountsOf(instr[0])[:4])
+
+	// transfer authorized by the delegate, not the owner
+	transfer := instr[1]
+	assert.Equal(t, common.TokenProgramID, transfer.ProgramID)
+	assert.Equal(t, []common.PublicKey{ownerAta, mint, recipientAta, delegate}, accountsOf(transfer))
+	assert.True(t, transfer.Accounts[3].IsSigner)
+	require.Len(t, transfer.Data, 10)
+	assert.Equal(t, byte(token.InstructionTransferChecked), transfer.Data[0])
+	assert.Equal(t, uint64(1), binary.LittleEndian.Uint64(transfer.Data[1:9]))
+	assert.Equal(t, byte(0), transfer.Data[9])
+
+	// custom fee payer
+	feePayer := types.NewAccount().PublicKey
+	instr, err = instructions.TransferDelegatedNFT(instructions.TransferDelegatedNFTParams{
+		Mint:      mint,
+		Owner:     owner,
+		Delegate:  delegate,
+		Recipient: recipient,
+		FeePayer:  &feePayer,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	assert.Equal(t, feePayer, instr[0].Accounts[0].PubKey)
+
+	_, err = instructions.TransferDelegatedNFT(instructions.TransferDelegatedNFTParams{
+		Mint:      mint,
+		Owner:     owner,
+		Delegate:  delegate,
+		Recipient: owner,
+	})(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
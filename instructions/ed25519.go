@@ -0,0 +1,85 @@
+package instructions
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// Ed25519 program instruction layout.
+const (
+	ed25519OffsetsStart      = 2  // num_signatures (u8) + padding (u8)
+	ed25519OffsetsSize       = 14 // 7 x u16 offsets
+	ed25519CurrentInstrIndex = math.MaxUint16
+)
+
+// VerifyEd25519Params defines the parameters for the ed25519 signature verification instruction.
+type VerifyEd25519Params struct {
+	PublicKey common.PublicKey // required; The public key of the signer
+	Message   []byte           // required; The signed message
+	Signature []byte           // required; The ed25519 signature of the message (64 bytes)
+}
+
+// Validate validates the params.
+func (p VerifyEd25519Params) Validate() error {
+	if p.PublicKey == (common.PublicKey{}) {
+		return fmt.Errorf("public key is required")
+	}
+	if len(p.Message) == 0 {
+		return fmt.Errorf("message is required")
+	}
+	if len(p.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("signature must be %d bytes, got %d", ed25519.SignatureSize, len(p.Signature))
+	}
+	if ed25519OffsetsStart+ed25519OffsetsSize+common.PublicKeyLength+ed25519.SignatureSize+len(p.Message) > math.MaxUint16 {
+		return fmt.Errorf("message is too long")
+	}
+	return nil
+}
+
+// VerifyEd25519 builds the Ed25519 program instruction which verifies the signature of the message.
+// The transaction fails if the signature is invalid, so the on-chain programs can rely on
+// the instructions sysvar to check that the message was signed by the given public key,
+// e.g. for gasless or meta-transaction flows.
+func VerifyEd25519(params VerifyEd25519Params) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("validate verify ed25519: %w", err)
+		}
+
+		publicKeyOffset := ed25519OffsetsStart + ed25519OffsetsSize
+		signatureOffset := publicKeyOffset + common.PublicKeyLength
+		messageOffset := signatureOffset + ed25519.SignatureSize
+
+		data := make([]byte, messageOffset+len(params.Message))
+		data[0] = 1 // number of signatures
+		data[1] = 0 // padding
+
+		offsets := data[ed25519OffsetsStart:publicKeyOffset]
+		binary.LittleEndian.PutUint16(offsets[0:], uint16(signatureOffset))
+		binary.LittleEndian.PutUint16(offsets[2:], ed25519CurrentInstrIndex)
+		binary.LittleEndian.PutUint16(offsets[4:], uint16(publicKeyOffset))
+		binary.LittleEndian.PutUint16(offsets[6:], ed25519CurrentInstrIndex)
+		binary.LittleEndian.PutUint16(offsets[8:], uint16(messageOffset))
+		binary.LittleEndian.PutUint16(offsets[10:], uint16(len(params.Message)))
+		binary.LittleEndian.PutUint16(offsets[12:], ed25519CurrentInstrIndex)
+
+		copy(data[publicKeyOffset:], params.PublicKey.Bytes())
+		copy(data[signatureOffset:], params.Signature)
+		copy(data[messageOffset:], params.Message)
+
+		return []types.Instruction{
+			{
+				ProgramID: commonx.Ed25519ProgramID,
+				Accounts:  []types.AccountMeta{},
+				Data:      data,
+			},
+		}, nil
+	}
+}
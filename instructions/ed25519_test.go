@@ -0,0 +1,84 @@
+package instructions_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"testing"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEd25519(t *testing.T) {
+	signer := types.NewAccount()
+	msg := []byte("transfer 100 tokens to alice")
+	sig := commonx.SignMessage(signer, msg)
+
+	instr, err := instructions.VerifyEd25519(instructions.VerifyEd25519Params{
+		PublicKey: signer.PublicKey,
+		Message:   msg,
+		Signature: sig,
+	})(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	assert.Equal(t, commonx.Ed25519ProgramID, instr[0].ProgramID)
+	assert.Empty(t, instr[0].Accounts)
+
+	data := instr[0].Data
+	require.Len(t, data, 16+32+64+len(msg))
+	assert.Equal(t, byte(1), data[0], "number of signatures")
+	assert.Equal(t, byte(0), data[1], "padding")
+
+	u16 := func(offset int) int { return int(binary.LittleEndian.Uint16(data[offset:])) }
+	signatureOffset := u16(2)
+	signatureInstrIndex := u16(4)
+	publicKeyOffset := u16(6)
+	publicKeyInstrIndex := u16(8)
+	messageOffset := u16(10)
+	messageSize := u16(12)
+	messageInstrIndex := u16(14)
+
+	assert.Equal(t, 48, signatureOffset)
+	assert.Equal(t, 16, publicKeyOffset)
+	assert.Equal(t, 112, messageOffset)
+	assert.Equal(t, len(msg), messageSize)
+	for _, idx := range []int{signatureInstrIndex, publicKeyInstrIndex, messageInstrIndex} {
+		assert.Equal(t, 0xFFFF, idx, "data must refer to the current instruction")
+	}
+
+	pubkey := data[publicKeyOffset : publicKeyOffset+common.PublicKeyLength]
+	signature := data[signatureOffset : signatureOffset+ed25519.SignatureSize]
+	message := data[messageOffset : messageOffset+messageSize]
+	assert.Equal(t, signer.PublicKey.Bytes(), pubkey)
+	assert.Equal(t, sig, signature)
+	assert.Equal(t, msg, message)
+	assert.True(t, ed25519.Verify(pubkey, message, signature))
+}
+
+func TestVerifyEd25519_Validation(t *testing.T) {
+	signer := types.NewAccount()
+	msg := []byte("hello")
+	sig := commonx.SignMessage(signer, msg)
+
+	tests := []struct {
+		name   string
+		params instructions.VerifyEd25519Params
+	}{
+		{name: "missing public key", params: instructions.VerifyEd25519Params{Message: msg, Signature: sig}},
+		{name: "missing message", params: instructions.VerifyEd25519Params{PublicKey: signer.PublicKey, Signature: sig}},
+		{name: "short signature", params: instructions.VerifyEd25519Params{PublicKey: signer.PublicKey, Message: msg, Signature: sig[:63]}},
+		{name: "message too long", params: instructions.VerifyEd25519Params{PublicKey: signer.PublicKey, Message: make([]byte, 65536), Signature: sig}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := instructions.VerifyEd25519(tt.params)(context.Background(), nil)
+			require.Error(t, err)
+		})
+	}
+}
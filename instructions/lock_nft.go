@@ -0,0 +1,128 @@
+package instructions
+
+import (
+	"context"
+	"fmt"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// Token metadata program instructions which aren't provided by the solana-go-sdk.
+const (
+	tokenMetadataInstructionLock   uint8 = 46
+	tokenMetadataInstructionUnlock uint8 = 47
+)
+
+// LockNFTParams defines the parameters for the LockNFT and UnlockNFT instructions.
+type LockNFTParams struct {
+	Mint               common.PublicKey  // required; The programmable NFT mint public key
+	Owner              common.PublicKey  // required; The NFT owner wallet
+	Delegate           common.PublicKey  // required; The utility delegate of the NFT, e.g. a staking program authority
+	FeePayer           *common.PublicKey // optional; The wallet to pay for the transaction; default is Delegate
+	AuthorizationRules *common.PublicKey // optional; The rule set of the NFT, if any
+}
+
+// UnlockNFTParams defines the parameters for the UnlockNFT instruction.
+type UnlockNFTParams = LockNFTParams
+
+// Validate checks the parameters for the LockNFT and UnlockNFT instructions.
+func (p LockNFTParams) Validate() error {
+	if p.Mint == (common.PublicKey{}) {
+		return fmt.Errorf("mint is required")
+	}
+	if p.Owner == (common.PublicKey{}) {
+		return fmt.Errorf("owner is required")
+	}
+	if p.Delegate == (common.PublicKey{}) {
+		return fmt.Errorf("delegate is required")
+	}
+	if p.FeePayer != nil && *p.FeePayer == (common.PublicKey{}) {
+		return fmt.Errorf("invalid fee payer public key")
+	}
+	if p.AuthorizationRules != nil && *p.AuthorizationRules == (common.PublicKey{}) {
+		return fmt.Errorf("invalid authorization rules public key")
+	}
+	return nil
+}
+
+// LockNFT locks the programmable NFT in the owner's wallet on behalf of its utility delegate,
+// so the NFT can't be transferred or burned until UnlockNFT is called, e.g. while it's staked.
+// Programmable NFTs can't be frozen with the token program; the lock state is stored in the token record.
+// The delegate must be approved by the owner with the token metadata Delegate instruction beforehand.
+func LockNFT(params LockNFTParams) InstructionFunc {
+	return lockNFT(params, tokenMetadataInstructionLock)
+}
+
+// UnlockNFT unlocks the programmable NFT locked with LockNFT on behalf of its utility delegate.
+func UnlockNFT(params UnlockNFTParams) InstructionFunc {
+	return lockNFT(params, tokenMetadataInstructionUnlock)
+}
+
+// lockNFT returns the token metadata Lock or Unlock instruction,
+// which share the accounts and the arguments layout.
+func lockNFT(params LockNFTParams, instruction uint8) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+
+		md, err := c.GetTokenMetadata(ctx, params.Mint.ToBase58())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token metadata: %w", err)
+		}
+		if md.TokenStandard != token_metadata.TokenStandardProgrammableNonFungible.String() {
+			return nil, fmt.Errorf("token is not a programmable NFT: %s", md.TokenStandard)
+		}
+
+		if params.FeePayer == nil {
+			params.FeePayer = &params.Delegate
+		}
+
+		tokenAccount, _, err := common.FindAssociatedTokenAddress(params.Owner, params.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find associated token address: %w", err)
+		}
+		metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(params.Mint)
+		if err != nil {
+			return nil, err
+		}
+		editionPubkey, err := token_metadata.DeriveEditionPubkey(params.Mint)
+		if err != nil {
+			return nil, err
+		}
+		tokenRecord, err := token_metadata.DeriveTokenRecordPubkey(params.Mint, tokenAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		// the missing optional accounts are replaced with the token metadata program id
+		authRulesProgram, authRules := common.MetaplexTokenMetaProgramID, common.MetaplexTokenMetaProgramID
+		if params.AuthorizationRules != nil {
+			authRulesProgram, authRules = commonx.TokenAuthRulesProgramID, *params.AuthorizationRules
+		}
+
+		return []types.Instruction{{
+			ProgramID: common.MetaplexTokenMetaProgramID,
+			Accounts: []types.AccountMeta{
+				{PubKey: params.Delegate, IsSigner: true, IsWritable: false},
+				{PubKey: params.Owner, IsSigner: false, IsWritable: false},
+				{PubKey: tokenAccount, IsSigner: false, IsWritable: true},
+				{PubKey: params.Mint, IsSigner: false, IsWritable: false},
+				{PubKey: metadataPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: editionPubkey, IsSigner: false, IsWritable: false},
+				{PubKey: tokenRecord, IsSigner: false, IsWritable: true},
+				{PubKey: *params.FeePayer, IsSigner: true, IsWritable: true},
+				{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				{PubKey: common.SysVarInstructionsPubkey, IsSigner: false, IsWritable: false},
+				{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+				{PubKey: authRulesProgram, IsSigner: false, IsWritable: false},
+				{PubKey: authRules, IsSigner: false, IsWritable: false},
+			},
+			// instruction, args version: V1, authorization data: none
+			Data: []byte{instruction, 0, 0},
+		}}, nil
+	}
+}
@@ -0,0 +1,103 @@
+package instructions_test
+
+import (
+	"context"
+	"testing"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockNFT(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	delegate := types.NewAccount().PublicKey
+	params := instructions.LockNFTParams{Mint: mint, Owner: owner, Delegate: delegate}
+	pnft := &mockClient{metadata: &token_metadata.Metadata{
+		TokenStandard: token_metadata.TokenStandardProgrammableNonFungible.String(),
+	}}
+
+	tokenAccount, _, err := common.FindAssociatedTokenAddress(owner, mint)
+	require.NoError(t, err)
+	metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+	require.NoError(t, err)
+	editionPubkey, err := token_metadata.DeriveEditionPubkey(mint)
+	require.NoError(t, err)
+	tokenRecord, err := token_metadata.DeriveTokenRecordPubkey(mint, tokenAccount)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		instruction   instructions.InstructionFunc
+		discriminator byte
+	}{
+		"lock":   {instructions.LockNFT(params), 46},
+		"unlock": {instructions.UnlockNFT(params), 47},
+	} {
+		t.Run(name, func(t *testing.T) {
+			instr, err := tc.instruction(context.Background(), pnft)
+			require.NoError(t, err)
+			require.Len(t, instr, 1)
+
+			assert.Equal(t, common.MetaplexTokenMetaProgramID, instr[0].ProgramID)
+			assert.Equal(t, []byte{tc.discriminator, 0, 0}, instr[0].Data)
+			assert.Equal(t, []common.PublicKey{
+				delegate,
+				owner,
+				tokenAccount,
+				mint,
+				metadataPubkey,
+				editionPubkey,
+				tokenRecord,
+				delegate,
+				common.SystemProgramID,
+				common.SysVarInstructionsPubkey,
+				common.TokenProgramID,
+				common.MetaplexTokenMetaProgramID,
+				common.MetaplexTokenMetaProgramID,
+			}, accountsOf(instr[0]))
+			assert.True(t, instr[0].Accounts[0].IsSigner)
+			assert.True(t, instr[0].Accounts[2].IsWritable)
+			assert.True(t, instr[0].Accounts[6].IsWritable)
+			assert.True(t, instr[0].Accounts[7].IsSigner)
+		})
+	}
+
+	t.Run("fee payer and rule set", func(t *testing.T) {
+		feePayer := types.NewAccount().PublicKey
+		rules := types.NewAccount().PublicKey
+
+		instr, err := instructions.LockNFT(instructions.LockNFTParams{
+			Mint:               mint,
+			Owner:              owner,
+			Delegate:           delegate,
+			FeePayer:           &feePayer,
+			AuthorizationRules: &rules,
+		})(context.Background(), pnft)
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+		accounts := accountsOf(instr[0])
+		assert.Equal(t, feePayer, accounts[7])
+		assert.Equal(t, commonx.TokenAuthRulesProgramID, accounts[11])
+		assert.Equal(t, rules, accounts[12])
+	})
+
+	t.Run("not a programmable NFT", func(t *testing.T) {
+		_, err := instructions.LockNFT(params)(context.Background(), &mockClient{metadata: &token_metadata.Metadata{
+			TokenStandard: token_metadata.TokenStandardNonFungible.String(),
+		}})
+		assert.Error(t, err)
+
+		_, err = instructions.UnlockNFT(params)(context.Background(), &mockClient{})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, err := instructions.LockNFT(instructions.LockNFTParams{Mint: mint, Owner: owner})(context.Background(), pnft)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,113 @@
+package instructions
+
+import (
+	"context"
+	"fmt"
+
+	typesx "github.com/dmitrymomot/solana/types"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/address_lookup_table"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// CreateLookupTableParams is the params for creating an address lookup table.
+type CreateLookupTableParams struct {
+	Authority  common.PublicKey // required; The lookup table authority public key.
+	Payer      common.PublicKey // required; The public key of the account which pays for the lookup table rent.
+	RecentSlot uint64           // required; A recent finalized slot; the lookup table address is derived from the authority and this slot.
+}
+
+// Validate validates the params.
+func (p CreateLookupTableParams) Validate() error {
+	if p.Authority == (common.PublicKey{}) {
+		return fmt.Errorf("authority is required")
+	}
+	if p.Payer == (common.PublicKey{}) {
+		return fmt.Errorf("payer is required")
+	}
+	if p.RecentSlot == 0 {
+		return fmt.Errorf("recent slot is required")
+	}
+	return nil
+}
+
+// LookupTableAddress returns the address of the lookup table which will be created with these params.
+func (p CreateLookupTableParams) LookupTableAddress() common.PublicKey {
+	addr, _ := address_lookup_table.DeriveLookupTableAddress(p.Authority, p.RecentSlot)
+	return addr
+}
+
+// CreateLookupTable creates an address lookup table.
+// Use CreateLookupTableParams.LookupTableAddress to get the derived address of the new table.
+func CreateLookupTable(params CreateLookupTableParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("create lookup table: %w", err)
+		}
+
+		lookupTable, bump := address_lookup_table.DeriveLookupTableAddress(params.Authority, params.RecentSlot)
+
+		return []types.Instruction{
+			address_lookup_table.CreateLookupTable(address_lookup_table.CreateLookupTableParams{
+				LookupTable: lookupTable,
+				Authority:   params.Authority,
+				Payer:       params.Payer,
+				RecentSlot:  params.RecentSlot,
+				BumpSeed:    bump,
+			}),
+		}, nil
+	}
+}
+
+// ExtendLookupTableParams is the params for appending addresses to an address lookup table.
+type ExtendLookupTableParams struct {
+	LookupTable common.PublicKey   // required; The lookup table public key.
+	Authority   common.PublicKey   // required; The lookup table authority public key.
+	Payer       *common.PublicKey  // optional; The public key of the account which pays for the additional rent; required if the table balance does not cover it.
+	Addresses   []common.PublicKey // required; The addresses to append; up to types.LookupTableMaxAddresses.
+}
+
+// Validate validates the params.
+func (p ExtendLookupTableParams) Validate() error {
+	if p.LookupTable == (common.PublicKey{}) {
+		return fmt.Errorf("lookup table is required")
+	}
+	if p.Authority == (common.PublicKey{}) {
+		return fmt.Errorf("authority is required")
+	}
+	if p.Payer != nil && *p.Payer == (common.PublicKey{}) {
+		return fmt.Errorf("invalid payer public key")
+	}
+	if len(p.Addresses) == 0 {
+		return fmt.Errorf("at least one address is required")
+	}
+	if uint(len(p.Addresses)) > typesx.LookupTableMaxAddresses {
+		return fmt.Errorf("too many addresses: %d; lookup table can store up to %d addresses", len(p.Addresses), typesx.LookupTableMaxAddresses)
+	}
+	for i, addr := range p.Addresses {
+		if addr == (common.PublicKey{}) {
+			return fmt.Errorf("address at index %d is invalid", i)
+		}
+	}
+	return nil
+}
+
+// ExtendLookupTable appends addresses to an address lookup table.
+// Note that a single transaction fits about 20 addresses,
+// so large lists should be split across several transactions.
+func ExtendLookupTable(params ExtendLookupTableParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("extend lookup table: %w", err)
+		}
+
+		return []types.Instruction{
+			address_lookup_table.ExtendLookupTable(address_lookup_table.ExtendLookupTableParams{
+				LookupTable: params.LookupTable,
+				Authority:   params.Authority,
+				Payer:       params.Payer,
+				Addresses:   params.Addresses,
+			}),
+		}, nil
+	}
+}
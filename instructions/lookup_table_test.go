@@ -0,0 +1,87 @@
+package instructions_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLookupTable(t *testing.T) {
+	authority := types.NewAccount().PublicKey
+	payer := types.NewAccount().PublicKey
+	params := instructions.CreateLookupTableParams{
+		Authority:  authority,
+		Payer:      payer,
+		RecentSlot: 123456789,
+	}
+
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, params.RecentSlot)
+	expected, bump, err := common.FindProgramAddress([][]byte{authority.Bytes(), slot}, common.AddressLookupTableProgramID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, params.LookupTableAddress())
+
+	instr, err := instructions.CreateLookupTable(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	assert.Equal(t, common.AddressLookupTableProgramID, instr[0].ProgramID)
+	assert.Equal(t, expected, instr[0].Accounts[0].PubKey)
+	assert.Equal(t, authority, instr[0].Accounts[1].PubKey)
+	assert.Equal(t, payer, instr[0].Accounts[2].PubKey)
+	// instruction index (u32), recent slot (u64), bump seed (u8)
+	assert.Equal(t, append(append([]byte{0, 0, 0, 0}, slot...), byte(bump)), instr[0].Data)
+
+	_, err = instructions.CreateLookupTable(instructions.CreateLookupTableParams{Authority: authority, Payer: payer})(context.Background(), &mockClient{})
+	assert.Error(t, err)
+}
+
+func TestExtendLookupTable(t *testing.T) {
+	lookupTable := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	addresses := []common.PublicKey{types.NewAccount().PublicKey, types.NewAccount().PublicKey}
+
+	instr, err := instructions.ExtendLookupTable(instructions.ExtendLookupTableParams{
+		LookupTable: lookupTable,
+		Authority:   authority,
+		Payer:       &authority,
+		Addresses:   addresses,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	assert.Equal(t, common.AddressLookupTableProgramID, instr[0].ProgramID)
+	assert.Equal(t, lookupTable, instr[0].Accounts[0].PubKey)
+	assert.Equal(t, authority, instr[0].Accounts[1].PubKey)
+	// instruction index (u32), addresses count (u64), addresses
+	expected := []byte{2, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}
+	expected = append(expected, addresses[0].Bytes()...)
+	expected = append(expected, addresses[1].Bytes()...)
+	assert.Equal(t, expected, instr[0].Data)
+}
+
+func TestExtendLookupTableParams_Validate(t *testing.T) {
+	params := instructions.ExtendLookupTableParams{
+		LookupTable: types.NewAccount().PublicKey,
+		Authority:   types.NewAccount().PublicKey,
+	}
+	assert.Error(t, params.Validate())
+
+	params.Addresses = make([]common.PublicKey, 257)
+	for i := range params.Addresses {
+		params.Addresses[i] = types.NewAccount().PublicKey
+	}
+	assert.Error(t, params.Validate())
+
+	params.Addresses = params.Addresses[:256]
+	assert.NoError(t, params.Validate())
+
+	params.Addresses[10] = common.PublicKey{}
+	assert.Error(t, params.Validate())
+}
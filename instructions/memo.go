@@ -2,15 +2,27 @@ package instructions
 
 import (
 	"context"
+	"fmt"
+	"unicode/utf8"
 
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/program/memo"
 	"github.com/portto/solana-go-sdk/types"
 )
 
+// MemoMaxLength is the maximum length of the memo in bytes,
+// which fits into a single transaction alongside a signer.
+const MemoMaxLength = 566
+
 // Memo is the memo instruction.
+// The memo must be a valid UTF-8 string and must not exceed MemoMaxLength bytes,
+// otherwise the memo program rejects the transaction.
 func Memo(str string, signers ...common.PublicKey) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := validateMemo(str); err != nil {
+			return nil, fmt.Errorf("memo: %w", err)
+		}
+
 		return []types.Instruction{
 			memo.BuildMemo(memo.BuildMemoParam{
 				SignerPubkeys: signers,
@@ -19,3 +31,43 @@ func Memo(str string, signers ...common.PublicKey) InstructionFunc {
 		}, nil
 	}
 }
+
+// MemoWithSigners is the memo instruction which requires the given accounts to sign the transaction.
+// It can be used to prove the authorship of the memo.
+// The memo text must be a valid UTF-8 string and must not exceed MemoMaxLength bytes.
+func MemoWithSigners(text string, signers ...common.PublicKey) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if text == "" {
+			return nil, fmt.Errorf("memo with signers: memo text is required")
+		}
+		if err := validateMemo(text); err != nil {
+			return nil, fmt.Errorf("memo with signers: %w", err)
+		}
+		if len(signers) == 0 {
+			return nil, fmt.Errorf("memo with signers: at least one signer is required")
+		}
+		for _, signer := range signers {
+			if signer == (common.PublicKey{}) {
+				return nil, fmt.Errorf("memo with signers: invalid signer public key")
+			}
+		}
+
+		return []types.Instruction{
+			memo.BuildMemo(memo.BuildMemoParam{
+				SignerPubkeys: signers,
+				Memo:          []byte(text),
+			}),
+		}, nil
+	}
+}
+
+// validateMemo checks that the memo text is a valid UTF-8 string within the length limit.
+func validateMemo(text string) error {
+	if !utf8.ValidString(text) {
+		return fmt.Errorf("memo text must be a valid UTF-8 string")
+	}
+	if len(text) > MemoMaxLength {
+		return fmt.Errorf("memo text must not exceed %d bytes, got %d", MemoMaxLength, len(text))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package instructions_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoWithSigners(t *testing.T) {
+	signer1 := types.NewAccount().PublicKey
+	signer2 := types.NewAccount().PublicKey
+
+	instr, err := instructions.MemoWithSigners("hello", signer1, signer2)(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	assert.Equal(t, common.MemoProgramID, instr[0].ProgramID)
+	assert.Equal(t, []byte("hello"), instr[0].Data)
+	require.Len(t, instr[0].Accounts, 2)
+	for i, signer := range []common.PublicKey{signer1, signer2} {
+		assert.Equal(t, signer, instr[0].Accounts[i].PubKey)
+		assert.True(t, instr[0].Accounts[i].IsSigner)
+		assert.False(t, instr[0].Accounts[i].IsWritable)
+	}
+}
+
+func TestMemoWithSigners_Validation(t *testing.T) {
+	signer := types.NewAccount().PublicKey
+
+	tests := []struct {
+		name    string
+		text    string
+		signers []common.PublicKey
+	}{
+		{name: "empty text", text: "", signers: []common.PublicKey{signer}},
+		{name: "invalid utf-8", text: string([]byte{0xff, 0xfe}), signers: []common.PublicKey{signer}},
+		{name: "too long", text: strings.Repeat("a", instructions.MemoMaxLength+1), signers: []common.PublicKey{signer}},
+		{name: "no signers", text: "hello"},
+		{name: "empty signer", text: "hello", signers: []common.PublicKey{{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := instructions.MemoWithSigners(tt.text, tt.signers...)(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := instructions.MemoWithSigners(strings.Repeat("a", instructions.MemoMaxLength), signer)(context.Background(), nil)
+	assert.NoError(t, err)
+}
+
+func TestMemo_WithoutSigners(t *testing.T) {
+	instr, err := instructions.Memo("hello")(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+	assert.Empty(t, instr[0].Accounts)
+}
+
+func TestMemo_Validation(t *testing.T) {
+	// multibyte characters are counted in bytes
+	multibyte := strings.Repeat("ж", instructions.MemoMaxLength/2)
+	instr, err := instructions.Memo(multibyte)(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+	assert.Equal(t, []byte(multibyte), instr[0].Data)
+
+	_, err = instructions.Memo(multibyte+"ж")(context.Background(), nil)
+	require.ErrorContains(t, err, "must not exceed 566 bytes, got 568")
+
+	_, err = instructions.Memo(strings.Repeat("a", instructions.MemoMaxLength+1))(context.Background(), nil)
+	require.Error(t, err)
+
+	_, err = instructions.Memo(string([]byte{0xff, 0xfe}))(context.Background(), nil)
+	require.ErrorContains(t, err, "valid UTF-8")
+}
@@ -3,10 +3,10 @@ package instructions
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/dmitrymomot/solana/metadata"
 	"github.com/dmitrymomot/solana/token_metadata"
+	typesx "github.com/dmitrymomot/solana/types"
 	"github.com/dmitrymomot/solana/utils"
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/program/associated_token_account"
@@ -22,12 +22,17 @@ type MintFungibleParam struct {
 	MintTo   common.PublicKey  // required; The wallet to mint tokens to
 	FeePayer *common.PublicKey // optional; The wallet to pay the fees from; default is MintTo
 
-	Decimals      uint8  // required; The number of decimals the token has
+	Decimals      uint8  // required; The number of decimals the token has, up to 9; the client default decimals are used if it's out of range
 	SupplyAmount  uint64 // required; The init supply of the token (in token minimal units), e.g: if you want to mint 10 tokens and decimals=9, amount=10*1e9/amount=10000000000; default is 0, then no tokens will be minted
 	IsFixedSupply bool   // required; Whether the token has a fixed supply or not. If true, you cannot mint more tokens.
+	SupplyCap     uint64 // optional; The maximum supply of the token (in token minimal units); SupplyAmount must not exceed it. The cap isn't stored on-chain: the mint authority is revoked once SupplyAmount reaches the cap, otherwise it's kept, so the issuer is responsible for respecting the cap on further minting; default is 0, no cap
 	MetadataURI   string // optional; URI of the token metadata; can be set later
 	TokenName     string // optional; Name of the token; used for the token metadata if MetadataURI is not set.
 	TokenSymbol   string // optional; Symbol of the token; used for the token metadata if MetadataURI is not set.
+
+	DisableFreezeAuthority bool // optional; Whether to create the mint without the freeze authority, so the token accounts can never be frozen; independent of IsFixedSupply and SupplyCap; default is false, the freeze authority is MintTo
+
+	TokenProgram *common.PublicKey // optional; The token program to create the mint with, e.g. the Token-2022 program; default is the classic SPL Token program
 }
 
 // Validate checks that the required fields of the params are set.
@@ -38,7 +43,10 @@ func (p MintFungibleParam) Validate() error {
 	if p.MintTo == (common.PublicKey{}) {
 		return fmt.Errorf("field MintTo is required")
 	}
-	if p.MetadataURI != "" && !strings.HasPrefix(p.MetadataURI, "http") {
+	if p.SupplyCap > 0 && p.SupplyAmount > p.SupplyCap {
+		return fmt.Errorf("field SupplyAmount must not exceed SupplyCap")
+	}
+	if p.MetadataURI != "" && !metadata.IsSupportedURI(p.MetadataURI) {
 		return fmt.Errorf("field MetadataURI must be a valid URI")
 	}
 	if p.FeePayer != nil && *p.FeePayer == (common.PublicKey{}) {
@@ -53,15 +61,25 @@ func (p MintFungibleParam) Validate() error {
 	if p.TokenSymbol != "" && (len(p.TokenSymbol) < 3 || len(p.TokenSymbol) > 10) {
 		return fmt.Errorf("token symbol must be between 3 and 10 characters")
 	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
 // MintFungible creates instructions for minting fungible tokens or assets.
 // The token mint account must be created before calling this function.
 // To mint common fungible tokens, decimals must be greater than 0.
-// If decimals is 0, the token is fungible asset.
+// If decimals is 0, the token is fungible asset, see MintFungibleAsset.
+// The client default decimals are used if decimals is out of range.
+// The token standard can't be set explicitly: the token metadata program infers it on-chain
+// from the mint, Fungible if decimals > 0, FungibleAsset otherwise.
 func MintFungible(params MintFungibleParam) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if params.Decimals > typesx.SPLTokenMaxDecimals {
+			params.Decimals = c.DefaultDecimals()
+		}
+
 		if err := params.Validate(); err != nil {
 			return nil, fmt.Errorf("invalid params: %w", err)
 		}
@@ -77,7 +95,7 @@ func MintFungible(params MintFungibleParam) InstructionFunc {
 
 		var metadataV2 metaplex_token_metadata.DataV2
 		if params.MetadataURI != "" {
-			md, err := metadata.MetadataFromURI(params.MetadataURI)
+			md, err := metadata.MetadataFromURIWithContext(ctx, nil, params.MetadataURI)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get metadata from URI: %w", err)
 			}
@@ -101,25 +119,32 @@ func MintFungible(params MintFungibleParam) InstructionFunc {
 			}
 		}
 
+		freezeAuth := utils.Pointer(params.MintTo)
+		if params.DisableFreezeAuthority {
+			freezeAuth = nil
+		}
+
 		rentExemption, err := c.GetMinimumBalanceForRentExemption(ctx, token.MintAccountSize)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get minimum balance for rent exemption: %w", err)
 		}
 
+		tokenProgram := tokenProgramOrDefault(params.TokenProgram)
+
 		instructions := []types.Instruction{
 			system.CreateAccount(system.CreateAccountParam{
 				From:     *params.FeePayer,
 				New:      params.Mint,
-				Owner:    common.TokenProgramID,
+				Owner:    tokenProgram,
 				Lamports: rentExemption,
 				Space:    token.MintAccountSize,
 			}),
-			token.InitializeMint2(token.InitializeMint2Param{
+			withTokenProgram(token.InitializeMint2(token.InitializeMint2Param{
 				Decimals:   params.Decimals,
 				Mint:       params.Mint,
 				MintAuth:   params.MintTo,
-				FreezeAuth: utils.Pointer(params.MintTo),
-			}),
+				FreezeAuth: freezeAuth,
+			}), tokenProgram),
 			metaplex_token_metadata.CreateMetadataAccountV3(metaplex_token_metadata.CreateMetadataAccountV3Param{
 				Metadata:                metaPubkey,
 				Mint:                    params.Mint,
@@ -133,52 +158,114 @@ func MintFungible(params MintFungibleParam) InstructionFunc {
 		}
 
 		if params.SupplyAmount > 0 {
-			ownerAta, _, err := common.FindAssociatedTokenAddress(params.MintTo, params.Mint)
+			ownerAta, err := findTokenProgramAssociatedAddress(params.MintTo, params.Mint, tokenProgram)
 			if err != nil {
 				return nil, fmt.Errorf("failed to find associated token address: %w", err)
 			}
 
+			createAta := associated_token_account.CreateAssociatedTokenAccount(
+				associated_token_account.CreateAssociatedTokenAccountParam{
+					Funder:                 *params.FeePayer,
+					Owner:                  params.MintTo,
+					Mint:                   params.Mint,
+					AssociatedTokenAccount: ownerAta,
+				},
+			)
+			// the sdk always passes the classic token program as the 6th account
+			createAta.Accounts[5].PubKey = tokenProgram
+
 			instructions = append(
 				instructions,
-				associated_token_account.CreateAssociatedTokenAccount(
-					associated_token_account.CreateAssociatedTokenAccountParam{
-						Funder:                 *params.FeePayer,
-						Owner:                  params.MintTo,
-						Mint:                   params.Mint,
-						AssociatedTokenAccount: ownerAta,
-					},
-				),
-				token.MintToChecked(token.MintToCheckedParam{
+				createAta,
+				withTokenProgram(token.MintToChecked(token.MintToCheckedParam{
 					Mint:     params.Mint,
 					Auth:     params.MintTo,
 					Signers:  []common.PublicKey{},
 					To:       ownerAta,
 					Amount:   params.SupplyAmount,
 					Decimals: params.Decimals,
-				}),
+				}), tokenProgram),
 			)
 		}
 
-		if params.IsFixedSupply && params.SupplyAmount > 0 {
-			instructions = append(instructions, token.SetAuthority(token.SetAuthorityParam{
+		// the cap is reached, so no more tokens can be minted
+		capReached := params.SupplyCap > 0 && params.SupplyAmount == params.SupplyCap
+		if (params.IsFixedSupply || capReached) && params.SupplyAmount > 0 {
+			instructions = append(instructions, withTokenProgram(token.SetAuthority(token.SetAuthorityParam{
 				Account:  params.Mint,
 				AuthType: token.AuthorityTypeMintTokens,
 				Auth:     params.MintTo,
 				NewAuth:  nil,
 				Signers:  []common.PublicKey{},
-			}))
+			}), tokenProgram))
 		}
 
 		return instructions, nil
 	}
 }
 
+// MintFungibleAssetParam defines the parameters for the MintFungibleAsset instruction.
+type MintFungibleAssetParam struct {
+	Mint     common.PublicKey  // required; The token mint public key
+	MintTo   common.PublicKey  // required; The wallet to mint assets to
+	FeePayer *common.PublicKey // optional; The wallet to pay the fees from; default is MintTo
+
+	SupplyAmount  uint64 // optional; The init supply of the asset; default is 0, then no assets will be minted
+	IsFixedSupply bool   // optional; Whether the asset has a fixed supply or not. If true, you cannot mint more assets.
+	SupplyCap     uint64 // optional; The maximum supply of the asset, see MintFungibleParam.SupplyCap; default is 0, no cap
+	MetadataURI   string // optional; URI of the asset metadata; can be set later
+	TokenName     string // optional; Name of the asset; used for the asset metadata if MetadataURI is not set.
+	TokenSymbol   string // optional; Symbol of the asset; used for the asset metadata if MetadataURI is not set.
+
+	DisableFreezeAuthority bool // optional; Whether to create the mint without the freeze authority; default is false, the freeze authority is MintTo
+
+	TokenProgram *common.PublicKey // optional; The token program to create the mint with, e.g. the Token-2022 program; default is the classic SPL Token program
+}
+
+// Validate checks that the required fields of the params are set.
+func (p MintFungibleAssetParam) Validate() error {
+	return p.mintFungibleParam().Validate()
+}
+
+// mintFungibleParam returns the MintFungible parameters of the fungible asset.
+func (p MintFungibleAssetParam) mintFungibleParam() MintFungibleParam {
+	return MintFungibleParam{
+		Mint:          p.Mint,
+		MintTo:        p.MintTo,
+		FeePayer:      p.FeePayer,
+		Decimals:      0,
+		SupplyAmount:  p.SupplyAmount,
+		IsFixedSupply: p.IsFixedSupply,
+		SupplyCap:     p.SupplyCap,
+		MetadataURI:   p.MetadataURI,
+		TokenName:     p.TokenName,
+		TokenSymbol:   p.TokenSymbol,
+
+		DisableFreezeAuthority: p.DisableFreezeAuthority,
+		TokenProgram:           p.TokenProgram,
+	}
+}
+
+// MintFungibleAsset creates instructions for minting a semi-fungible asset, e.g. a game item,
+// which has a supply of indivisible units and the token metadata.
+// The mint has 0 decimals, so the token metadata program records the FungibleAsset token standard
+// on CreateMetadataAccountV3, which has no token standard argument.
+// The token mint account must be created before calling this function.
+func MintFungibleAsset(params MintFungibleAssetParam) InstructionFunc {
+	return MintFungible(params.mintFungibleParam())
+}
+
 // MintExistedFungibleParam is a parameter for MintExistedFungible.
 type MintExistedFungibleParam struct {
 	Mint         common.PublicKey  // required; The token mint public key
 	MintTo       common.PublicKey  // required; The wallet to mint tokens to
 	FeePayer     *common.PublicKey // optional; The wallet to pay the fees from; default is MintTo
 	SupplyAmount uint64            // required; The init supply of the token (in token minimal units), e.g: if you want to mint 10 tokens and decimals=9, amount=10*1e9/amount=10000000000; default is 0, then no tokens will be minted
+
+	MintAuthority   *common.PublicKey  // optional; The mint authority, a wallet or a multisig account; default is MintTo
+	MultisigSigners []common.PublicKey // optional; The signers of the multisig mint authority; must be set if MintAuthority is a multisig account
+
+	TokenProgram *common.PublicKey // optional; The token program which owns the mint, e.g. the Token-2022 program; default is detected from the mint if the client supports it, otherwise the classic SPL Token program
 }
 
 // Validate validates the parameter.
@@ -195,6 +282,15 @@ func (p MintExistedFungibleParam) Validate() error {
 	if p.SupplyAmount == 0 {
 		return fmt.Errorf("supply amount is required")
 	}
+	if p.MintAuthority != nil && *p.MintAuthority == (common.PublicKey{}) {
+		return fmt.Errorf("mint authority public key is invalid")
+	}
+	if err := validateMultisigSigners(p.MultisigSigners); err != nil {
+		return err
+	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -203,21 +299,31 @@ func (p MintExistedFungibleParam) Validate() error {
 // If the token is fixed supply, this instruction will fail.
 func MintExistedFungible(params MintExistedFungibleParam) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
 		if params.FeePayer == nil {
 			params.FeePayer = &params.MintTo
 		}
-		ownerAta, _, err := common.FindAssociatedTokenAddress(params.MintTo, params.Mint)
+		if params.MintAuthority == nil {
+			params.MintAuthority = &params.MintTo
+		}
+		tokenProgram, err := resolveTokenProgram(ctx, c, params.TokenProgram, params.Mint)
+		if err != nil {
+			return nil, err
+		}
+		ownerAta, err := findTokenProgramAssociatedAddress(params.MintTo, params.Mint, tokenProgram)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find associated token address: %w", err)
 		}
 		instructions := []types.Instruction{
-			token.MintTo(token.MintToParam{
+			withTokenProgram(token.MintTo(token.MintToParam{
 				Mint:    params.Mint,
-				Auth:    params.MintTo,
-				Signers: []common.PublicKey{},
+				Auth:    *params.MintAuthority,
+				Signers: params.MultisigSigners,
 				To:      ownerAta,
 				Amount:  params.SupplyAmount,
-			}),
+			}), tokenProgram),
 		}
 		return instructions, nil
 	}
@@ -226,8 +332,12 @@ func MintExistedFungible(params MintExistedFungibleParam) InstructionFunc {
 // DisableFungibleTokenMintingParam is a parameter for DisableFungibleTokenMinting.
 type DisableFungibleTokenMintingParam struct {
 	Mint     common.PublicKey  // required; The token mint public key
-	MintAuth common.PublicKey  // required; The mint authority
+	MintAuth common.PublicKey  // required; The mint authority, a wallet or a multisig account
 	FeePayer *common.PublicKey // optional; The wallet to pay the fees from; default is MintAuth
+
+	MultisigSigners []common.PublicKey // optional; The signers of the multisig mint authority; must be set if MintAuth is a multisig account
+
+	TokenProgram *common.PublicKey // optional; The token program which owns the mint, e.g. the Token-2022 program; default is detected from the mint if the client supports it, otherwise the classic SPL Token program
 }
 
 // Validate validates the parameter.
@@ -241,6 +351,12 @@ func (p DisableFungibleTokenMintingParam) Validate() error {
 	if p.FeePayer != nil && *p.FeePayer == (common.PublicKey{}) {
 		return fmt.Errorf("fee payer public key is invalid")
 	}
+	if err := validateMultisigSigners(p.MultisigSigners); err != nil {
+		return err
+	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -248,17 +364,24 @@ func (p DisableFungibleTokenMintingParam) Validate() error {
 // After freezing, no more tokens can be minted.
 func DisableFungibleTokenMinting(params DisableFungibleTokenMintingParam) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
 		if params.FeePayer == nil {
 			params.FeePayer = &params.MintAuth
 		}
+		tokenProgram, err := resolveTokenProgram(ctx, c, params.TokenProgram, params.Mint)
+		if err != nil {
+			return nil, err
+		}
 		instructions := []types.Instruction{
-			token.SetAuthority(token.SetAuthorityParam{
+			withTokenProgram(token.SetAuthority(token.SetAuthorityParam{
 				Account:  params.Mint,
 				AuthType: token.AuthorityTypeMintTokens,
 				Auth:     params.MintAuth,
 				NewAuth:  nil,
-				Signers:  []common.PublicKey{},
-			}),
+				Signers:  params.MultisigSigners,
+			}), tokenProgram),
 		}
 		return instructions, nil
 	}
@@ -0,0 +1,207 @@
+package instructions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMintFungibleAsset(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+
+	instr, err := instructions.MintFungibleAsset(instructions.MintFungibleAssetParam{
+		Mint:          mint,
+		MintTo:        owner,
+		SupplyAmount:  100,
+		IsFixedSupply: true,
+		TokenName:     "Sword",
+		TokenSymbol:   "SWORD",
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	// create mint account, initialize mint, create metadata, create ata, mint to, disable minting
+	require.Len(t, instr, 6)
+
+	// initialize mint: instruction, decimals
+	initMint := instr[1]
+	assert.Equal(t, common.TokenProgramID, initMint.ProgramID)
+	assert.Equal(t, byte(token.InstructionInitializeMint2), initMint.Data[0])
+	assert.Equal(t, byte(0), initMint.Data[1])
+
+	// the token metadata program records the FungibleAsset token standard for the 0 decimals mint without edition
+	createMetadata := instr[2]
+	assert.Equal(t, common.MetaplexTokenMetaProgramID, createMetadata.ProgramID)
+	assert.Equal(t, byte(metaplex_token_metadata.InstructionCreateMetadataAccountV3), createMetadata.Data[0])
+	assert.Equal(t, byte(0), createMetadata.Data[len(createMetadata.Data)-1]) // no collection details
+	for _, in := range instr {
+		if in.ProgramID == common.MetaplexTokenMetaProgramID {
+			assert.NotEqual(t, byte(metaplex_token_metadata.InstructionCreateMasterEditionV3), in.Data[0])
+		}
+	}
+
+	// mint to checked: instruction, amount, decimals
+	mintTo := instr[4]
+	assert.Equal(t, byte(token.InstructionMintToChecked), mintTo.Data[0])
+	assert.Equal(t, byte(0), mintTo.Data[9])
+
+	_, err = instructions.MintFungibleAsset(instructions.MintFungibleAssetParam{
+		Mint:   mint,
+		MintTo: owner,
+	})(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
+
+func TestMintFungible_DefaultDecimals(t *testing.T) {
+	defaultDecimals := uint8(6)
+
+	tests := []struct {
+		name     string
+		decimals uint8
+		want     uint8
+	}{
+		{"given decimals", 2, 2},
+		{"out of range", 12, defaultDecimals},
+		{"fungible asset", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instr, err := instructions.MintFungible(instructions.MintFungibleParam{
+				Mint:         types.NewAccount().PublicKey,
+				MintTo:       types.NewAccount().PublicKey,
+				Decimals:     tt.decimals,
+				SupplyAmount: 100,
+				TokenName:    "Token",
+				TokenSymbol:  "TKN",
+			})(context.Background(), &mockClient{decimals: &defaultDecimals})
+			require.NoError(t, err)
+
+			// initialize mint: instruction, decimals
+			assert.Equal(t, byte(token.InstructionInitializeMint2), instr[1].Data[0])
+			assert.Equal(t, tt.want, instr[1].Data[1])
+			// mint to checked: instruction, amount, decimals
+			assert.Equal(t, byte(token.InstructionMintToChecked), instr[4].Data[0])
+			assert.Equal(t, tt.want, instr[4].Data[9])
+		})
+	}
+}
+
+func TestMintNonFungible_Creators(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	feePayer := types.NewAccount().PublicKey
+	artist := types.NewAccount().PublicKey
+	params := instructions.MintNonFungibleParam{
+		Mint:        types.NewAccount().PublicKey,
+		Owner:       owner,
+		FeePayer:    &feePayer,
+		TokenName:   "NFT",
+		TokenSymbol: "NFT",
+	}
+
+	params.Creators = &[]instructions.Creator{{Address: artist, Share: 100}}
+	require.ErrorContains(t, params.Validate(), "must be one of the creators")
+
+	params.Creators = &[]instructions.Creator{{Address: artist, Share: 60}, {Address: feePayer, Share: 30}}
+	require.ErrorContains(t, params.Validate(), "creators share must be 100, got 90")
+
+	params.Creators = &[]instructions.Creator{{Address: artist, Share: 70}, {Address: feePayer, Share: 30}}
+	require.NoError(t, params.Validate())
+
+	// the default creators list if no creators are set
+	params.Creators = nil
+	instr, err := instructions.MintNonFungible(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	data := decodeCreateMetadataData(t, instr[2])
+	require.NotNil(t, data.Creators)
+	assert.Equal(t, []metaplex_token_metadata.Creator{
+		{Address: owner, Verified: true, Share: 100},
+		{Address: feePayer, Verified: false, Share: 0},
+	}, *data.Creators)
+}
+
+// decodeCreateMetadataData decodes the data argument of the CreateMetadataAccountV3 instruction.
+func decodeCreateMetadataData(t *testing.T, instr types.Instruction) metaplex_token_metadata.DataV2 {
+	t.Helper()
+	require.Equal(t, common.MetaplexTokenMetaProgramID, instr.ProgramID)
+
+	var args struct {
+		Instruction       uint8
+		Data              metaplex_token_metadata.DataV2
+		IsMutable         bool
+		CollectionDetails *metaplex_token_metadata.CollectionDetails
+	}
+	require.NoError(t, borsh.Deserialize(&args, instr.Data))
+	require.Equal(t, uint8(metaplex_token_metadata.InstructionCreateMetadataAccountV3), args.Instruction)
+	return args.Data
+}
+
+func TestMintFungible_SupplyCap(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	params := instructions.MintFungibleParam{
+		Mint:         mint,
+		MintTo:       owner,
+		Decimals:     2,
+		SupplyAmount: 1000,
+		SupplyCap:    999,
+		TokenName:    "Token",
+		TokenSymbol:  "TKN",
+	}
+	require.Error(t, params.Validate())
+	_, err := instructions.MintFungible(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+
+	// below the cap: the mint authority is kept
+	params.SupplyCap = 2000
+	require.NoError(t, params.Validate())
+	instr, err := instructions.MintFungible(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	// create mint account, initialize mint, create metadata, create ata, mint to
+	require.Len(t, instr, 5)
+
+	// the cap is reached: the mint authority is revoked, the freeze authority is kept
+	params.SupplyCap = 1000
+	instr, err = instructions.MintFungible(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 6)
+	setAuthority := instr[5]
+	assert.Equal(t, byte(token.InstructionSetAuthority), setAuthority.Data[0])
+	assert.Equal(t, byte(token.AuthorityTypeMintTokens), setAuthority.Data[1])
+	assert.Equal(t, byte(0), setAuthority.Data[2]) // no new authority
+	// initialize mint: instruction, decimals, mint authority, freeze authority option, freeze authority
+	initMint := instr[1]
+	assert.Equal(t, byte(1), initMint.Data[34])
+	assert.Equal(t, owner.Bytes(), initMint.Data[35:67])
+}
+
+func TestMintFungible_DisableFreezeAuthority(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	params := instructions.MintFungibleParam{
+		Mint:         types.NewAccount().PublicKey,
+		MintTo:       owner,
+		Decimals:     2,
+		SupplyAmount: 1000,
+		TokenName:    "Token",
+		TokenSymbol:  "TKN",
+	}
+
+	instr, err := instructions.MintFungible(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 5)
+	assert.Equal(t, byte(1), instr[1].Data[34])
+
+	// the freeze authority is disabled without revoking the mint authority
+	params.DisableFreezeAuthority = true
+	instr, err = instructions.MintFungible(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 5)
+	assert.Equal(t, byte(0), instr[1].Data[34])
+	assert.Equal(t, owner.Bytes(), instr[1].Data[2:34])
+}
@@ -3,7 +3,6 @@ package instructions
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/dmitrymomot/solana/metadata"
 	"github.com/dmitrymomot/solana/token_metadata"
@@ -23,7 +22,7 @@ type MintNonFungibleParam struct {
 	FeePayer            *common.PublicKey // optional; The wallet to pay the fees from; default is Owner
 	Collection          *common.PublicKey // optional; The collection mint public key
 	CollectionAuthority *common.PublicKey // optional; The collection authority; default is Owner
-	Creators            *[]Creator        // optional; The creators of the token; FeePayer must be one of the creators; Default is mintTo:100 & FeePayer:0
+	Creators            *[]Creator        // optional; The creators of the token; shares must sum up to 100; FeePayer must be one of the creators, it's not appended implicitly anymore; default is Owner:100, plus FeePayer:0 if it differs from Owner
 
 	MaxEditionSupply     uint64  // optional; The max print edition supply; default is 0
 	MetadataURI          string  // optional; URI of the token metadata; can be set later
@@ -44,7 +43,7 @@ func (p MintNonFungibleParam) Validate() error {
 	if p.Owner == (common.PublicKey{}) {
 		return fmt.Errorf("field Owner is required")
 	}
-	if p.MetadataURI != "" && !strings.HasPrefix(p.MetadataURI, "http") {
+	if p.MetadataURI != "" && !metadata.IsSupportedURI(p.MetadataURI) {
 		return fmt.Errorf("field MetadataURI must be a valid URI")
 	}
 	if p.FeePayer != nil && *p.FeePayer == (common.PublicKey{}) {
@@ -68,10 +67,35 @@ func (p MintNonFungibleParam) Validate() error {
 	if p.UseMethod != nil && !p.UseMethod.Valid() {
 		return fmt.Errorf("invalid use method")
 	}
+	if p.Creators != nil {
+		if err := validateCreators(*p.Creators); err != nil {
+			return err
+		}
+
+		feePayer := p.Owner
+		if p.FeePayer != nil {
+			feePayer = *p.FeePayer
+		}
+		feePayerInCreators := false
+		for _, creator := range *p.Creators {
+			if creator.Address == feePayer {
+				feePayerInCreators = true
+				break
+			}
+		}
+		if !feePayerInCreators {
+			return fmt.Errorf("fee payer %s must be one of the creators", feePayer.ToBase58())
+		}
+	}
 	return nil
 }
 
 // MintNonFungible creates instructions for minting fungible tokens.
+// The token standard can't be set explicitly: the token metadata program infers it on-chain
+// and records NonFungible once the master edition is created.
+// Breaking change: the explicitly set creators must include the fee payer.
+// Earlier versions appended it with 0 share, now the params validation fails instead,
+// so add the fee payer to Creators or leave Creators nil to get the default creators list.
 func MintNonFungible(params MintNonFungibleParam) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
 		if err := params.Validate(); err != nil {
@@ -108,7 +132,7 @@ func MintNonFungible(params MintNonFungibleParam) InstructionFunc {
 		}
 
 		if params.MetadataURI != "" {
-			md, err := metadata.MetadataFromURI(params.MetadataURI)
+			md, err := metadata.MetadataFromURIWithContext(ctx, nil, params.MetadataURI)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get metadata from URI: %w", err)
 			}
@@ -142,34 +166,14 @@ func MintNonFungible(params MintNonFungibleParam) InstructionFunc {
 		// Preparing of NFT creators list
 		if params.Creators != nil {
 			creators := make([]metaplex_token_metadata.Creator, 0, len(*params.Creators))
-			totalShare := uint8(0)
-			feePayerInCreators := false
 			for _, creator := range *params.Creators {
-				if creator.Address.ToBase58() == params.FeePayer.ToBase58() {
-					feePayerInCreators = true
-				}
-				totalShare += creator.Share
-				creators = append(creators, metaplex_token_metadata.Creator{
-					Address: creator.Address,
-					Share:   creator.Share,
-					Verified: func() bool {
-						return creator.Address.ToBase58() == params.Owner.ToBase58()
-					}(),
-				})
-			}
-
-			if !feePayerInCreators {
 				creators = append(creators, metaplex_token_metadata.Creator{
-					Address:  *params.FeePayer,
-					Share:    0,
-					Verified: false,
+					Address:  creator.Address,
+					Share:    creator.Share,
+					Verified: creator.Address == params.Owner,
 				})
 			}
 
-			if totalShare != 100 {
-				return nil, fmt.Errorf("creators share must be 100, got %d", totalShare)
-			}
-
 			metadataV2.Creators = &creators
 		} else {
 			creators := []metaplex_token_metadata.Creator{{
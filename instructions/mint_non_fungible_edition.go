@@ -85,7 +85,7 @@ func MintNonFungibleEdition(params MintNonFungibleEditionParam) InstructionFunc
 			return nil, fmt.Errorf("failed to get master edition supply: %w", err)
 		}
 		if current >= max {
-			return nil, fmt.Errorf("master edition supply is already at max")
+			return nil, fmt.Errorf("master edition supply is already at max: %d/%d editions printed", current, max)
 		}
 
 		editionNumber := current + 1
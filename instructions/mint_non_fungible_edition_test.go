@@ -0,0 +1,93 @@
+package instructions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMintNonFungibleEdition_Supply(t *testing.T) {
+	params := instructions.MintNonFungibleEditionParam{
+		FeePayer:           types.NewAccount().PublicKey,
+		MasterEditionMint:  types.NewAccount().PublicKey,
+		MasterEditionOwner: types.NewAccount().PublicKey,
+		EditionMint:        types.NewAccount().PublicKey,
+	}
+
+	c := &mockClient{masterEdition: &token_metadata.Edition{Supply: 3, MaxSupply: 10}}
+	instr, err := instructions.MintNonFungibleEdition(params)(context.Background(), c)
+	require.NoError(t, err)
+	require.NotEmpty(t, instr)
+
+	// the edition marker of the 4th edition is used
+	marker, err := token_metadata.DeriveEditionMarkerPubkey(params.MasterEditionMint, 4)
+	require.NoError(t, err)
+	last := instr[len(instr)-1]
+	assert.Equal(t, common.MetaplexTokenMetaProgramID, last.ProgramID)
+	assert.Contains(t, accountsOf(last), marker)
+
+	// fully printed master edition
+	c = &mockClient{masterEdition: &token_metadata.Edition{Supply: 10, MaxSupply: 10}}
+	_, err = instructions.MintNonFungibleEdition(params)(context.Background(), c)
+	require.ErrorContains(t, err, "already at max")
+
+	// unlimited supply master edition
+	c = &mockClient{masterEdition: &token_metadata.Edition{Supply: 10, UnlimitedSupply: true}}
+	_, err = instructions.MintNonFungibleEdition(params)(context.Background(), c)
+	require.NoError(t, err)
+
+	// not a master edition
+	_, err = instructions.MintNonFungibleEdition(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
+
+func accountsOf(instr types.Instruction) []common.PublicKey {
+	result := make([]common.PublicKey, 0, len(instr.Accounts))
+	for _, acc := range instr.Accounts {
+		result = append(result, acc.PubKey)
+	}
+	return result
+}
+
+func TestMintNonFungibleEdition_EditionOwner(t *testing.T) {
+	params := instructions.MintNonFungibleEditionParam{
+		FeePayer:           types.NewAccount().PublicKey,
+		MasterEditionMint:  types.NewAccount().PublicKey,
+		MasterEditionOwner: types.NewAccount().PublicKey,
+		EditionMint:        types.NewAccount().PublicKey,
+	}
+	c := &mockClient{masterEdition: &token_metadata.Edition{Supply: 0, MaxSupply: 10}}
+
+	tests := []struct {
+		name  string
+		owner common.PublicKey
+		want  common.PublicKey
+	}{
+		{name: "edition owner is not set", want: params.MasterEditionOwner},
+		{name: "edition owner is set", owner: params.FeePayer, want: params.FeePayer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := params
+			p.EditionOwner = tt.owner
+
+			instr, err := instructions.MintNonFungibleEdition(p)(context.Background(), c)
+			require.NoError(t, err)
+
+			ata, _, err := common.FindAssociatedTokenAddress(tt.want, p.EditionMint)
+			require.NoError(t, err)
+
+			// create associated token account: funder, ata, owner, mint, ...
+			require.GreaterOrEqual(t, len(instr), 3)
+			assert.Equal(t, common.SPLAssociatedTokenAccountProgramID, instr[2].ProgramID)
+			assert.Equal(t, ata, instr[2].Accounts[1].PubKey)
+			assert.Equal(t, tt.want, instr[2].Accounts[2].PubKey)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package instructions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/system"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// CreateTokenMultisigParams is the params for creating an SPL token multisig account.
+type CreateTokenMultisigParams struct {
+	FeePayer   common.PublicKey   // required; The wallet to pay the fees and the multisig account rent from.
+	Multisig   common.PublicKey   // required; The new multisig account public key; must sign the transaction.
+	Signers    []common.PublicKey // required; The multisig members; from 1 to 11 public keys.
+	MinSigners uint8              // required; The number of members required to sign (M of N).
+}
+
+// Validate validates the params.
+func (p CreateTokenMultisigParams) Validate() error {
+	if p.FeePayer == (common.PublicKey{}) {
+		return fmt.Errorf("fee payer is required")
+	}
+	if p.Multisig == (common.PublicKey{}) {
+		return fmt.Errorf("multisig is required")
+	}
+	if len(p.Signers) == 0 || len(p.Signers) > token.MaxSigners {
+		return fmt.Errorf("number of signers must be between 1 and %d", token.MaxSigners)
+	}
+	if p.MinSigners == 0 || int(p.MinSigners) > len(p.Signers) {
+		return fmt.Errorf("min signers must be between 1 and the number of signers")
+	}
+	seen := make(map[common.PublicKey]struct{}, len(p.Signers))
+	for _, signer := range p.Signers {
+		if signer == (common.PublicKey{}) {
+			return fmt.Errorf("invalid signer public key")
+		}
+		if _, ok := seen[signer]; ok {
+			return fmt.Errorf("duplicate signer: %s", signer.ToBase58())
+		}
+		seen[signer] = struct{}{}
+	}
+	return nil
+}
+
+// CreateTokenMultisig creates an SPL token multisig account,
+// which can be used as a mint or freeze authority requiring M of N signatures.
+func CreateTokenMultisig(params CreateTokenMultisigParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("create token multisig: %w", err)
+		}
+
+		rentExemption, err := c.GetMinimumBalanceForRentExemption(ctx, token.MultisigAccountSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get minimum balance for rent exemption: %w", err)
+		}
+
+		return []types.Instruction{
+			system.CreateAccount(system.CreateAccountParam{
+				From:     params.FeePayer,
+				New:      params.Multisig,
+				Owner:    common.TokenProgramID,
+				Lamports: rentExemption,
+				Space:    token.MultisigAccountSize,
+			}),
+			token.InitializeMultisig(token.InitializeMultisigParam{
+				Account:     params.Multisig,
+				Signers:     params.Signers,
+				MinRequired: params.MinSigners,
+			}),
+		}, nil
+	}
+}
+
+// validateMultisigSigners validates the signers of a multisig authority.
+func validateMultisigSigners(signers []common.PublicKey) error {
+	if len(signers) > token.MaxSigners {
+		return fmt.Errorf("too many multisig signers; max is %d", token.MaxSigners)
+	}
+	for _, signer := range signers {
+		if signer == (common.PublicKey{}) {
+			return fmt.Errorf("invalid multisig signer public key")
+		}
+	}
+	return nil
+}
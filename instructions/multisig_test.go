@@ -0,0 +1,154 @@
+package instructions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTokenMultisig(t *testing.T) {
+	feePayer := types.NewAccount().PublicKey
+	multisig := types.NewAccount().PublicKey
+	signers := []common.PublicKey{types.NewAccount().PublicKey, types.NewAccount().PublicKey, types.NewAccount().PublicKey}
+
+	instr, err := instructions.CreateTokenMultisig(instructions.CreateTokenMultisigParams{
+		FeePayer:   feePayer,
+		Multisig:   multisig,
+		Signers:    signers,
+		MinSigners: 2,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 2)
+
+	assert.Equal(t, common.SystemProgramID, instr[0].ProgramID)
+	assert.Equal(t, feePayer, instr[0].Accounts[0].PubKey)
+	assert.Equal(t, multisig, instr[0].Accounts[1].PubKey)
+
+	assert.Equal(t, common.TokenProgramID, instr[1].ProgramID)
+	// instruction, min required signers
+	assert.Equal(t, []byte{byte(token.InstructionInitializeMultisig), 2}, instr[1].Data)
+	require.Len(t, instr[1].Accounts, 2+len(signers))
+	assert.Equal(t, multisig, instr[1].Accounts[0].PubKey)
+	assert.Equal(t, common.SysVarRentPubkey, instr[1].Accounts[1].PubKey)
+	for i, signer := range signers {
+		assert.Equal(t, signer, instr[1].Accounts[2+i].PubKey)
+	}
+}
+
+func TestCreateTokenMultisigParams_Validate(t *testing.T) {
+	signers := []common.PublicKey{types.NewAccount().PublicKey, types.NewAccount().PublicKey}
+	params := instructions.CreateTokenMultisigParams{
+		FeePayer: types.NewAccount().PublicKey,
+		Multisig: types.NewAccount().PublicKey,
+		Signers:  signers,
+	}
+	assert.Error(t, params.Validate())
+
+	params.MinSigners = 3
+	assert.Error(t, params.Validate())
+
+	params.MinSigners = 2
+	assert.NoError(t, params.Validate())
+
+	params.Signers = []common.PublicKey{signers[0], signers[0]}
+	assert.Error(t, params.Validate())
+
+	params.Signers = make([]common.PublicKey, token.MaxSigners+1)
+	assert.Error(t, params.Validate())
+}
+
+func TestMintExistedFungible_Multisig(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	mintTo := types.NewAccount().PublicKey
+	multisig := types.NewAccount().PublicKey
+	signers := []common.PublicKey{types.NewAccount().PublicKey, types.NewAccount().PublicKey}
+
+	params := instructions.MintExistedFungibleParam{
+		Mint:            mint,
+		MintTo:          mintTo,
+		SupplyAmount:    1000,
+		MintAuthority:   &multisig,
+		MultisigSigners: signers,
+	}
+	require.NoError(t, params.Validate())
+
+	instr, err := instructions.MintExistedFungible(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	ata, _, err := common.FindAssociatedTokenAddress(mintTo, mint)
+	require.NoError(t, err)
+
+	// mint, destination, multisig authority, then the 2 of 3 signers
+	require.Len(t, instr[0].Accounts, 5)
+	assert.Equal(t, mint, instr[0].Accounts[0].PubKey)
+	assert.Equal(t, ata, instr[0].Accounts[1].PubKey)
+	assert.Equal(t, types.AccountMeta{PubKey: multisig, IsSigner: false, IsWritable: false}, instr[0].Accounts[2])
+	for i, signer := range signers {
+		assert.Equal(t, types.AccountMeta{PubKey: signer, IsSigner: true, IsWritable: false}, instr[0].Accounts[3+i])
+	}
+}
+
+func TestDisableFungibleTokenMinting_Multisig(t *testing.T) {
+	multisig := types.NewAccount().PublicKey
+	signers := []common.PublicKey{types.NewAccount().PublicKey, types.NewAccount().PublicKey}
+
+	instr, err := instructions.DisableFungibleTokenMinting(instructions.DisableFungibleTokenMintingParam{
+		Mint:            types.NewAccount().PublicKey,
+		MintAuth:        multisig,
+		MultisigSigners: signers,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+	require.Len(t, instr[0].Accounts, 4)
+	assert.False(t, instr[0].Accounts[1].IsSigner)
+	assert.True(t, instr[0].Accounts[2].IsSigner)
+	assert.True(t, instr[0].Accounts[3].IsSigner)
+}
+
+func TestMintExistedFungible_InvalidMultisigSigners(t *testing.T) {
+	multisig := types.NewAccount().PublicKey
+	params := instructions.MintExistedFungibleParam{
+		Mint:            types.NewAccount().PublicKey,
+		MintTo:          types.NewAccount().PublicKey,
+		SupplyAmount:    1000,
+		MintAuthority:   &multisig,
+		MultisigSigners: []common.PublicKey{types.NewAccount().PublicKey, {}},
+	}
+	_, err := instructions.MintExistedFungible(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+
+	params.MultisigSigners = make([]common.PublicKey, token.MaxSigners+1)
+	_, err = instructions.MintExistedFungible(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+
+	params.MultisigSigners = nil
+	params.Mint = common.PublicKey{}
+	_, err = instructions.MintExistedFungible(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
+
+func TestDisableFungibleTokenMinting_InvalidMultisigSigners(t *testing.T) {
+	params := instructions.DisableFungibleTokenMintingParam{
+		Mint:            types.NewAccount().PublicKey,
+		MintAuth:        types.NewAccount().PublicKey,
+		MultisigSigners: []common.PublicKey{types.NewAccount().PublicKey, {}},
+	}
+	_, err := instructions.DisableFungibleTokenMinting(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+
+	params.MultisigSigners = make([]common.PublicKey, token.MaxSigners+1)
+	_, err = instructions.DisableFungibleTokenMinting(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+
+	params.MultisigSigners = nil
+	params.MintAuth = common.PublicKey{}
+	_, err = instructions.DisableFungibleTokenMinting(params)(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package instructions
+
+import (
+	"context"
+	"fmt"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// tokenProgramResolver detects the token program which owns a mint, see client.Client.GetTokenProgramForMint.
+type tokenProgramResolver interface {
+	GetTokenProgramForMint(ctx context.Context, base58MintAddr string) (common.PublicKey, error)
+}
+
+// resolveTokenProgram returns the given token program if it's set.
+// Otherwise, it detects the token program of the existing mint if the client is able to, like client.Client does,
+// or falls back to the classic SPL Token program.
+func resolveTokenProgram(ctx context.Context, c Client, tokenProgram *common.PublicKey, mint common.PublicKey) (common.PublicKey, error) {
+	if tokenProgram != nil {
+		return *tokenProgram, nil
+	}
+
+	resolver, ok := c.(tokenProgramResolver)
+	if !ok {
+		return common.TokenProgramID, nil
+	}
+
+	programID, err := resolver.GetTokenProgramForMint(ctx, mint.ToBase58())
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to detect token program: %w", err)
+	}
+
+	return programID, nil
+}
+
+// tokenProgramOrDefault returns the given token program or the classic SPL Token program if it's not set.
+func tokenProgramOrDefault(tokenProgram *common.PublicKey) common.PublicKey {
+	if tokenProgram == nil {
+		return common.TokenProgramID
+	}
+	return *tokenProgram
+}
+
+// validateTokenProgram checks that the optional token program is one of the supported token programs:
+// the classic SPL Token program or the Token-2022 program.
+func validateTokenProgram(tokenProgram *common.PublicKey) error {
+	if tokenProgram != nil && !commonx.IsTokenProgramID(*tokenProgram) {
+		return fmt.Errorf("unsupported token program: %s", tokenProgram.ToBase58())
+	}
+	return nil
+}
+
+// findTokenProgramAssociatedAddress returns the associated token account of the owner for the mint
+// owned by the given token program.
+func findTokenProgramAssociatedAddress(owner, mint, tokenProgram common.PublicKey) (common.PublicKey, error) {
+	if tokenProgram == common.TokenProgramID {
+		ata, _, err := common.FindAssociatedTokenAddress(owner, mint)
+		return ata, err
+	}
+	return commonx.DeriveTokenAccountPubkeyWithProgram(owner, mint, tokenProgram)
+}
+
+// withTokenProgram routes the token instruction built by the sdk through the given token program.
+// The Token-2022 program keeps the instruction layouts of the classic SPL Token program,
+// while the sdk always sets the classic program ID.
+func withTokenProgram(instruction types.Instruction, tokenProgram common.PublicKey) types.Instruction {
+	instruction.ProgramID = tokenProgram
+	return instruction
+}
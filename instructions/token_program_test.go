@@ -0,0 +1,224 @@
+package instructions_test
+
+import (
+	"context"
+	"testing"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenProgram(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	recipient := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+
+	for name, tokenProgram := range map[string]common.PublicKey{
+		"spl token":  common.TokenProgramID,
+		"token-2022": commonx.Token2022ProgramID,
+	} {
+		tokenProgram := tokenProgram
+		ownerAta, err := commonx.DeriveTokenAccountPubkeyWithProgram(owner, mint, tokenProgram)
+		require.NoError(t, err)
+		recipientAta, err := commonx.DeriveTokenAccountPubkeyWithProgram(recipient, mint, tokenProgram)
+		require.NoError(t, err)
+
+		t.Run(name, func(t *testing.T) {
+			build := func(fn instructions.InstructionFunc) []types.Instruction {
+				t.Helper()
+				instr, err := fn(context.Background(), &mockClient{})
+				require.NoError(t, err)
+				return instr
+			}
+
+			t.Run("mint", func(t *testing.T) {
+				instr := build(instructions.MintFungible(instructions.MintFungibleParam{
+					Mint:          mint,
+					MintTo:        owner,
+					Decimals:      6,
+					SupplyAmount:  100,
+					IsFixedSupply: true,
+					TokenName:     "Token",
+					TokenSymbol:   "TKN",
+					TokenProgram:  &tokenProgram,
+				}))
+				// create mint account, initialize mint, create metadata, create ata, mint to, disable minting
+				require.Len(t, instr, 6)
+				assert.Equal(t, tokenProgram.Bytes(), instr[0].Data[len(instr[0].Data)-32:]) // the mint account owner
+				assert.Equal(t, tokenProgram, instr[1].ProgramID)
+				assert.Equal(t, common.SPLAssociatedTokenAccountProgramID, instr[3].ProgramID)
+				assert.Equal(t, ownerAta, instr[3].Accounts[1].PubKey)
+				assert.Equal(t, tokenProgram, instr[3].Accounts[5].PubKey)
+				assert.Equal(t, tokenProgram, instr[4].ProgramID)
+				assert.Equal(t, ownerAta, instr[4].Accounts[1].PubKey)
+				assert.Equal(t, tokenProgram, instr[5].ProgramID)
+
+				instr = build(instructions.MintExistedFungible(instructions.MintExistedFungibleParam{
+					Mint:         mint,
+					MintTo:       owner,
+					SupplyAmount: 100,
+					TokenProgram: &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, ownerAta, instr[0].Accounts[1].PubKey)
+
+				instr = build(instructions.DisableFungibleTokenMinting(instructions.DisableFungibleTokenMintingParam{
+					Mint:         mint,
+					MintAuth:     owner,
+					TokenProgram: &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, byte(token.InstructionSetAuthority), instr[0].Data[0])
+				assert.Equal(t, []common.PublicKey{mint, owner}, accountsOf(instr[0]))
+			})
+
+			t.Run("transfer", func(t *testing.T) {
+				instr := build(instructions.TransferToken(instructions.TransferTokenParam{
+					Sender:       owner,
+					Recipient:    recipient,
+					Mint:         mint,
+					Amount:       10,
+					TokenProgram: &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, []common.PublicKey{ownerAta, recipientAta, owner}, accountsOf(instr[0]))
+
+				instr = build(instructions.TransferTokenChecked(instructions.TransferTokenCheckedParam{
+					Sender:       owner,
+					Recipient:    recipient,
+					Mint:         mint,
+					Amount:       10,
+					Decimals:     6,
+					TokenProgram: &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, []common.PublicKey{ownerAta, mint, recipientAta, owner}, accountsOf(instr[0]))
+			})
+
+			t.Run("burn", func(t *testing.T) {
+				instr := build(instructions.BurnToken(instructions.BurnTokenParams{
+					Mint:              mint,
+					TokenAccountOwner: owner,
+					Amount:            10,
+					TokenProgram:      &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, byte(token.InstructionBurn), instr[0].Data[0])
+				assert.Equal(t, []common.PublicKey{ownerAta, mint, owner}, accountsOf(instr[0]))
+			})
+
+			t.Run("freeze", func(t *testing.T) {
+				instr := build(instructions.FreezeTokenAccount(instructions.FreezeTokenAccountParams{
+					FreezeAuth:        owner,
+					Mint:              mint,
+					TokenAccountOwner: &recipient,
+					TokenProgram:      &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, []common.PublicKey{recipientAta, mint, owner}, accountsOf(instr[0]))
+
+				instr = build(instructions.UnfreezeTokenAccount(instructions.UnfreezeTokenAccountParams{
+					FreezeAuth:        owner,
+					Mint:              mint,
+					TokenAccountOwner: &recipient,
+					TokenProgram:      &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, []common.PublicKey{recipientAta, mint, owner}, accountsOf(instr[0]))
+			})
+
+			t.Run("close", func(t *testing.T) {
+				instr := build(instructions.CloseTokenAccount(instructions.CloseTokenAccountParams{
+					Owner:        owner,
+					Mint:         &mint,
+					TokenProgram: &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, []common.PublicKey{ownerAta, owner, owner}, accountsOf(instr[0]))
+
+				instr = build(instructions.CloseTokenAccounts(instructions.CloseTokenAccountsParams{
+					Owner:        owner,
+					Mints:        []common.PublicKey{mint},
+					TokenProgram: &tokenProgram,
+				}))
+				require.Len(t, instr, 1)
+				assert.Equal(t, tokenProgram, instr[0].ProgramID)
+				assert.Equal(t, ownerAta, instr[0].Accounts[0].PubKey)
+			})
+		})
+	}
+
+	t.Run("unsupported token program", func(t *testing.T) {
+		program := common.SystemProgramID
+		_, err := instructions.TransferToken(instructions.TransferTokenParam{
+			Sender:       owner,
+			Recipient:    recipient,
+			Mint:         mint,
+			Amount:       10,
+			TokenProgram: &program,
+		})(context.Background(), &mockClient{})
+		require.Error(t, err)
+	})
+}
+
+func TestTokenProgram_AutoDetect(t *testing.T) {
+	owner := types.NewAccount().PublicKey
+	recipient := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+
+	ownerAta, err := commonx.DeriveTokenAccountPubkeyWithProgram(owner, mint, commonx.Token2022ProgramID)
+	require.NoError(t, err)
+	recipientAta, err := commonx.DeriveTokenAccountPubkeyWithProgram(recipient, mint, commonx.Token2022ProgramID)
+	require.NoError(t, err)
+
+	params := instructions.TransferTokenCheckedParam{
+		Sender:    owner,
+		Recipient: recipient,
+		Mint:      mint,
+		Amount:    10,
+		Decimals:  6,
+	}
+
+	t.Run("token-2022 mint", func(t *testing.T) {
+		instr, err := instructions.TransferTokenChecked(params)(context.Background(), &mockClient{
+			tokenPrograms: map[string]common.PublicKey{mint.ToBase58(): commonx.Token2022ProgramID},
+		})
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+		assert.Equal(t, commonx.Token2022ProgramID, instr[0].ProgramID)
+		assert.Equal(t, []common.PublicKey{ownerAta, mint, recipientAta, owner}, accountsOf(instr[0]))
+
+		instr, err = instructions.DisableFungibleTokenMinting(instructions.DisableFungibleTokenMintingParam{
+			Mint:     mint,
+			MintAuth: owner,
+		})(context.Background(), &mockClient{
+			tokenPrograms: map[string]common.PublicKey{mint.ToBase58(): commonx.Token2022ProgramID},
+		})
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+		assert.Equal(t, commonx.Token2022ProgramID, instr[0].ProgramID)
+	})
+
+	t.Run("client can't detect the token program", func(t *testing.T) {
+		c := struct{ instructions.Client }{&mockClient{
+			tokenPrograms: map[string]common.PublicKey{mint.ToBase58(): commonx.Token2022ProgramID},
+		}}
+		instr, err := instructions.TransferTokenChecked(params)(context.Background(), c)
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+		assert.Equal(t, common.TokenProgramID, instr[0].ProgramID)
+	})
+}
@@ -2,6 +2,7 @@ package instructions
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 
 	"github.com/portto/solana-go-sdk/common"
@@ -48,3 +49,111 @@ func TransferSOL(params TransferSOLParams) InstructionFunc {
 		return []types.Instruction{instruction}, nil
 	}
 }
+
+// maxTransactionSize is the maximum size of a serialized transaction in bytes,
+// the same as transaction.MaxTransactionSize, which can't be imported here.
+const maxTransactionSize = 1232
+
+type (
+	// BatchTransferSOLParams defines the parameters for transferring SOL to multiple recipients.
+	BatchTransferSOLParams struct {
+		Sender     common.PublicKey       // required; The wallet to send SOL from
+		Recipients []SOLTransferRecipient // required; The recipients of SOL
+	}
+
+	// SOLTransferRecipient defines a single recipient of the batch SOL transfer.
+	SOLTransferRecipient struct {
+		Recipient common.PublicKey // required; The wallet to send SOL to
+		Lamports  uint64           // required; The amount of SOL to send (in lamports)
+	}
+)
+
+// Validate validates the parameters.
+func (p BatchTransferSOLParams) Validate() error {
+	if p.Sender == (common.PublicKey{}) {
+		return fmt.Errorf("missed or invalid sender public key")
+	}
+	if len(p.Recipients) == 0 {
+		return fmt.Errorf("recipients list is empty")
+	}
+
+	var total uint64
+	for i, r := range p.Recipients {
+		if r.Recipient == (common.PublicKey{}) {
+			return fmt.Errorf("recipient #%d: missed or invalid recipient public key", i)
+		}
+		if r.Recipient == p.Sender {
+			return fmt.Errorf("recipient #%d: sender and recipient must be different", i)
+		}
+		if r.Lamports == 0 {
+			return fmt.Errorf("recipient #%d: amount must be greater than 0", i)
+		}
+		if total+r.Lamports < total {
+			return fmt.Errorf("recipient #%d: total amount overflows uint64", i)
+		}
+		total += r.Lamports
+	}
+
+	return nil
+}
+
+// Total returns the total amount of lamports sent to all the recipients.
+func (p BatchTransferSOLParams) Total() uint64 {
+	var total uint64
+	for _, r := range p.Recipients {
+		total += r.Lamports
+	}
+	return total
+}
+
+// BatchTransferSOL transfers SOL from one wallet to multiple recipients in a single transaction,
+// one transfer instruction per recipient in the given order.
+// Note: This function does not check if the sender has enough SOL to send. It is the responsibility
+// of the caller to check this, see BatchTransferSOLParams.Total.
+// Returns an error if the transaction paid by the sender would exceed the transaction size limit;
+// a separate fee payer adds one more signature and account, so leave some room in that case.
+func BatchTransferSOL(params BatchTransferSOLParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid given data: %w", err)
+		}
+
+		instructions := make([]types.Instruction, 0, len(params.Recipients))
+		for _, r := range params.Recipients {
+			instructions = append(instructions, system.Transfer(system.TransferParam{
+				From:   params.Sender,
+				To:     r.Recipient,
+				Amount: r.Lamports,
+			}))
+		}
+
+		size, err := estimateTransactionSize(instructions, params.Sender)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate transaction size: %w", err)
+		}
+		if size > maxTransactionSize {
+			return nil, fmt.Errorf("too many recipients: transaction size %d exceeds the limit of %d bytes", size, maxTransactionSize)
+		}
+
+		return instructions, nil
+	}
+}
+
+// estimateTransactionSize returns the size of the serialized and signed legacy transaction in bytes,
+// which contains the given instructions and is paid by the given fee payer.
+func estimateTransactionSize(instructions []types.Instruction, feePayer common.PublicKey) (int, error) {
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:        feePayer,
+		Instructions:    instructions,
+		RecentBlockhash: common.PublicKey{}.ToBase58(),
+	})
+	data, err := message.Serialize()
+	if err != nil {
+		return 0, err
+	}
+
+	// the signatures count fits into a single byte of the compact-u16 encoding
+	signatures := int(message.Header.NumRequireSignatures)
+
+	return 1 + signatures*ed25519.SignatureSize + len(data), nil
+}
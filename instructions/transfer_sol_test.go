@@ -0,0 +1,76 @@
+package instructions_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchTransferSOL(t *testing.T) {
+	sender := types.NewAccount().PublicKey
+
+	params := instructions.BatchTransferSOLParams{Sender: sender}
+	for i := 1; i <= 5; i++ {
+		params.Recipients = append(params.Recipients, instructions.SOLTransferRecipient{
+			Recipient: types.NewAccount().PublicKey,
+			Lamports:  uint64(i) * 1_000_000,
+		})
+	}
+	assert.Equal(t, uint64(15_000_000), params.Total())
+
+	instr, err := instructions.BatchTransferSOL(params)(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, len(params.Recipients))
+
+	var total uint64
+	for i, in := range instr {
+		assert.Equal(t, common.SystemProgramID, in.ProgramID)
+		assert.Equal(t, []common.PublicKey{sender, params.Recipients[i].Recipient}, accountsOf(in))
+		assert.True(t, in.Accounts[0].IsSigner)
+		// instruction (u32), lamports (u64)
+		require.Len(t, in.Data, 12)
+		lamports := binary.LittleEndian.Uint64(in.Data[4:])
+		assert.Equal(t, params.Recipients[i].Lamports, lamports)
+		total += lamports
+	}
+	assert.Equal(t, params.Total(), total)
+}
+
+func TestBatchTransferSOL_Invalid(t *testing.T) {
+	sender := types.NewAccount().PublicKey
+	recipient := types.NewAccount().PublicKey
+
+	tests := map[string]instructions.BatchTransferSOLParams{
+		"missed sender": {Recipients: []instructions.SOLTransferRecipient{{Recipient: recipient, Lamports: 1}}},
+		"no recipients": {Sender: sender},
+		"zero amount":   {Sender: sender, Recipients: []instructions.SOLTransferRecipient{{Recipient: recipient}}},
+		"to sender":     {Sender: sender, Recipients: []instructions.SOLTransferRecipient{{Recipient: sender, Lamports: 1}}},
+		"overflow": {Sender: sender, Recipients: []instructions.SOLTransferRecipient{
+			{Recipient: recipient, Lamports: ^uint64(0)},
+			{Recipient: types.NewAccount().PublicKey, Lamports: 1},
+		}},
+	}
+	for name, params := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := instructions.BatchTransferSOL(params)(context.Background(), &mockClient{})
+			require.Error(t, err)
+		})
+	}
+
+	// too many recipients to fit into a single transaction
+	params := instructions.BatchTransferSOLParams{Sender: sender}
+	for i := 0; i < 30; i++ {
+		params.Recipients = append(params.Recipients, instructions.SOLTransferRecipient{
+			Recipient: types.NewAccount().PublicKey,
+			Lamports:  1,
+		})
+	}
+	_, err := instructions.BatchTransferSOL(params)(context.Background(), &mockClient{})
+	require.ErrorContains(t, err, "too many recipients")
+}
@@ -16,6 +16,8 @@ type TransferTokenParam struct {
 	Mint      common.PublicKey  // required; The token mint to send
 	Amount    uint64            // required; The amount of tokens to send (in token minimal units)
 	Reference *common.PublicKey // optional; public key to use as a reference for the transaction.
+
+	TokenProgram *common.PublicKey // optional; The token program which owns the mint, e.g. the Token-2022 program; default is detected from the mint if the client supports it, otherwise the classic SPL Token program
 }
 
 // Validate validates the parameters.
@@ -35,6 +37,9 @@ func (p TransferTokenParam) Validate() error {
 	if p.Reference != nil && *p.Reference == (common.PublicKey{}) {
 		return fmt.Errorf("invalid reference public key")
 	}
+	if err := validateTokenProgram(p.TokenProgram); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -42,28 +47,105 @@ func (p TransferTokenParam) Validate() error {
 // Note: This function does not check if the sender has enough tokens to send. It is the responsibility
 // of the caller to check this.
 // FeePayer must be provided if Sender is not set.
+// Prefer TransferTokenChecked: the token program does not verify the token decimals here,
+// so a miscomputed amount is sent silently.
 func TransferToken(params TransferTokenParam) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
 		if err := params.Validate(); err != nil {
 			return nil, fmt.Errorf("invalid given data: %w", err)
 		}
 
-		senderAta, _, err := common.FindAssociatedTokenAddress(params.Sender, params.Mint)
+		tokenProgram, err := resolveTokenProgram(ctx, c, params.TokenProgram, params.Mint)
+		if err != nil {
+			return nil, err
+		}
+
+		senderAta, err := findTokenProgramAssociatedAddress(params.Sender, params.Mint, tokenProgram)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find associated token address for sender wallet: %w", err)
 		}
 
-		recipientAta, _, err := common.FindAssociatedTokenAddress(params.Recipient, params.Mint)
+		recipientAta, err := findTokenProgramAssociatedAddress(params.Recipient, params.Mint, tokenProgram)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find associated token address for recipient wallet: %w", err)
 		}
 
-		instruction := token.Transfer(token.TransferParam{
+		instruction := withTokenProgram(token.Transfer(token.TransferParam{
 			From:   senderAta,
 			To:     recipientAta,
 			Auth:   params.Sender,
 			Amount: params.Amount,
-		})
+		}), tokenProgram)
+
+		if params.Reference != nil {
+			instruction.Accounts = append(instruction.Accounts, types.AccountMeta{
+				PubKey:     *params.Reference,
+				IsSigner:   false,
+				IsWritable: false,
+			})
+		}
+
+		return []types.Instruction{instruction}, nil
+	}
+}
+
+// TransferTokenCheckedParam defines the parameters for transferring tokens with the decimals check.
+type TransferTokenCheckedParam struct {
+	Sender    common.PublicKey  // required; The wallet to send tokens from
+	Recipient common.PublicKey  // required; The wallet to send tokens to
+	Mint      common.PublicKey  // required; The token mint to send
+	Amount    uint64            // required; The amount of tokens to send (in token minimal units)
+	Decimals  uint8             // required; The number of decimals the token has; must match the mint decimals
+	Reference *common.PublicKey // optional; public key to use as a reference for the transaction.
+
+	TokenProgram *common.PublicKey // optional; The token program which owns the mint, e.g. the Token-2022 program; default is detected from the mint if the client supports it, otherwise the classic SPL Token program
+}
+
+// Validate validates the parameters.
+func (p TransferTokenCheckedParam) Validate() error {
+	return TransferTokenParam{
+		Sender:       p.Sender,
+		Recipient:    p.Recipient,
+		Mint:         p.Mint,
+		Amount:       p.Amount,
+		Reference:    p.Reference,
+		TokenProgram: p.TokenProgram,
+	}.Validate()
+}
+
+// TransferTokenChecked transfers tokens from one wallet to another, like TransferToken,
+// but the token program rejects the transfer if Decimals does not match the mint decimals.
+// This is the recommended way to transfer tokens.
+func TransferTokenChecked(params TransferTokenCheckedParam) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid given data: %w", err)
+		}
+
+		tokenProgram, err := resolveTokenProgram(ctx, c, params.TokenProgram, params.Mint)
+		if err != nil {
+			return nil, err
+		}
+
+		senderAta, err := findTokenProgramAssociatedAddress(params.Sender, params.Mint, tokenProgram)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find associated token address for sender wallet: %w", err)
+		}
+
+		recipientAta, err := findTokenProgramAssociatedAddress(params.Recipient, params.Mint, tokenProgram)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find associated token address for recipient wallet: %w", err)
+		}
+
+		instruction := withTokenProgram(token.TransferChecked(token.TransferCheckedParam{
+			From:     senderAta,
+			To:       recipientAta,
+			Mint:     params.Mint,
+			Auth:     params.Sender,
+			Signers:  []common.PublicKey{},
+			Amount:   params.Amount,
+			Decimals: params.Decimals,
+		}), tokenProgram)
 
 		if params.Reference != nil {
 			instruction.Accounts = append(instruction.Accounts, types.AccountMeta{
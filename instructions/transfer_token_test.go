@@ -0,0 +1,53 @@
+package instructions_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/token"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTokenChecked(t *testing.T) {
+	sender := types.NewAccount().PublicKey
+	recipient := types.NewAccount().PublicKey
+	mint := types.NewAccount().PublicKey
+	reference := types.NewAccount().PublicKey
+
+	instr, err := instructions.TransferTokenChecked(instructions.TransferTokenCheckedParam{
+		Sender:    sender,
+		Recipient: recipient,
+		Mint:      mint,
+		Amount:    1_500_000,
+		Decimals:  6,
+		Reference: &reference,
+	})(context.Background(), &mockClient{})
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	senderAta, _, err := common.FindAssociatedTokenAddress(sender, mint)
+	require.NoError(t, err)
+	recipientAta, _, err := common.FindAssociatedTokenAddress(recipient, mint)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.TokenProgramID, instr[0].ProgramID)
+	assert.Equal(t, []common.PublicKey{senderAta, mint, recipientAta, sender, reference}, accountsOf(instr[0]))
+	// instruction, amount, decimals
+	require.Len(t, instr[0].Data, 10)
+	assert.Equal(t, byte(token.InstructionTransferChecked), instr[0].Data[0])
+	assert.Equal(t, uint64(1_500_000), binary.LittleEndian.Uint64(instr[0].Data[1:9]))
+	assert.Equal(t, byte(6), instr[0].Data[9])
+
+	_, err = instructions.TransferTokenChecked(instructions.TransferTokenCheckedParam{
+		Sender:    sender,
+		Recipient: recipient,
+		Mint:      mint,
+		Decimals:  6,
+	})(context.Background(), &mockClient{})
+	require.Error(t, err)
+}
@@ -3,7 +3,6 @@ package instructions
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/dmitrymomot/solana/metadata"
 	"github.com/dmitrymomot/solana/token_metadata"
@@ -21,13 +20,18 @@ type UpdateMetadataParams struct {
 
 	MetadataUri          *string                        // optional; new metadata json uri
 	SellerFeeBasisPoints *uint16                        // optional; new seller fee basis points
-	Creators             *[]Creator                     // optional; new creators list
+	Creators             *[]Creator                     // optional; new creators list; at most MaxCreators unique creators whose shares sum up to 100
 	PrimarySaleHappened  *bool                          // optional; new primary sale happened
 	IsMutable            *bool                          // optional; new is mutable
 	Collection           *common.PublicKey              // optional; new collection public key
 	UseMethod            *token_metadata.TokenUseMethod // optional; new use method
 	UseLimit             *uint64                        // optional; new use limit; default is 1; if use method is empty, use limit will be ignored
 	UseRemaining         *uint64                        // optional; new use remaining; default equals use limit; if use method is empty, use remaining will be ignored
+
+	ClearCollection bool // optional; removes the collection from the metadata; can't be set with Collection
+	ClearUses       bool // optional; removes the uses from the metadata; can't be set with UseMethod
+
+	KeepOnChainNameSymbol bool // optional; keeps the on-chain name and symbol when MetadataUri is set, so the new metadata json isn't fetched; default is false, the name and symbol are taken from the new metadata json if it's reachable
 }
 
 // Validate validates the params.
@@ -41,10 +45,7 @@ func (p UpdateMetadataParams) Validate() error {
 	if p.NewUpdateAuthority != nil && *p.NewUpdateAuthority == (common.PublicKey{}) {
 		return fmt.Errorf("new update authority is invalid")
 	}
-	if p.MetadataUri != nil &&
-		(*p.MetadataUri == "" ||
-			(!strings.HasPrefix(*p.MetadataUri, "http://") &&
-				!strings.HasPrefix(*p.MetadataUri, "https://"))) {
+	if p.MetadataUri != nil && !metadata.IsSupportedURI(*p.MetadataUri) {
 		return fmt.Errorf("metadata uri is invalid")
 	}
 	if p.SellerFeeBasisPoints != nil && *p.SellerFeeBasisPoints > 10000 {
@@ -59,6 +60,17 @@ func (p UpdateMetadataParams) Validate() error {
 	if p.UseMethod != nil && !p.UseMethod.Valid() {
 		return fmt.Errorf("use method is invalid")
 	}
+	if p.ClearCollection && p.Collection != nil {
+		return fmt.Errorf("collection can't be set and cleared at the same time")
+	}
+	if p.ClearUses && p.UseMethod != nil {
+		return fmt.Errorf("use method can't be set and uses cleared at the same time")
+	}
+	if p.Creators != nil && len(*p.Creators) > 0 {
+		if err := validateCreators(*p.Creators); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -66,7 +78,7 @@ func (p UpdateMetadataParams) Validate() error {
 func UpdateMetadata(params UpdateMetadataParams) InstructionFunc {
 	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
 		if err := params.Validate(); err != nil {
-			return nil, fmt.Errorf("validate update metadata: %w", err)
+			return nil, fmt.Errorf("failed to validate params: %w", err)
 		}
 
 		tokenMetadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(params.Mint)
@@ -74,7 +86,7 @@ func UpdateMetadata(params UpdateMetadataParams) InstructionFunc {
 			return nil, fmt.Errorf("failed to derive token metadata pubkey: %w", err)
 		}
 
-		oldMetadata, err := c.GetTokenMetadata(ctx, params.Mint.ToBase58())
+		oldMetadata, err := getOnChainTokenMetadata(ctx, c, tokenMetadataPubkey, params.Mint)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get current token metadata: %w", err)
 		}
@@ -101,7 +113,7 @@ func UpdateMetadata(params UpdateMetadataParams) InstructionFunc {
 					}
 					return nil
 				}(),
-				Data: getDataParam(oldMetadata, params),
+				Data: getDataParam(ctx, oldMetadata, params),
 			}),
 		}
 
@@ -116,11 +128,9 @@ func getCreatorsParam(oldMetadata *token_metadata.Metadata, params UpdateMetadat
 		creators := make([]metaplex_token_metadata.Creator, 0, len(*params.Creators))
 		for _, creator := range *params.Creators {
 			creators = append(creators, metaplex_token_metadata.Creator{
-				Address: creator.Address,
-				Share:   creator.Share,
-				Verified: func() bool {
-					return creator.Address.ToBase58() == params.UpdateAuthority.ToBase58()
-				}(),
+				Address:  creator.Address,
+				Share:    creator.Share,
+				Verified: creator.Address == params.UpdateAuthority,
 			})
 		}
 		return &creators
@@ -144,6 +154,10 @@ func getCreatorsParam(oldMetadata *token_metadata.Metadata, params UpdateMetadat
 
 // get collection param to update metadata
 func getCollectionParam(oldMetadata *token_metadata.Metadata, params UpdateMetadataParams) *metaplex_token_metadata.Collection {
+	if params.ClearCollection {
+		return nil
+	}
+
 	if params.Collection != nil {
 		return &metaplex_token_metadata.Collection{
 			Verified: false,
@@ -152,8 +166,13 @@ func getCollectionParam(oldMetadata *token_metadata.Metadata, params UpdateMetad
 	}
 
 	if oldMetadata.Collection != nil {
+		key := common.PublicKeyFromString(oldMetadata.Collection.Key)
+		if key == (common.PublicKey{}) {
+			// the empty collection key can't reference the collection, so drop it
+			return nil
+		}
 		return &metaplex_token_metadata.Collection{
-			Key:      common.PublicKeyFromString(oldMetadata.Collection.Key),
+			Key:      key,
 			Verified: oldMetadata.Collection.Verified,
 		}
 	}
@@ -163,6 +182,10 @@ func getCollectionParam(oldMetadata *token_metadata.Metadata, params UpdateMetad
 
 // get uses param to update metadata
 func getUsesParam(oldMetadata *token_metadata.Metadata, params UpdateMetadataParams) *metaplex_token_metadata.Uses {
+	if params.ClearUses {
+		return nil
+	}
+
 	if params.UseMethod != nil {
 		if params.UseLimit == nil || *params.UseLimit == 0 {
 			params.UseLimit = utils.Pointer[uint64](1)
@@ -193,19 +216,21 @@ func getUsesParam(oldMetadata *token_metadata.Metadata, params UpdateMetadataPar
 }
 
 // get data param to update metadata
-func getDataParam(oldMetadata *token_metadata.Metadata, params UpdateMetadataParams) *metaplex_token_metadata.DataV2 {
+func getDataParam(ctx context.Context, oldMetadata *token_metadata.Metadata, params UpdateMetadataParams) *metaplex_token_metadata.DataV2 {
 	if params.MetadataUri != nil ||
 		params.SellerFeeBasisPoints != nil ||
 		params.Creators != nil ||
 		params.Collection != nil ||
-		params.UseMethod != nil {
-
-		var (
-			name   = oldMetadata.Data.Name
-			symbol = oldMetadata.Data.Symbol
-		)
-		if params.MetadataUri != nil {
-			metadata, _ := metadata.MetadataFromURI(*params.MetadataUri)
+		params.UseMethod != nil ||
+		params.ClearCollection ||
+		params.ClearUses {
+
+		var name, symbol string
+		if oldMetadata.Data != nil {
+			name, symbol = oldMetadata.Data.Name, oldMetadata.Data.Symbol
+		}
+		if params.MetadataUri != nil && !params.KeepOnChainNameSymbol {
+			metadata, _ := metadata.MetadataFromURIWithContext(ctx, nil, *params.MetadataUri)
 			if metadata != nil {
 				name = metadata.Name
 				symbol = metadata.Symbol
@@ -235,3 +260,131 @@ func getDataParam(oldMetadata *token_metadata.Metadata, params UpdateMetadataPar
 
 	return nil
 }
+
+// SetPrimarySaleHappenedParams is the params for SetPrimarySaleHappened
+type SetPrimarySaleHappenedParams struct {
+	Mint            common.PublicKey // required; The mint of the token
+	UpdateAuthority common.PublicKey // required; The update authority of the token
+}
+
+// Validate validates the params.
+func (p SetPrimarySaleHappenedParams) Validate() error {
+	if p.Mint == (common.PublicKey{}) {
+		return fmt.Errorf("mint is required")
+	}
+	if p.UpdateAuthority == (common.PublicKey{}) {
+		return fmt.Errorf("update authority is required")
+	}
+	return nil
+}
+
+// SetPrimarySaleHappened marks the primary sale of the token as happened.
+// Only the flag is updated; data, creators and collection are left untouched.
+// Returns no instructions if the flag is already set.
+func SetPrimarySaleHappened(params SetPrimarySaleHappenedParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
+
+		tokenMetadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(params.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive token metadata pubkey: %w", err)
+		}
+
+		currentMetadata, err := c.GetTokenMetadata(ctx, params.Mint.ToBase58())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current token metadata: %w", err)
+		}
+		if currentMetadata.UpdateAuthority != params.UpdateAuthority.ToBase58() {
+			return nil, fmt.Errorf("update authority does not match the token metadata update authority")
+		}
+		if currentMetadata.PrimarySaleHappened {
+			return nil, nil
+		}
+
+		instructions := []types.Instruction{
+			metaplex_token_metadata.UpdateMetadataAccountV2(metaplex_token_metadata.UpdateMetadataAccountV2Param{
+				MetadataAccount:     tokenMetadataPubkey,
+				UpdateAuthority:     params.UpdateAuthority,
+				PrimarySaleHappened: utils.Pointer(true),
+			}),
+		}
+		return instructions, nil
+	}
+}
+
+// SetImmutableParams is the params for SetImmutable
+type SetImmutableParams struct {
+	Mint            common.PublicKey // required; The mint of the token
+	UpdateAuthority common.PublicKey // required; The update authority of the token
+}
+
+// Validate validates the params.
+func (p SetImmutableParams) Validate() error {
+	if p.Mint == (common.PublicKey{}) {
+		return fmt.Errorf("mint is required")
+	}
+	if p.UpdateAuthority == (common.PublicKey{}) {
+		return fmt.Errorf("update authority is required")
+	}
+	return nil
+}
+
+// SetImmutable locks the token metadata permanently.
+// Only the mutability flag is updated; all other fields are left untouched.
+// Note: this action is irreversible, once the metadata is immutable it cannot be changed anymore.
+func SetImmutable(params SetImmutableParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to validate params: %w", err)
+		}
+
+		tokenMetadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(params.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive token metadata pubkey: %w", err)
+		}
+
+		currentMetadata, err := c.GetTokenMetadata(ctx, params.Mint.ToBase58())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current token metadata: %w", err)
+		}
+		if currentMetadata.UpdateAuthority != params.UpdateAuthority.ToBase58() {
+			return nil, fmt.Errorf("update authority does not match the token metadata update authority")
+		}
+		if !currentMetadata.IsMutable {
+			return nil, fmt.Errorf("token metadata is already immutable")
+		}
+
+		instructions := []types.Instruction{
+			metaplex_token_metadata.UpdateMetadataAccountV2(metaplex_token_metadata.UpdateMetadataAccountV2Param{
+				MetadataAccount: tokenMetadataPubkey,
+				UpdateAuthority: params.UpdateAuthority,
+				IsMutable:       utils.Pointer(false),
+			}),
+		}
+		return instructions, nil
+	}
+}
+
+// accountDataReader reads the raw account data, see client.Client.GetAccountData.
+type accountDataReader interface {
+	GetAccountData(ctx context.Context, base58Addr string) ([]byte, error)
+}
+
+// getOnChainTokenMetadata returns the current token metadata with the on-chain name and symbol,
+// read from the metadata account without downloading the metadata json of the current URI.
+// Falls back to GetTokenMetadata if the client can't read the raw accounts.
+func getOnChainTokenMetadata(ctx context.Context, c Client, metadataAccount, mint common.PublicKey) (*token_metadata.Metadata, error) {
+	reader, ok := c.(accountDataReader)
+	if !ok {
+		return c.GetTokenMetadata(ctx, mint.ToBase58())
+	}
+
+	data, err := reader.GetAccountData(ctx, metadataAccount.ToBase58())
+	if err != nil {
+		return nil, err
+	}
+
+	return token_metadata.DeserializeOnChainMetadata(data)
+}
@@ -0,0 +1,478 @@
+package instructions_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/metadata"
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPrimarySaleHappened(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	c := &mockClient{metadata: &token_metadata.Metadata{
+		UpdateAuthority: authority.ToBase58(),
+		Mint:            mint.ToBase58(),
+		IsMutable:       true,
+	}}
+
+	instr, err := instructions.SetPrimarySaleHappened(instructions.SetPrimarySaleHappenedParams{
+		Mint:            mint,
+		UpdateAuthority: authority,
+	})(context.Background(), c)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	metadataPubkey, err := token_metadata.DeriveTokenMetadataPubkey(mint)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.MetaplexTokenMetaProgramID, instr[0].ProgramID)
+	assert.Equal(t, metadataPubkey, instr[0].Accounts[0].PubKey)
+	assert.Equal(t, authority, instr[0].Accounts[1].PubKey)
+	// instruction, data: none, new update authority: none, primary sale happened: some(true), is mutable: none
+	assert.Equal(t, []byte{byte(metaplex_token_metadata.InstructionUpdateMetadataAccountV2), 0, 0, 1, 1, 0}, instr[0].Data)
+}
+
+func TestSetPrimarySaleHappened_AlreadyHappened(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	c := &mockClient{metadata: &token_metadata.Metadata{
+		UpdateAuthority:     authority.ToBase58(),
+		Mint:                mint.ToBase58(),
+		PrimarySaleHappened: true,
+	}}
+
+	instr, err := instructions.SetPrimarySaleHappened(instructions.SetPrimarySaleHappenedParams{
+		Mint:            mint,
+		UpdateAuthority: authority,
+	})(context.Background(), c)
+	require.NoError(t, err)
+	assert.Empty(t, instr)
+}
+
+func TestSetPrimarySaleHappened_WrongAuthority(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	c := &mockClient{metadata: &token_metadata.Metadata{
+		UpdateAuthority: types.NewAccount().PublicKey.ToBase58(),
+		Mint:            mint.ToBase58(),
+	}}
+
+	_, err := instructions.SetPrimarySaleHappened(instructions.SetPrimarySaleHappenedParams{
+		Mint:            mint,
+		UpdateAuthority: types.NewAccount().PublicKey,
+	})(context.Background(), c)
+	require.Error(t, err)
+}
+
+func TestSetImmutable(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	c := &mockClient{metadata: &token_metadata.Metadata{
+		UpdateAuthority: authority.ToBase58(),
+		Mint:            mint.ToBase58(),
+		IsMutable:       true,
+	}}
+
+	instr, err := instructions.SetImmutable(instructions.SetImmutableParams{
+		Mint:            mint,
+		UpdateAuthority: authority,
+	})(context.Background(), c)
+	require.NoError(t, err)
+	require.Len(t, instr, 1)
+
+	assert.Equal(t, common.MetaplexTokenMetaProgramID, instr[0].ProgramID)
+	// instruction, data: none, new update authority: none, primary sale happened: none, is mutable: some(false)
+	assert.Equal(t, []byte{byte(metaplex_token_metadata.InstructionUpdateMetadataAccountV2), 0, 0, 0, 1, 0}, instr[0].Data)
+}
+
+func TestSetImmutable_Validation(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+
+	tests := []struct {
+		name     string
+		params   instructions.SetImmutableParams
+		metadata *token_metadata.Metadata
+	}{
+		{
+			name:   "missing mint",
+			params: instructions.SetImmutableParams{UpdateAuthority: authority},
+		},
+		{
+			name:   "missing update authority",
+			params: instructions.SetImmutableParams{Mint: mint},
+		},
+		{
+			name:     "wrong update authority",
+			params:   instructions.SetImmutableParams{Mint: mint, UpdateAuthority: types.NewAccount().PublicKey},
+			metadata: &token_metadata.Metadata{UpdateAuthority: authority.ToBase58(), IsMutable: true},
+		},
+		{
+			name:     "already immutable",
+			params:   instructions.SetImmutableParams{Mint: mint, UpdateAuthority: authority},
+			metadata: &token_metadata.Metadata{UpdateAuthority: authority.ToBase58(), IsMutable: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := instructions.SetImmutable(tt.params)(context.Background(), &mockClient{metadata: tt.metadata})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUpdateMetadataParams_Validate_MetadataUri(t *testing.T) {
+	params := instructions.UpdateMetadataParams{
+		Mint:            types.NewAccount().PublicKey,
+		UpdateAuthority: types.NewAccount().PublicKey,
+	}
+
+	for _, uri := range []string{"https://example.com/1.json", "ipfs://bafycid", "ar://txid"} {
+		params.MetadataUri = &uri
+		assert.NoError(t, params.Validate(), uri)
+	}
+	for _, uri := range []string{"", "ipfs://", "ftp://example.com/1.json"} {
+		params.MetadataUri = &uri
+		assert.Error(t, params.Validate(), uri)
+	}
+}
+
+func TestUpdateMetadata_Creators(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	creator := types.NewAccount().PublicKey
+	c := &mockClient{metadata: &token_metadata.Metadata{
+		UpdateAuthority: authority.ToBase58(),
+		Mint:            mint.ToBase58(),
+		IsMutable:       true,
+		Data:            &metadata.Metadata{Name: "NFT", Symbol: "NFT"},
+	}}
+
+	t.Run("shares must sum up to 100", func(t *testing.T) {
+		params := instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			Creators:        &[]instructions.Creator{{Address: creator, Share: 60}, {Address: authority, Share: 30}},
+		}
+		require.ErrorContains(t, params.Validate(), "creators share must be 100, got 90")
+
+		_, err := instructions.UpdateMetadata(params)(context.Background(), c)
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate creator", func(t *testing.T) {
+		params := instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			Creators:        &[]instructions.Creator{{Address: creator, Share: 50}, {Address: creator, Share: 50}},
+		}
+		require.ErrorContains(t, params.Validate(), "duplicate creator")
+	})
+
+	t.Run("too many creators", func(t *testing.T) {
+		creators := []instructions.Creator{{Address: authority, Share: 100}}
+		for i := 0; i < instructions.MaxCreators; i++ {
+			creators = append(creators, instructions.Creator{Address: types.NewAccount().PublicKey})
+		}
+		params := instructions.UpdateMetadataParams{Mint: mint, UpdateAuthority: authority, Creators: &creators}
+		require.Error(t, params.Validate())
+	})
+
+	t.Run("update authority is not a creator", func(t *testing.T) {
+		params := instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			Creators:        &[]instructions.Creator{{Address: creator, Share: 100}},
+		}
+		require.NoError(t, params.Validate())
+
+		instr, err := instructions.UpdateMetadata(params)(context.Background(), c)
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+
+		data := decodeUpdateMetadataData(t, instr[0])
+		require.NotNil(t, data.Creators)
+		assert.Equal(t, []metaplex_token_metadata.Creator{{Address: creator, Verified: false, Share: 100}}, *data.Creators)
+	})
+
+	t.Run("update authority is one of the creators", func(t *testing.T) {
+		params := instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			Creators:        &[]instructions.Creator{{Address: creator, Share: 70}, {Address: authority, Share: 30}},
+		}
+		require.NoError(t, params.Validate())
+
+		instr, err := instructions.UpdateMetadata(params)(context.Background(), c)
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+
+		// the creators are kept as given; only the signing update authority is verified
+		data := decodeUpdateMetadataData(t, instr[0])
+		require.NotNil(t, data.Creators)
+		assert.Equal(t, []metaplex_token_metadata.Creator{
+			{Address: creator, Verified: false, Share: 70},
+			{Address: authority, Verified: true, Share: 30},
+		}, *data.Creators)
+	})
+}
+
+// decodeUpdateMetadataData decodes the data argument of the UpdateMetadataAccountV2 instruction.
+func decodeUpdateMetadataData(t *testing.T, instr types.Instruction) *metaplex_token_metadata.DataV2 {
+	t.Helper()
+
+	var args struct {
+		Instruction         uint8
+		Data                *metaplex_token_metadata.DataV2
+		NewUpdateAuthority  *common.PublicKey
+		PrimarySaleHappened *bool
+		IsMutable           *bool
+	}
+	require.NoError(t, borsh.Deserialize(&args, instr.Data))
+	require.Equal(t, uint8(metaplex_token_metadata.InstructionUpdateMetadataAccountV2), args.Instruction)
+
+	return args.Data
+}
+
+func TestUpdateMetadata_Clear(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	collection := types.NewAccount().PublicKey
+	newMockClient := func() *mockClient {
+		return &mockClient{metadata: &token_metadata.Metadata{
+			UpdateAuthority: authority.ToBase58(),
+			Mint:            mint.ToBase58(),
+			IsMutable:       true,
+			MetadataUri:     "https://example.com/1.json",
+			Data:            &metadata.Metadata{Name: "NFT", Symbol: "NFT"},
+			Collection:      &token_metadata.Collection{Key: collection.ToBase58(), Verified: true},
+			Uses:            &token_metadata.Uses{UseMethod: string(token_metadata.TokenUseMethodMulti), Total: 5, Remaining: 3},
+		}}
+	}
+
+	t.Run("keep on nil", func(t *testing.T) {
+		sfbp := uint16(500)
+		instr, err := instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+			Mint:                 mint,
+			UpdateAuthority:      authority,
+			SellerFeeBasisPoints: &sfbp,
+		})(context.Background(), newMockClient())
+		require.NoError(t, err)
+
+		data := decodeUpdateMetadataData(t, instr[0])
+		require.NotNil(t, data)
+		assert.Equal(t, uint16(500), data.SellerFeeBasisPoints)
+		require.NotNil(t, data.Collection)
+		assert.Equal(t, collection, data.Collection.Key)
+		assert.True(t, data.Collection.Verified)
+		require.NotNil(t, data.Uses)
+		assert.Equal(t, uint64(3), data.Uses.Remaining)
+	})
+
+	t.Run("clear collection", func(t *testing.T) {
+		instr, err := instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			ClearCollection: true,
+		})(context.Background(), newMockClient())
+		require.NoError(t, err)
+
+		data := decodeUpdateMetadataData(t, instr[0])
+		require.NotNil(t, data)
+		assert.Nil(t, data.Collection)
+		assert.NotNil(t, data.Uses)
+		assert.Equal(t, "https://example.com/1.json", data.Uri)
+	})
+
+	t.Run("clear uses", func(t *testing.T) {
+		instr, err := instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			ClearUses:       true,
+		})(context.Background(), newMockClient())
+		require.NoError(t, err)
+
+		data := decodeUpdateMetadataData(t, instr[0])
+		require.NotNil(t, data)
+		assert.Nil(t, data.Uses)
+		assert.NotNil(t, data.Collection)
+	})
+
+	t.Run("empty old collection", func(t *testing.T) {
+		for _, key := range []string{"", (common.PublicKey{}).ToBase58()} {
+			mc := newMockClient()
+			mc.metadata.Collection = &token_metadata.Collection{Key: key}
+
+			instr, err := instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+				Mint:            mint,
+				UpdateAuthority: authority,
+				ClearUses:       true,
+			})(context.Background(), mc)
+			require.NoError(t, err)
+
+			data := decodeUpdateMetadataData(t, instr[0])
+			require.NotNil(t, data)
+			assert.Nil(t, data.Collection)
+		}
+	})
+
+	t.Run("set and clear", func(t *testing.T) {
+		params := instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			Collection:      &collection,
+			ClearCollection: true,
+		}
+		require.Error(t, params.Validate())
+
+		params = instructions.UpdateMetadataParams{
+			Mint:            mint,
+			UpdateAuthority: authority,
+			UseMethod:       utils.Pointer(token_metadata.TokenUseMethodBurn),
+			ClearUses:       true,
+		}
+		require.Error(t, params.Validate())
+	})
+}
+
+func TestUpdateMetadata_KeepOnChainNameSymbol(t *testing.T) {
+	// the old metadata json is unreachable, which is the usual reason to swap the URI
+	var oldRequests int32
+	oldSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&oldRequests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer oldSrv.Close()
+
+	var newRequests int32
+	newSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&newRequests, 1)
+		_, _ = w.Write([]byte(`{"name":"Renamed","symbol":"NEW"}`))
+	}))
+	defer newSrv.Close()
+
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	metadataAccount, err := borsh.Serialize(metaplex_token_metadata.Metadata{
+		Key:             metaplex_token_metadata.KeyMetadataV1,
+		UpdateAuthority: authority,
+		Mint:            mint,
+		Data:            metaplex_token_metadata.Data{Name: "NFT", Symbol: "NFT", Uri: oldSrv.URL + "/1.json"},
+		IsMutable:       true,
+	})
+	require.NoError(t, err)
+
+	rpcSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "getAccountInfo", req.Method)
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": map[string]interface{}{
+					"lamports":   5616720,
+					"owner":      common.MetaplexTokenMetaProgramID.ToBase58(),
+					"executable": false,
+					"rentEpoch":  0,
+					"data":       []string{base64.StdEncoding.EncodeToString(metadataAccount), "base64"},
+				},
+			},
+		})
+	}))
+	defer rpcSrv.Close()
+
+	c := client.New(client.SetSolanaEndpoint(rpcSrv.URL))
+	uri := newSrv.URL + "/2.json"
+
+	instr, err := instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+		Mint:                  mint,
+		UpdateAuthority:       authority,
+		MetadataUri:           &uri,
+		KeepOnChainNameSymbol: true,
+	})(context.Background(), c)
+	require.NoError(t, err)
+	assert.Zero(t, atomic.LoadInt32(&oldRequests))
+	assert.Zero(t, atomic.LoadInt32(&newRequests))
+
+	data := decodeUpdateMetadataData(t, instr[0])
+	require.NotNil(t, data)
+	assert.Equal(t, uri, data.Uri)
+	assert.Equal(t, "NFT", data.Name)
+	assert.Equal(t, "NFT", data.Symbol)
+
+	// without the flag the name and symbol are taken from the new metadata json
+	instr, err = instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+		Mint:            mint,
+		UpdateAuthority: authority,
+		MetadataUri:     &uri,
+	})(context.Background(), c)
+	require.NoError(t, err)
+	assert.Zero(t, atomic.LoadInt32(&oldRequests))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&newRequests))
+
+	data = decodeUpdateMetadataData(t, instr[0])
+	require.NotNil(t, data)
+	assert.Equal(t, "Renamed", data.Name)
+	assert.Equal(t, "NEW", data.Symbol)
+
+	// the other updates keep the on-chain name and symbol as well
+	sfbp := uint16(500)
+	instr, err = instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+		Mint:                 mint,
+		UpdateAuthority:      authority,
+		SellerFeeBasisPoints: &sfbp,
+	})(context.Background(), c)
+	require.NoError(t, err)
+	assert.Zero(t, atomic.LoadInt32(&oldRequests))
+
+	data = decodeUpdateMetadataData(t, instr[0])
+	require.NotNil(t, data)
+	assert.Equal(t, oldSrv.URL+"/1.json", data.Uri)
+	assert.Equal(t, "NFT", data.Name)
+	assert.Equal(t, "NFT", data.Symbol)
+	assert.Equal(t, sfbp, data.SellerFeeBasisPoints)
+}
+
+func TestUpdateMetadata_NilData(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	authority := types.NewAccount().PublicKey
+	c := &mockClient{metadata: &token_metadata.Metadata{
+		UpdateAuthority: authority.ToBase58(),
+		Mint:            mint.ToBase58(),
+		IsMutable:       true,
+		MetadataUri:     "https://example.com/1.json",
+	}}
+
+	sfbp := uint16(500)
+	instr, err := instructions.UpdateMetadata(instructions.UpdateMetadataParams{
+		Mint:                 mint,
+		UpdateAuthority:      authority,
+		SellerFeeBasisPoints: &sfbp,
+	})(context.Background(), c)
+	require.NoError(t, err)
+
+	data := decodeUpdateMetadataData(t, instr[0])
+	require.NotNil(t, data)
+	assert.Equal(t, sfbp, data.SellerFeeBasisPoints)
+}
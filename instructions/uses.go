@@ -193,3 +193,82 @@ func UseToken(params UseTokenParams) InstructionFunc {
 		}, nil
 	}
 }
+
+// accountChecker checks whether an account exists, see client.Client.AccountExists.
+type accountChecker interface {
+	AccountExists(ctx context.Context, base58Addr string) (bool, error)
+}
+
+// UseTokenWithApprovalParams are the parameters for the UseTokenWithApproval instruction.
+type UseTokenWithApprovalParams struct {
+	FeePayer     common.PublicKey // required; the account to pay the fees
+	Mint         common.PublicKey // required; the token mint to use
+	MintOwner    common.PublicKey // required; the mint owner; must sign the transaction if the use authority isn't approved yet
+	UseAuthority common.PublicKey // required; the use authority to use the token
+	NumberOfUses uint64           // optional; the number of uses to approve if the use authority isn't approved yet; default is 1
+}
+
+// Validate checks that the required fields of the params are set.
+func (p UseTokenWithApprovalParams) Validate() error {
+	return UseTokenParams{
+		FeePayer:     p.FeePayer,
+		Mint:         p.Mint,
+		MintOwner:    p.MintOwner,
+		UseAuthority: p.UseAuthority,
+	}.Validate()
+}
+
+// UseTokenWithApproval instructs the mint to use the token like UseToken,
+// approving the use authority first if it has no use authority record yet.
+// The record is looked up via the client, which must implement AccountExists like client.Client does.
+func UseTokenWithApproval(params UseTokenWithApprovalParams) InstructionFunc {
+	return func(ctx context.Context, c Client) ([]types.Instruction, error) {
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("validate use token with approval: %w", err)
+		}
+		if params.NumberOfUses == 0 {
+			params.NumberOfUses = 1
+		}
+
+		checker, ok := c.(accountChecker)
+		if !ok {
+			return nil, fmt.Errorf("client is not able to check the use authority record")
+		}
+
+		useAuthorityRecord, err := metaplex_token_metadata.GetUseAuthorityRecord(params.Mint, params.UseAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get use authority record: %w", err)
+		}
+		approved, err := checker.AccountExists(ctx, useAuthorityRecord.ToBase58())
+		if err != nil {
+			return nil, fmt.Errorf("failed to check use authority record: %w", err)
+		}
+
+		var instructions []types.Instruction
+		if !approved {
+			approve, err := ApproveUseAuthority(ApproveUseAuthorityParams{
+				FeePayer:        params.FeePayer,
+				Mint:            params.Mint,
+				MintOwner:       params.MintOwner,
+				NewUseAuthority: params.UseAuthority,
+				NumberOfUses:    params.NumberOfUses,
+			})(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			instructions = append(instructions, approve...)
+		}
+
+		use, err := UseToken(UseTokenParams{
+			FeePayer:     params.FeePayer,
+			Mint:         params.Mint,
+			MintOwner:    params.MintOwner,
+			UseAuthority: params.UseAuthority,
+		})(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(instructions, use...), nil
+	}
+}
@@ -0,0 +1,54 @@
+package instructions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseTokenWithApproval(t *testing.T) {
+	feePayer := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+	params := instructions.UseTokenWithApprovalParams{
+		FeePayer:     feePayer,
+		Mint:         types.NewAccount().PublicKey,
+		MintOwner:    owner,
+		UseAuthority: feePayer,
+		NumberOfUses: 3,
+	}
+	record, err := metaplex_token_metadata.GetUseAuthorityRecord(params.Mint, params.UseAuthority)
+	require.NoError(t, err)
+
+	t.Run("auto approve", func(t *testing.T) {
+		instr, err := instructions.UseTokenWithApproval(params)(context.Background(), &mockClient{})
+		require.NoError(t, err)
+		require.Len(t, instr, 2)
+
+		assert.Equal(t, byte(metaplex_token_metadata.InstructionApproveUseAuthority), instr[0].Data[0])
+		assert.Equal(t, record, instr[0].Accounts[0].PubKey)
+		assert.Equal(t, owner, instr[0].Accounts[1].PubKey)
+		assert.True(t, instr[0].Accounts[1].IsSigner, "the owner must sign the approval")
+		// instruction, number of uses (u64 little endian)
+		assert.Equal(t, []byte{3, 0, 0, 0, 0, 0, 0, 0}, instr[0].Data[1:])
+
+		assert.Equal(t, byte(metaplex_token_metadata.InstructionUtilize), instr[1].Data[0])
+	})
+
+	t.Run("already approved", func(t *testing.T) {
+		c := &mockClient{accounts: map[string]bool{record.ToBase58(): true}}
+		instr, err := instructions.UseTokenWithApproval(params)(context.Background(), c)
+		require.NoError(t, err)
+		require.Len(t, instr, 1)
+		assert.Equal(t, byte(metaplex_token_metadata.InstructionUtilize), instr[0].Data[0])
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, err := instructions.UseTokenWithApproval(instructions.UseTokenWithApprovalParams{})(context.Background(), &mockClient{})
+		require.Error(t, err)
+	})
+}
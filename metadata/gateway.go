@@ -0,0 +1,84 @@
+package metadata
+
+import (
+	"strings"
+	"sync"
+)
+
+// Supported metadata URI schemes.
+const (
+	IPFSScheme    = "ipfs://"
+	ArweaveScheme = "ar://"
+)
+
+// Default gateways used to resolve decentralized storage URIs.
+const (
+	DefaultIPFSGateway    = "https://ipfs.io"
+	DefaultArweaveGateway = "https://arweave.net"
+)
+
+var (
+	gatewayMu      sync.RWMutex
+	ipfsGateway    = DefaultIPFSGateway
+	arweaveGateway = DefaultArweaveGateway
+)
+
+// SetIPFSGateway sets the gateway base URL used to resolve ipfs:// URIs,
+// e.g. "https://cloudflare-ipfs.com" or "nftstorage.link".
+// The https scheme is used if the base URL has no scheme.
+func SetIPFSGateway(baseURL string) {
+	gatewayMu.Lock()
+	defer gatewayMu.Unlock()
+	ipfsGateway = normalizeGateway(baseURL, DefaultIPFSGateway)
+}
+
+// SetArweaveGateway sets the gateway base URL used to resolve ar:// URIs.
+// The https scheme is used if the base URL has no scheme.
+func SetArweaveGateway(baseURL string) {
+	gatewayMu.Lock()
+	defer gatewayMu.Unlock()
+	arweaveGateway = normalizeGateway(baseURL, DefaultArweaveGateway)
+}
+
+// IsSupportedURI returns true if the metadata URI can be fetched:
+// http(s) URLs, ipfs:// and ar:// URIs.
+func IsSupportedURI(uri string) bool {
+	for _, prefix := range []string{"http://", "https://", IPFSScheme, ArweaveScheme} {
+		if strings.HasPrefix(uri, prefix) && len(uri) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveURI rewrites ipfs://<cid> to <ipfs gateway>/ipfs/<cid>
+// and ar://<id> to <arweave gateway>/<id>.
+// Any other URI is returned as is.
+func ResolveURI(uri string) string {
+	gatewayMu.RLock()
+	defer gatewayMu.RUnlock()
+
+	switch {
+	case strings.HasPrefix(uri, IPFSScheme):
+		// some tools produce ipfs://ipfs/<cid> URIs
+		path := strings.TrimPrefix(strings.TrimPrefix(uri, IPFSScheme), "ipfs/")
+		return ipfsGateway + "/ipfs/" + path
+	case strings.HasPrefix(uri, ArweaveScheme):
+		return arweaveGateway + "/" + strings.TrimPrefix(uri, ArweaveScheme)
+	}
+
+	return uri
+}
+
+// normalizeGateway adds the https scheme if missing and trims the trailing slash.
+// Returns the fallback if the base URL is empty.
+func normalizeGateway(baseURL, fallback string) string {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return fallback
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "https://" + baseURL
+	}
+	return baseURL
+}
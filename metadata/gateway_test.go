@@ -0,0 +1,65 @@
+package metadata_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dmitrymomot/solana/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveURI(t *testing.T) {
+	defer metadata.SetIPFSGateway("")
+	defer metadata.SetArweaveGateway("")
+
+	assert.Equal(t, "https://ipfs.io/ipfs/bafycid/1.json", metadata.ResolveURI("ipfs://bafycid/1.json"))
+	assert.Equal(t, "https://ipfs.io/ipfs/bafycid", metadata.ResolveURI("ipfs://ipfs/bafycid"))
+	assert.Equal(t, "https://arweave.net/txid", metadata.ResolveURI("ar://txid"))
+	assert.Equal(t, "https://example.com/1.json", metadata.ResolveURI("https://example.com/1.json"))
+
+	metadata.SetIPFSGateway("nftstorage.link/")
+	metadata.SetArweaveGateway("http://localhost:1984")
+	assert.Equal(t, "https://nftstorage.link/ipfs/bafycid", metadata.ResolveURI("ipfs://bafycid"))
+	assert.Equal(t, "http://localhost:1984/txid", metadata.ResolveURI("ar://txid"))
+}
+
+func TestIsSupportedURI(t *testing.T) {
+	for _, uri := range []string{"https://example.com/1.json", "http://example.com", "ipfs://bafycid", "ar://txid"} {
+		assert.True(t, metadata.IsSupportedURI(uri), uri)
+	}
+	for _, uri := range []string{"", "ipfs://", "ftp://example.com", "example.com/1.json"} {
+		assert.False(t, metadata.IsSupportedURI(uri), uri)
+	}
+}
+
+func TestMetadataFromURI_Gateways(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/bafycid":
+			_, _ = w.Write([]byte(`{"name":"IPFS Token"}`))
+		case "/txid":
+			_, _ = w.Write([]byte(`{"name":"Arweave Token"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	metadata.SetIPFSGateway(srv.URL)
+	defer metadata.SetIPFSGateway("")
+	metadata.SetArweaveGateway(srv.URL)
+	defer metadata.SetArweaveGateway("")
+
+	md, err := metadata.MetadataFromURIWithClient(srv.Client(), "ipfs://bafycid")
+	require.NoError(t, err)
+	assert.Equal(t, "IPFS Token", md.Name)
+
+	md, err = metadata.MetadataFromURIWithClient(srv.Client(), "ar://txid")
+	require.NoError(t, err)
+	assert.Equal(t, "Arweave Token", md.Name)
+
+	_, err = metadata.MetadataFromURIWithClient(srv.Client(), "ftp://example.com/1.json")
+	require.Error(t, err)
+}
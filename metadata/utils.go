@@ -1,10 +1,14 @@
 package metadata
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"time"
 )
 
 // MetadataFromJSON parses the metadata from JSON
@@ -18,24 +22,136 @@ func MetadataFromJSON(data []byte) (*Metadata, error) {
 	return m, nil
 }
 
+// Off-chain metadata fetching defaults.
+const (
+	DefaultHTTPTimeout = 10 * time.Second // default timeout of a single metadata request
+	DefaultMaxRetries  = 2                // number of retries after the failed first attempt
+)
+
+// retryDelay is the base delay between the metadata request attempts, it grows linearly.
+var retryDelay = 250 * time.Millisecond
+
+var (
+	httpClientMu sync.RWMutex
+	httpClient   = &http.Client{Timeout: DefaultHTTPTimeout}
+)
+
+// HTTPStatusError is returned when the metadata URI responds with a non-200 status code.
+type HTTPStatusError struct {
+	URI        string
+	StatusCode int
+}
+
+// Error returns the error message.
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected response status from metadata uri %s: %d %s", e.URI, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// temporary returns true if the request may succeed on retry.
+func (e *HTTPStatusError) temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// SetHTTPClient sets the http client used by MetadataFromURI.
+// The default client has DefaultHTTPTimeout timeout.
+func SetHTTPClient(c *http.Client) {
+	httpClientMu.Lock()
+	defer httpClientMu.Unlock()
+	httpClient = c
+}
+
+// getHTTPClient returns the http client used by MetadataFromURI.
+func getHTTPClient() *http.Client {
+	httpClientMu.RLock()
+	defer httpClientMu.RUnlock()
+	return httpClient
+}
+
 // MetadataFromURI parses the metadata from a URI
-// The URI must be a valid HTTP(S) URL
+// The URI must be a valid HTTP(S) URL, ipfs:// or ar:// URI
+// Uses the http client set by SetHTTPClient, see MetadataFromURIWithClient.
 func MetadataFromURI(uri string) (*Metadata, error) {
+	return MetadataFromURIWithClient(getHTTPClient(), uri)
+}
+
+// MetadataFromURIWithClient parses the metadata from a URI using the given http client.
+// The URI must be a valid HTTP(S) URL, ipfs:// and ar:// URIs are resolved via the configured gateways.
+// The request is retried up to DefaultMaxRetries times on network errors, 429 and 5xx responses.
+// Returns *HTTPStatusError if the URI responds with a non-200 status code.
+func MetadataFromURIWithClient(client *http.Client, uri string) (*Metadata, error) {
+	return MetadataFromURIWithContext(context.Background(), client, uri)
+}
+
+// MetadataFromURIWithContext parses the metadata from a URI, like MetadataFromURIWithClient,
+// aborting the requests and the retries once the context is done.
+// The http client set by SetHTTPClient is used if the client is nil.
+func MetadataFromURIWithContext(ctx context.Context, client *http.Client, uri string) (*Metadata, error) {
 	if uri == "" {
 		return nil, nil
 	}
+	if !IsSupportedURI(uri) {
+		return nil, fmt.Errorf("unsupported metadata uri: %s", uri)
+	}
+	if client == nil {
+		client = getHTTPClient()
+	}
+	uri = ResolveURI(uri)
+
+	var (
+		body []byte
+		err  error
+	)
+	for attempt := 0; attempt <= DefaultMaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("failed to download metadata from uri: %w", ctx.Err())
+			case <-time.After(time.Duration(attempt) * retryDelay):
+			}
+		}
+
+		body, err = fetchMetadata(ctx, client, uri)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		var statusErr *HTTPStatusError
+		if errors.As(err, &statusErr) && !statusErr.temporary() {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return MetadataFromJSON(body)
+}
 
-	resp, err := http.Get(uri)
+// fetchMetadata downloads the raw metadata from the URI.
+func fetchMetadata(ctx context.Context, client *http.Client, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download metadata from uri: %w", err)
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download metadata from uri: %w", err)
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{URI: uri, StatusCode: resp.StatusCode}
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read metadata from uri: %w", err)
 	}
 
-	return MetadataFromJSON(body)
+	return body, nil
 }
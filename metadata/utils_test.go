@@ -0,0 +1,92 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/solana/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataFromURIWithClient_Retry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"Test Token","symbol":"TST"}`))
+	}))
+	defer srv.Close()
+
+	md, err := metadata.MetadataFromURIWithClient(srv.Client(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Token", md.Name)
+	assert.Equal(t, "TST", md.Symbol)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestMetadataFromURIWithClient_Timeout(t *testing.T) {
+	var calls int32
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-time.After(time.Second):
+		case <-done:
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	client := srv.Client()
+	client.Timeout = 50 * time.Millisecond
+
+	_, err := metadata.MetadataFromURIWithClient(client, srv.URL)
+	require.Error(t, err)
+	assert.EqualValues(t, metadata.DefaultMaxRetries+1, atomic.LoadInt32(&calls))
+}
+
+func TestMetadataFromURIWithContext_Cancelled(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := metadata.MetadataFromURIWithContext(ctx, srv.Client(), srv.URL)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, atomic.LoadInt32(&calls), "cancelled request must not be sent or retried")
+}
+
+func TestMetadataFromURIWithClient_StatusError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := metadata.MetadataFromURIWithClient(srv.Client(), srv.URL)
+	var statusErr *metadata.HTTPStatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.Equal(t, srv.URL, statusErr.URI)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "non-retryable status must not be retried")
+}
+
+func TestMetadataFromURI_EmptyURI(t *testing.T) {
+	md, err := metadata.MetadataFromURI("")
+	require.NoError(t, err)
+	assert.Nil(t, md)
+}
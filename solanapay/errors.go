@@ -0,0 +1,13 @@
+package solanapay
+
+import "errors"
+
+// Predefined package errors
+var (
+	ErrInvalidRecipient = errors.New("invalid recipient public key")
+	ErrInvalidAmount    = errors.New("invalid amount; must be a non-negative decimal number within the token range, e.g. 1.5")
+	ErrInvalidSPLToken  = errors.New("invalid spl-token mint public key")
+	ErrInvalidReference = errors.New("invalid reference public key")
+	ErrInvalidURL       = errors.New("invalid solana pay url")
+	ErrInvalidScheme    = errors.New("invalid solana pay url scheme; must be solana:")
+)
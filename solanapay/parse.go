@@ -0,0 +1,87 @@
+package solanapay
+
+import (
+	"net/url"
+
+	commonx "github.com/dmitrymomot/solana/common"
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// TransferRequest is the decoded Solana Pay transfer request.
+type TransferRequest struct {
+	Recipient  common.PublicKey   // The wallet to send SOL or SPL tokens to
+	Amount     string             // The amount in the user units, e.g. "1.5"; empty if the wallet should prompt for it
+	SPLToken   *common.PublicKey  // The SPL token mint; nil if the transfer is in SOL
+	References []common.PublicKey // The public keys to find the transaction by
+	Label      string             // The source of the request, e.g. the merchant name
+	Message    string             // The description of the request, e.g. the order ID
+	Memo       string             // The memo to include into the transaction
+}
+
+// ParseURL decodes the Solana Pay transfer request URI, e.g.
+// solana:<recipient>?amount=1.5&spl-token=<mint>&reference=<reference>&label=Shop
+// Returns an error if the URI is malformed, e.g. has a wrong scheme, invalid public keys
+// or an out-of-range amount.
+func ParseURL(raw string) (*TransferRequest, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	if u.Scheme != URIScheme {
+		return nil, ErrInvalidScheme
+	}
+	if u.Opaque == "" {
+		return nil, ErrInvalidURL
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+
+	req := &TransferRequest{
+		Amount:  query.Get("amount"),
+		Label:   query.Get("label"),
+		Message: query.Get("message"),
+		Memo:    query.Get("memo"),
+	}
+
+	if req.Recipient, err = parsePublicKey(u.Opaque); err != nil {
+		return nil, ErrInvalidRecipient
+	}
+	if mint := query.Get("spl-token"); mint != "" {
+		pk, err := parsePublicKey(mint)
+		if err != nil {
+			return nil, ErrInvalidSPLToken
+		}
+		req.SPLToken = &pk
+	}
+	if _, ok := query["amount"]; ok {
+		if err := validateAmount(req.Amount, req.SPLToken == nil); err != nil {
+			return nil, err
+		}
+	}
+	for _, ref := range query["reference"] {
+		pk, err := parsePublicKey(ref)
+		if err != nil {
+			return nil, ErrInvalidReference
+		}
+		req.References = append(req.References, pk)
+	}
+
+	return req, nil
+}
+
+// parsePublicKey decodes the base58 encoded public key.
+// Unlike common.ValidateSolanaWalletAddr, it accepts the off-curve addresses, e.g. PDAs.
+func parsePublicKey(s string) (common.PublicKey, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	if len(b) != common.PublicKeyLength {
+		return common.PublicKey{}, commonx.ErrInvalidPublicKeyLength
+	}
+	return common.PublicKeyFromBytes(b), nil
+}
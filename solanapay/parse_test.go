@@ -0,0 +1,107 @@
+package solanapay_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/solana/solanapay"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURL_SOL(t *testing.T) {
+	req, err := solanapay.ParseURL("solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN?amount=1" +
+		"&label=Michael&message=Thanks%20for%20all%20the%20fish&memo=OrderId12345")
+	require.NoError(t, err)
+
+	assert.Equal(t, common.PublicKeyFromString("mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN"), req.Recipient)
+	assert.Equal(t, "1", req.Amount)
+	assert.Nil(t, req.SPLToken)
+	assert.Empty(t, req.References)
+	assert.Equal(t, "Michael", req.Label)
+	assert.Equal(t, "Thanks for all the fish", req.Message)
+	assert.Equal(t, "OrderId12345", req.Memo)
+}
+
+func TestParseURL_SPLToken(t *testing.T) {
+	req, err := solanapay.ParseURL("solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN?amount=0.01" +
+		"&spl-token=EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.01", req.Amount)
+	require.NotNil(t, req.SPLToken)
+	assert.Equal(t, common.PublicKeyFromString("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"), *req.SPLToken)
+
+	// spl token amounts may have more decimals than SOL
+	req, err = solanapay.ParseURL("solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN?amount=0.0000000001" +
+		"&spl-token=EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	require.NoError(t, err)
+	assert.Equal(t, "0.0000000001", req.Amount)
+}
+
+func TestParseURL_MultipleReferences(t *testing.T) {
+	req, err := solanapay.ParseURL("solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN" +
+		"?reference=82ZJ7nbGpixjeDCmEhUcmwXYfvurzAgGdtSMuHnUgyny" +
+		"&reference=HyRQcCqK6WqLTWUbUkkvNBGpFAYsCiqEX6ehvJbsTUY8")
+	require.NoError(t, err)
+
+	assert.Empty(t, req.Amount)
+	assert.Equal(t, []common.PublicKey{
+		common.PublicKeyFromString("82ZJ7nbGpixjeDCmEhUcmwXYfvurzAgGdtSMuHnUgyny"),
+		common.PublicKeyFromString("HyRQcCqK6WqLTWUbUkkvNBGpFAYsCiqEX6ehvJbsTUY8"),
+	}, req.References)
+}
+
+func TestParseURL_RoundTrip(t *testing.T) {
+	mint := common.PublicKeyFromString("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	params := solanapay.TransferRequestParams{
+		Recipient:  common.PublicKeyFromString("mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN"),
+		Amount:     "12.5",
+		SPLToken:   &mint,
+		References: []common.PublicKey{common.PublicKeyFromString("82ZJ7nbGpixjeDCmEhUcmwXYfvurzAgGdtSMuHnUgyny")},
+		Label:      "Café & Co",
+		Message:    "Order #1/2?",
+		Memo:       "a=b+c",
+	}
+
+	uri, err := solanapay.NewTransferRequest(params)
+	require.NoError(t, err)
+
+	req, err := solanapay.ParseURL(uri)
+	require.NoError(t, err)
+	assert.Equal(t, solanapay.TransferRequest(params), *req)
+}
+
+func TestParseURL_Errors(t *testing.T) {
+	const recipient = "mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN"
+
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr error
+	}{
+		{name: "wrong scheme", raw: "bitcoin:" + recipient, wantErr: solanapay.ErrInvalidScheme},
+		{name: "https scheme", raw: "https://example.com/" + recipient, wantErr: solanapay.ErrInvalidScheme},
+		{name: "hierarchical uri", raw: "solana://" + recipient, wantErr: solanapay.ErrInvalidURL},
+		{name: "missing recipient", raw: "solana:?amount=1", wantErr: solanapay.ErrInvalidURL},
+		{name: "invalid recipient", raw: "solana:not-a-key", wantErr: solanapay.ErrInvalidRecipient},
+		{name: "negative amount", raw: "solana:" + recipient + "?amount=-1", wantErr: solanapay.ErrInvalidAmount},
+		{name: "empty amount", raw: "solana:" + recipient + "?amount=", wantErr: solanapay.ErrInvalidAmount},
+		{name: "too many SOL decimals", raw: "solana:" + recipient + "?amount=0.0000000001", wantErr: solanapay.ErrInvalidAmount},
+		{name: "SOL amount overflow", raw: "solana:" + recipient + "?amount=18446744074", wantErr: solanapay.ErrInvalidAmount},
+		{
+			name:    "SPL amount overflow",
+			raw:     "solana:" + recipient + "?amount=18446744073709551616&spl-token=EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+			wantErr: solanapay.ErrInvalidAmount,
+		},
+		{name: "invalid spl token", raw: "solana:" + recipient + "?spl-token=abc", wantErr: solanapay.ErrInvalidSPLToken},
+		{name: "invalid reference", raw: "solana:" + recipient + "?reference=0OIl", wantErr: solanapay.ErrInvalidReference},
+		{name: "malformed query", raw: "solana:" + recipient + "?label=%zz", wantErr: solanapay.ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := solanapay.ParseURL(tt.raw)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package solanapay
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dmitrymomot/solana/types"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// Solana Pay constants.
+const (
+	URIScheme   = "solana" // Solana Pay URI scheme
+	SOLDecimals = 9        // number of decimals of the SOL amount
+)
+
+// amountRegexp matches the non-negative decimal amount without scientific notation.
+var amountRegexp = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// TransferRequestParams defines the parameters of the Solana Pay transfer request.
+type TransferRequestParams struct {
+	Recipient  common.PublicKey   // required; The wallet to send SOL or SPL tokens to
+	Amount     string             // optional; The amount in the user units, e.g. "1.5" SOL; the wallet prompts for it if empty
+	SPLToken   *common.PublicKey  // optional; The SPL token mint; the transfer is in SOL if nil
+	References []common.PublicKey // optional; The public keys to find the transaction by, see client.ValidateTransactionByReference
+	Label      string             // optional; The source of the request, e.g. the merchant name
+	Message    string             // optional; The description of the request, e.g. the order ID
+	Memo       string             // optional; The memo to include into the transaction (visible on-chain)
+}
+
+// Validate validates the params.
+func (p TransferRequestParams) Validate() error {
+	if p.Recipient == (common.PublicKey{}) {
+		return ErrInvalidRecipient
+	}
+	if p.Amount != "" {
+		if err := validateAmount(p.Amount, p.SPLToken == nil); err != nil {
+			return err
+		}
+	}
+	if p.SPLToken != nil && *p.SPLToken == (common.PublicKey{}) {
+		return ErrInvalidSPLToken
+	}
+	for _, ref := range p.References {
+		if ref == (common.PublicKey{}) {
+			return ErrInvalidReference
+		}
+	}
+	return nil
+}
+
+// NewTransferRequest creates the Solana Pay transfer request URI, e.g.
+// solana:<recipient>?amount=1.5&spl-token=<mint>&reference=<reference>&label=Shop&message=Order%20%23123
+// Use the reference with client.ValidateTransactionByReference to confirm the payment.
+func NewTransferRequest(params TransferRequestParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	query := make([]string, 0, 5+len(params.References))
+	if params.Amount != "" {
+		query = append(query, "amount="+params.Amount)
+	}
+	if params.SPLToken != nil {
+		query = append(query, "spl-token="+params.SPLToken.ToBase58())
+	}
+	for _, ref := range params.References {
+		query = append(query, "reference="+ref.ToBase58())
+	}
+	if params.Label != "" {
+		query = append(query, "label="+queryEscape(params.Label))
+	}
+	if params.Message != "" {
+		query = append(query, "message="+queryEscape(params.Message))
+	}
+	if params.Memo != "" {
+		query = append(query, "memo="+queryEscape(params.Memo))
+	}
+
+	uri := URIScheme + ":" + params.Recipient.ToBase58()
+	if len(query) > 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+
+	return uri, nil
+}
+
+// queryEscape escapes the string to be safely placed inside a URI query,
+// encoding spaces as %20 as the Solana Pay specification requires.
+func queryEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// validateAmount checks that the amount is a non-negative decimal number which fits into uint64 in the base units.
+// The SOL amount must not have more than 9 decimals; the SPL token decimals are unknown,
+// so only the integer part is checked.
+func validateAmount(amount string, sol bool) error {
+	if !amountRegexp.MatchString(amount) {
+		return ErrInvalidAmount
+	}
+
+	if sol {
+		if _, err := types.ParseTokenAmount(amount, SOLDecimals); err != nil {
+			return ErrInvalidAmount
+		}
+		return nil
+	}
+
+	integer := strings.SplitN(amount, ".", 2)[0]
+	if _, err := strconv.ParseUint(integer, 10, 64); err != nil {
+		return ErrInvalidAmount
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package solanapay_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/dmitrymomot/solana/solanapay"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransferRequest(t *testing.T) {
+	recipient := common.PublicKeyFromString("mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN")
+	mint := common.PublicKeyFromString("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	ref1 := common.PublicKeyFromString("82ZJ7nbGpixjeDCmEhUcmwXYfvurzAgGdtSMuHnUgyny")
+	ref2 := common.PublicKeyFromString("HyRQcCqK6WqLTWUbUkkvNBGpFAYsCiqEX6ehvJbsTUY8")
+
+	tests := []struct {
+		name   string
+		params solanapay.TransferRequestParams
+		want   string
+	}{
+		{
+			name:   "recipient only",
+			params: solanapay.TransferRequestParams{Recipient: recipient},
+			want:   "solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN",
+		},
+		{
+			name:   "sol amount",
+			params: solanapay.TransferRequestParams{Recipient: recipient, Amount: "1"},
+			want:   "solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN?amount=1",
+		},
+		{
+			name: "sol transfer with all fields",
+			params: solanapay.TransferRequestParams{
+				Recipient:  recipient,
+				Amount:     "0.000000001",
+				References: []common.PublicKey{ref1},
+				Label:      "Michael",
+				Message:    "Thanks for all the fish",
+				Memo:       "OrderId12345",
+			},
+			want: "solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN?amount=0.000000001" +
+				"&reference=82ZJ7nbGpixjeDCmEhUcmwXYfvurzAgGdtSMuHnUgyny" +
+				"&label=Michael&message=Thanks%20for%20all%20the%20fish&memo=OrderId12345",
+		},
+		{
+			name: "spl token transfer with multiple references",
+			params: solanapay.TransferRequestParams{
+				Recipient:  recipient,
+				Amount:     "0.01",
+				SPLToken:   &mint,
+				References: []common.PublicKey{ref1, ref2},
+			},
+			want: "solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN?amount=0.01" +
+				"&spl-token=EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v" +
+				"&reference=82ZJ7nbGpixjeDCmEhUcmwXYfvurzAgGdtSMuHnUgyny" +
+				"&reference=HyRQcCqK6WqLTWUbUkkvNBGpFAYsCiqEX6ehvJbsTUY8",
+		},
+		{
+			name: "special characters",
+			params: solanapay.TransferRequestParams{
+				Recipient: recipient,
+				Label:     "Café & Co",
+				Message:   "Order #1/2?",
+				Memo:      "a=b+c",
+			},
+			want: "solana:mvines9iiHiQTysrwkJjGf2gb9Ex9jXJX8ns3qwf2kN" +
+				"?label=Caf%C3%A9%20%26%20Co&message=Order%20%231%2F2%3F&memo=a%3Db%2Bc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := solanapay.NewTransferRequest(tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// the URI must be parsed back to the same values
+			u, err := url.Parse(got)
+			require.NoError(t, err)
+			assert.Equal(t, solanapay.URIScheme, u.Scheme)
+			assert.Equal(t, tt.params.Recipient.ToBase58(), u.Opaque)
+			q := u.Query()
+			assert.Equal(t, tt.params.Amount, q.Get("amount"))
+			assert.Equal(t, tt.params.Label, q.Get("label"))
+			assert.Equal(t, tt.params.Message, q.Get("message"))
+			assert.Equal(t, tt.params.Memo, q.Get("memo"))
+			assert.Len(t, q["reference"], len(tt.params.References))
+		})
+	}
+}
+
+func TestNewTransferRequest_Validation(t *testing.T) {
+	recipient := types.NewAccount().PublicKey
+
+	tests := []struct {
+		name    string
+		params  solanapay.TransferRequestParams
+		wantErr error
+	}{
+		{name: "missing recipient", params: solanapay.TransferRequestParams{Amount: "1"}, wantErr: solanapay.ErrInvalidRecipient},
+		{name: "negative amount", params: solanapay.TransferRequestParams{Recipient: recipient, Amount: "-1"}, wantErr: solanapay.ErrInvalidAmount},
+		{name: "scientific notation", params: solanapay.TransferRequestParams{Recipient: recipient, Amount: "1e-9"}, wantErr: solanapay.ErrInvalidAmount},
+		{name: "trailing dot", params: solanapay.TransferRequestParams{Recipient: recipient, Amount: "1."}, wantErr: solanapay.ErrInvalidAmount},
+		{name: "not a number", params: solanapay.TransferRequestParams{Recipient: recipient, Amount: "one"}, wantErr: solanapay.ErrInvalidAmount},
+		{name: "empty spl token", params: solanapay.TransferRequestParams{Recipient: recipient, SPLToken: &common.PublicKey{}}, wantErr: solanapay.ErrInvalidSPLToken},
+		{name: "empty reference", params: solanapay.TransferRequestParams{Recipient: recipient, References: []common.PublicKey{{}}}, wantErr: solanapay.ErrInvalidReference},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := solanapay.NewTransferRequest(tt.params)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
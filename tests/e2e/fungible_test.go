@@ -106,6 +106,27 @@ func TestFungibleToken(t *testing.T) {
 		})
 	})
 
+	// Transfer with wrong decimals must be rejected by the token program on the preflight simulation
+	t.Run("transfer token checked with wrong decimals", func(t *testing.T) {
+		tx, err := transaction.NewTransactionBuilder(sc).
+			SetFeePayer(e2e.FeePayerPubkey).
+			AddInstruction(instructions.TransferTokenChecked(instructions.TransferTokenCheckedParam{
+				Mint:      mint.PublicKey,
+				Amount:    supplyAmount / 2,
+				Decimals:  types.SPLTokenDefaultDecimals + 1,
+				Sender:    e2e.Wallet1Pubkey,
+				Recipient: e2e.Wallet1Pubkey,
+			})).
+			Build(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, tx)
+
+		txHash, txStatus, err := e2e.SignAndSendTransaction(ctx, sc, tx, e2e.FeePayerPrivateKey, e2e.Wallet1PrivateKey)
+		require.Error(t, err)
+		fmt.Println("tx:", txHash, "status:", txStatus)
+		require.EqualValues(t, txStatus, types.TransactionStatusFailure)
+	})
+
 	// Burn token
 	t.Run("burn fungible token and close token account", func(t *testing.T) {
 		// Burn token and close token account
@@ -465,7 +486,7 @@ func TestFreezeTokenAccount(t *testing.T) {
 			ata, err := common.DeriveTokenAccount(e2e.Wallet1Pubkey.ToBase58(), mint.PublicKey.ToBase58())
 			require.NoError(t, err)
 			ataInfo, err := sc.GetTokenAccountInfo(ctx, ata.ToBase58())
-			require.Error(t, err)
+			require.ErrorIs(t, err, client.ErrTokenAccountNotFound)
 			require.EqualValues(t, ataInfo, token.TokenAccount{})
 		})
 	})
@@ -648,3 +669,49 @@ func TestDisableMintingOfFungibleToken(t *testing.T) {
 		})
 	})
 }
+
+func TestFungibleAsset(t *testing.T) {
+	var (
+		supplyAmount uint64 = 100
+		mint                = common.NewAccount()
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sc := client.New(client.SetSolanaEndpoint(e2e.SolanaDevnetRPCNode))
+
+	t.Run("mint fungible asset", func(t *testing.T) {
+		tx, err := transaction.NewTransactionBuilder(sc).
+			SetFeePayer(e2e.FeePayerPubkey).
+			AddSigner(mint).
+			AddInstruction(instructions.MintFungibleAsset(instructions.MintFungibleAssetParam{
+				Mint:         mint.PublicKey,
+				MintTo:       e2e.Wallet1Pubkey,
+				FeePayer:     &e2e.FeePayerPubkey,
+				SupplyAmount: supplyAmount,
+				TokenName:    "Test Asset",
+				TokenSymbol:  "TSTa",
+			})).
+			Build(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, tx)
+
+		txHash, txStatus, err := e2e.SignAndSendTransaction(ctx, sc, tx, e2e.FeePayerPrivateKey, e2e.Wallet1PrivateKey)
+		require.NoError(t, err)
+		fmt.Println("tx:", txHash, "status:", txStatus)
+		require.NotEmpty(t, txHash)
+		require.EqualValues(t, txStatus, types.TransactionStatusSuccess)
+	})
+
+	t.Run("check asset balance and metadata", func(t *testing.T) {
+		balance, err := sc.GetTokenBalance(ctx, e2e.Wallet1Pubkey.ToBase58(), mint.PublicKey.ToBase58())
+		require.NoError(t, err)
+		require.EqualValues(t, supplyAmount, balance.Amount)
+		require.EqualValues(t, 0, balance.Decimals)
+
+		metadata, err := sc.GetTokenMetadata(ctx, mint.PublicKey.ToBase58())
+		require.NoError(t, err)
+		require.EqualValues(t, token_metadata.TokenStandardFungibleAsset, metadata.TokenStandard)
+	})
+}
@@ -36,3 +36,22 @@ func TestGetTokensList(t *testing.T) {
 		// utils.PrettyPrint(metadata)
 	}
 }
+
+func TestGetTokenLargestAccounts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sc := client.New(client.SetSolanaEndpoint(e2e.SolanaDevnetRPCNode))
+
+	accounts, err := sc.GetTokenLargestAccounts(ctx, e2e.TokenMintPubkey.ToBase58())
+	require.NoError(t, err)
+	require.NotEmpty(t, accounts)
+	require.LessOrEqual(t, len(accounts), 20)
+
+	for i, acc := range accounts {
+		require.Equal(t, e2e.TokenMintPubkey, acc.Mint)
+		if i > 0 {
+			require.GreaterOrEqual(t, accounts[i-1].Balance.Amount, acc.Balance.Amount)
+		}
+	}
+}
@@ -11,7 +11,7 @@ import (
 
 // SignAndSendTransaction signs a transaction by the fee payer and the wallet1 and sends it.
 // Returns the transaction hash and status or an error.
-func SignAndSendTransaction(ctx context.Context, client *client.Client, tx string, signers ...string) (string, types.TransactionStatus, error) {
+func SignAndSendTransaction(ctx context.Context, sc *client.Client, tx string, signers ...string) (string, types.TransactionStatus, error) {
 	if tx == "" {
 		return "", types.TransactionStatusUnknown, fmt.Errorf("empty transaction")
 	}
@@ -26,24 +26,13 @@ func SignAndSendTransaction(ctx context.Context, client *client.Client, tx strin
 			if err != nil {
 				return "", types.TransactionStatusUnknown, fmt.Errorf("failed to create signer account: %w", err)
 			}
-			tx, err = client.SignTransaction(ctx, signerAcc, tx)
+			tx, err = sc.SignTransaction(ctx, signerAcc, tx)
 			if err != nil {
 				return "", types.TransactionStatusUnknown, fmt.Errorf("failed to sign transaction by signer: %w", err)
 			}
 		}
 	}
 
-	// Send the transaction
-	txHash, err := client.SendTransaction(ctx, tx)
-	if err != nil {
-		return "", types.TransactionStatusFailure, fmt.Errorf("failed to send transaction: %w", err)
-	}
-
-	// Wait for the transaction to be confirmed
-	txInfo, err := client.WaitForTransactionConfirmed(ctx, txHash, 0)
-	if err != nil {
-		return "", types.TransactionStatusUnknown, fmt.Errorf("failed to wait for transaction to be confirmed: %w", err)
-	}
-
-	return txHash, txInfo, nil
+	// Send the transaction and wait for it to be confirmed
+	return sc.SendAndConfirmTransaction(ctx, tx, client.ConfirmOptions{})
 }
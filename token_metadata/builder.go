@@ -20,6 +20,7 @@ type TokenMetadataInstructionBuilder struct {
 	updateAuthorityIsSigner *bool
 	isMutable               *bool
 	data                    token_metadata.DataV2
+	collectionSize          *uint64
 }
 
 // NewTokenMetadataInstructionBuilder creates a new TokenMetadataInstructionBuilder
@@ -157,7 +158,13 @@ func (b *TokenMetadataInstructionBuilder) SetCreatorBase58(creator string, share
 // SetCollection sets the on-chain collection
 // This is used to group tokens together.
 // Collection should be verified by the owner of the collection.
+// The zero public key, e.g. from an empty base58 string, removes the collection
+// instead of writing a bogus collection reference.
 func (b *TokenMetadataInstructionBuilder) SetCollection(collection common.PublicKey) *TokenMetadataInstructionBuilder {
+	if collection == (common.PublicKey{}) {
+		b.data.Collection = nil
+		return b
+	}
 	b.data.Collection = &token_metadata.Collection{
 		Key: collection,
 	}
@@ -169,6 +176,13 @@ func (b *TokenMetadataInstructionBuilder) SetCollectionBase58(collection string)
 	return b.SetCollection(common.PublicKeyFromString(collection))
 }
 
+// SetCollectionDetails marks the token as a sized collection NFT with the given number of the verified items.
+// Use 0 for a new collection. Supported by Build only; BuildV2 returns an error if it's set.
+func (b *TokenMetadataInstructionBuilder) SetCollectionDetails(size uint64) *TokenMetadataInstructionBuilder {
+	b.collectionSize = utils.Pointer(size)
+	return b
+}
+
 // SetUses provides a way to set the number of times a token can be used.
 // This is useful for NFTs that can be used multiple times.
 // For example, a ticket NFT that can be used once.
@@ -192,20 +206,65 @@ func (b *TokenMetadataInstructionBuilder) SetUses(method TokenUseMethod, remaini
 	return b
 }
 
-// Build builds the instruction
+// Build builds the CreateMetadataAccountV3 instruction
 func (b *TokenMetadataInstructionBuilder) Build() (meta common.PublicKey, instruction types.Instruction, err error) {
+	if err := b.prepare(); err != nil {
+		return PubNil, types.Instruction{}, err
+	}
+
+	params := token_metadata.CreateMetadataAccountV3Param{
+		Metadata:                b.metadata,
+		Mint:                    b.mint,
+		MintAuthority:           b.mintAuthority,
+		Payer:                   b.payer,
+		UpdateAuthority:         *b.updateAuthority,
+		UpdateAuthorityIsSigner: *b.updateAuthorityIsSigner,
+		IsMutable:               *b.isMutable,
+		Data:                    b.data,
+		CollectionSize:          b.collectionSize,
+	}
+
+	return b.metadata, token_metadata.CreateMetadataAccountV3(params), nil
+}
+
+// BuildV2 builds the deprecated CreateMetadataAccountV2 instruction.
+// Kept for backward compatibility; use Build instead.
+func (b *TokenMetadataInstructionBuilder) BuildV2() (meta common.PublicKey, instruction types.Instruction, err error) {
+	if b.collectionSize != nil {
+		return PubNil, types.Instruction{}, fmt.Errorf("collection details are not supported by CreateMetadataAccountV2")
+	}
+	if err := b.prepare(); err != nil {
+		return PubNil, types.Instruction{}, err
+	}
+
+	params := token_metadata.CreateMetadataAccountV2Param{
+		Metadata:                b.metadata,
+		Mint:                    b.mint,
+		MintAuthority:           b.mintAuthority,
+		Payer:                   b.payer,
+		UpdateAuthority:         *b.updateAuthority,
+		UpdateAuthorityIsSigner: *b.updateAuthorityIsSigner,
+		IsMutable:               *b.isMutable,
+		Data:                    b.data,
+	}
+
+	return b.metadata, token_metadata.CreateMetadataAccountV2(params), nil
+}
+
+// prepare validates the required fields and sets the defaults of the optional ones.
+func (b *TokenMetadataInstructionBuilder) prepare() error {
 	if b.mint == PubNil {
-		return PubNil, types.Instruction{}, fmt.Errorf("mint public key is required")
+		return fmt.Errorf("mint public key is required")
 	}
 
 	if b.payer == PubNil {
-		return PubNil, types.Instruction{}, fmt.Errorf("payer public key is required")
+		return fmt.Errorf("payer public key is required")
 	}
 
 	if b.metadata == PubNil {
 		mintMeta, err := token_metadata.GetTokenMetaPubkey(b.mint)
 		if err != nil {
-			return PubNil, types.Instruction{}, fmt.Errorf("failed to get metadata account pubkey: %w", err)
+			return fmt.Errorf("failed to get metadata account pubkey: %w", err)
 		}
 		b.metadata = mintMeta
 	}
@@ -218,16 +277,5 @@ func (b *TokenMetadataInstructionBuilder) Build() (meta common.PublicKey, instru
 		b.SetUpdateAuthority(b.payer)
 	}
 
-	params := token_metadata.CreateMetadataAccountV2Param{
-		Metadata:                b.metadata,
-		Mint:                    b.mint,
-		MintAuthority:           b.mintAuthority,
-		Payer:                   b.payer,
-		UpdateAuthority:         *b.updateAuthority,
-		UpdateAuthorityIsSigner: *b.updateAuthorityIsSigner,
-		IsMutable:               *b.isMutable,
-		Data:                    b.data,
-	}
-
-	return b.metadata, token_metadata.CreateMetadataAccountV2(params), nil
+	return nil
 }
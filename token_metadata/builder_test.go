@@ -0,0 +1,91 @@
+package token_metadata_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenMetadataInstructionBuilder_Build(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	payer := types.NewAccount().PublicKey
+
+	meta, instr, err := token_metadata.NewTokenMetadataInstructionBuilder().
+		SetMint(mint).
+		SetPayer(payer).
+		SetName("Collection").
+		SetSymbol("COL").
+		SetCollectionDetails(42).
+		Build()
+	require.NoError(t, err)
+
+	expected, err := metaplex_token_metadata.GetTokenMetaPubkey(mint)
+	require.NoError(t, err)
+	assert.Equal(t, expected, meta)
+	assert.Equal(t, expected, instr.Accounts[0].PubKey)
+
+	require.NotEmpty(t, instr.Data)
+	assert.Equal(t, byte(metaplex_token_metadata.InstructionCreateMetadataAccountV3), instr.Data[0])
+	// collection details: Some(V1 { size: 42 })
+	details := instr.Data[len(instr.Data)-10:]
+	assert.Equal(t, []byte{1, 0}, details[:2])
+	assert.Equal(t, uint64(42), binary.LittleEndian.Uint64(details[2:]))
+
+	// regular NFT: no collection details
+	_, instr, err = token_metadata.NewTokenMetadataInstructionBuilder().
+		SetMint(mint).
+		SetPayer(payer).
+		SetName("NFT").
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, byte(metaplex_token_metadata.InstructionCreateMetadataAccountV3), instr.Data[0])
+	assert.Equal(t, byte(0), instr.Data[len(instr.Data)-1])
+}
+
+func TestTokenMetadataInstructionBuilder_BuildV2(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	payer := types.NewAccount().PublicKey
+
+	_, instr, err := token_metadata.NewTokenMetadataInstructionBuilder().
+		SetMint(mint).
+		SetPayer(payer).
+		SetName("NFT").
+		BuildV2()
+	require.NoError(t, err)
+	assert.Equal(t, byte(metaplex_token_metadata.InstructionCreateMetadataAccountV2), instr.Data[0])
+
+	_, _, err = token_metadata.NewTokenMetadataInstructionBuilder().
+		SetMint(mint).
+		SetPayer(payer).
+		SetCollectionDetails(0).
+		BuildV2()
+	require.Error(t, err)
+
+	_, _, err = token_metadata.NewTokenMetadataInstructionBuilder().SetMint(mint).Build()
+	require.Error(t, err)
+}
+
+func TestTokenMetadataInstructionBuilder_EmptyCollection(t *testing.T) {
+	mint := types.NewAccount().PublicKey
+	payer := types.NewAccount().PublicKey
+
+	build := func(b *token_metadata.TokenMetadataInstructionBuilder) []byte {
+		_, instr, err := b.SetMint(mint).SetPayer(payer).SetName("NFT").Build()
+		require.NoError(t, err)
+		return instr.Data
+	}
+
+	expected := build(token_metadata.NewTokenMetadataInstructionBuilder())
+	assert.Equal(t, expected, build(token_metadata.NewTokenMetadataInstructionBuilder().SetCollectionBase58("")))
+	assert.Equal(t, expected, build(token_metadata.NewTokenMetadataInstructionBuilder().
+		SetCollection(types.NewAccount().PublicKey).
+		SetCollection(common.PublicKey{})))
+	assert.NotEqual(t, expected, build(token_metadata.NewTokenMetadataInstructionBuilder().
+		SetCollection(types.NewAccount().PublicKey)))
+}
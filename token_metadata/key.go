@@ -20,6 +20,7 @@ const (
 	KeyEditionMarker             Key = "edition_marker"
 	KeyUseAuthorityRecord        Key = "use_authority_record"
 	KeyCollectionAuthorityRecord Key = "collection_authority_record"
+	KeyTokenRecord               Key = "token_record"
 )
 
 // Map token_metadata.Key to string
@@ -34,6 +35,7 @@ var keysMap = map[token_metadata.Key]Key{
 	token_metadata.KeyEditionMarker:             KeyEditionMarker,
 	token_metadata.KeyUseAuthorityRecord:        KeyUseAuthorityRecord,
 	token_metadata.KeyCollectionAuthorityRecord: KeyCollectionAuthorityRecord,
+	token_metadata.KeyTokenRecord:               KeyTokenRecord,
 }
 
 // Cast token_metadata.Key to Key
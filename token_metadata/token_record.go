@@ -0,0 +1,132 @@
+package token_metadata
+
+import (
+	"fmt"
+
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+)
+
+// TokenState is the state of the programmable NFT token account.
+type TokenState string
+
+// String returns the string representation of the token state.
+func (s TokenState) String() string {
+	return string(s)
+}
+
+// Token states enum
+const (
+	TokenStateUnlocked TokenState = "unlocked"
+	TokenStateLocked   TokenState = "locked"
+	TokenStateListed   TokenState = "listed"
+)
+
+// Map the token record state to TokenState; the order matches the program enum.
+var tokenStates = []TokenState{
+	TokenStateUnlocked,
+	TokenStateLocked,
+	TokenStateListed,
+}
+
+// TokenDelegateRole is the role of the programmable NFT token delegate.
+type TokenDelegateRole string
+
+// String returns the string representation of the token delegate role.
+func (r TokenDelegateRole) String() string {
+	return string(r)
+}
+
+// Token delegate roles enum
+const (
+	TokenDelegateRoleSale           TokenDelegateRole = "sale"
+	TokenDelegateRoleTransfer       TokenDelegateRole = "transfer"
+	TokenDelegateRoleUtility        TokenDelegateRole = "utility"
+	TokenDelegateRoleStaking        TokenDelegateRole = "staking"
+	TokenDelegateRoleStandard       TokenDelegateRole = "standard"
+	TokenDelegateRoleLockedTransfer TokenDelegateRole = "locked_transfer"
+	TokenDelegateRoleMigration      TokenDelegateRole = "migration"
+)
+
+// Map the token record delegate role to TokenDelegateRole; the order matches the program enum.
+var tokenDelegateRoles = []TokenDelegateRole{
+	TokenDelegateRoleSale,
+	TokenDelegateRoleTransfer,
+	TokenDelegateRoleUtility,
+	TokenDelegateRoleStaking,
+	TokenDelegateRoleStandard,
+	TokenDelegateRoleLockedTransfer,
+	TokenDelegateRoleMigration,
+}
+
+// TokenRecord is the state of the programmable NFT token account,
+// stored by the token metadata program in the token record account.
+type TokenRecord struct {
+	State           TokenState        `json:"state"`
+	RuleSetRevision *uint64           `json:"rule_set_revision,omitempty"`
+	Delegate        string            `json:"delegate,omitempty"`        // base58 encoded delegate public key; empty if there is no delegate
+	DelegateRole    TokenDelegateRole `json:"delegate_role,omitempty"`   // empty if there is no delegate
+	LockedTransfer  string            `json:"locked_transfer,omitempty"` // base58 encoded address the token is allowed to be transferred to while locked
+}
+
+// IsLocked returns true if the token is locked by the delegate, so it can't be transferred or burned.
+func (r TokenRecord) IsLocked() bool {
+	return r.State == TokenStateLocked
+}
+
+// DeriveTokenRecordPubkey returns the token record public key of the given programmable NFT mint and token account.
+func DeriveTokenRecordPubkey(mint, tokenAccount common.PublicKey) (common.PublicKey, error) {
+	pk, err := token_metadata.GetTokenRecord(mint, tokenAccount)
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to derive token record pubkey: %w", err)
+	}
+
+	return pk, nil
+}
+
+// DeserializeTokenRecord deserializes the token record account data.
+func DeserializeTokenRecord(data []byte) (*TokenRecord, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("failed to deserialize token record: data is empty")
+	}
+	if token_metadata.Key(data[0]) != token_metadata.KeyTokenRecord {
+		return nil, fmt.Errorf("failed to deserialize token record: unexpected account key: %d", data[0])
+	}
+
+	// the sdk token record has the outdated layout, so it's decoded here
+	var record struct {
+		Key             uint8
+		Bump            uint8
+		State           uint8
+		RuleSetRevision *uint64
+		Delegate        *common.PublicKey
+		DelegateRole    *uint8
+		LockedTransfer  *common.PublicKey
+	}
+	if err := borsh.Deserialize(&record, data); err != nil {
+		return nil, fmt.Errorf("failed to deserialize token record: %w", err)
+	}
+
+	if int(record.State) >= len(tokenStates) {
+		return nil, fmt.Errorf("failed to deserialize token record: unknown token state: %d", record.State)
+	}
+	result := &TokenRecord{
+		State:           tokenStates[record.State],
+		RuleSetRevision: record.RuleSetRevision,
+	}
+	if record.Delegate != nil {
+		result.Delegate = record.Delegate.ToBase58()
+	}
+	if record.DelegateRole != nil {
+		if int(*record.DelegateRole) >= len(tokenDelegateRoles) {
+			return nil, fmt.Errorf("failed to deserialize token record: unknown delegate role: %d", *record.DelegateRole)
+		}
+		result.DelegateRole = tokenDelegateRoles[*record.DelegateRole]
+	}
+	if record.LockedTransfer != nil {
+		result.LockedTransfer = record.LockedTransfer.ToBase58()
+	}
+
+	return result, nil
+}
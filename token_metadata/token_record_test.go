@@ -0,0 +1,75 @@
+package token_metadata_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveTokenRecordPubkey(t *testing.T) {
+	mint := common.PublicKeyFromString("7Ucm1SKXbwfPZfxGbvXBqkpAiUq4ttqkJmbGpQkfLeYV")
+	ata := common.PublicKeyFromString("GoUfphwrd3obEPkEv39977Vyw6YnXK823fMiNBcX1Csd")
+
+	pk, err := token_metadata.DeriveTokenRecordPubkey(mint, ata)
+	require.NoError(t, err)
+	assert.Equal(t, "CmWEs3dpXQ4JGX8cq3B3MZ4QgPM9uEWpwA3qXYZVcJLP", pk.ToBase58())
+}
+
+func TestDeserializeTokenRecord(t *testing.T) {
+	delegate := common.PublicKeyFromString("4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T")
+	lockedTransfer := common.PublicKeyFromString("9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ")
+
+	// token record accounts are allocated with a fixed size of 80 bytes
+	record := func(fields ...byte) []byte {
+		data := append([]byte{11, 255}, fields...)
+		return append(data, make([]byte, 80-len(data))...)
+	}
+
+	t.Run("unlocked without delegate", func(t *testing.T) {
+		rec, err := token_metadata.DeserializeTokenRecord(record(0, 0, 0, 0, 0))
+		require.NoError(t, err)
+		assert.Equal(t, &token_metadata.TokenRecord{State: token_metadata.TokenStateUnlocked}, rec)
+		assert.False(t, rec.IsLocked())
+	})
+
+	t.Run("listed with rule set revision and locked transfer delegate", func(t *testing.T) {
+		fields := []byte{2, 1, 7, 0, 0, 0, 0, 0, 0, 0, 1}
+		fields = append(fields, delegate.Bytes()...)
+		fields = append(fields, 1, 5, 1)
+		fields = append(fields, lockedTransfer.Bytes()...)
+
+		rec, err := token_metadata.DeserializeTokenRecord(record(fields...))
+		require.NoError(t, err)
+		assert.Equal(t, token_metadata.TokenStateListed, rec.State)
+		require.NotNil(t, rec.RuleSetRevision)
+		assert.EqualValues(t, 7, *rec.RuleSetRevision)
+		assert.Equal(t, delegate.ToBase58(), rec.Delegate)
+		assert.Equal(t, token_metadata.TokenDelegateRoleLockedTransfer, rec.DelegateRole)
+		assert.Equal(t, lockedTransfer.ToBase58(), rec.LockedTransfer)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := token_metadata.DeserializeTokenRecord(nil)
+		assert.Error(t, err)
+
+		// metadata account key
+		_, err = token_metadata.DeserializeTokenRecord(append([]byte{4}, record(0, 0, 0, 0, 0)[1:]...))
+		assert.Error(t, err)
+
+		// unknown state
+		_, err = token_metadata.DeserializeTokenRecord(record(3, 0, 0, 0, 0))
+		assert.Error(t, err)
+
+		// unknown delegate role
+		fields := append([]byte{1, 0, 1}, delegate.Bytes()...)
+		_, err = token_metadata.DeserializeTokenRecord(record(append(fields, 1, 7, 0)...))
+		assert.Error(t, err)
+
+		// truncated
+		_, err = token_metadata.DeserializeTokenRecord([]byte{11, 255, 0, 1})
+		assert.Error(t, err)
+	})
+}
@@ -22,6 +22,7 @@ type (
 		EditionNonce         *uint8             `json:"edition_nonce,omitempty"`
 		TokenStandard        string             `json:"token_standard"`
 		Collection           *Collection        `json:"collection,omitempty"`
+		CollectionDetails    *CollectionDetails `json:"collection_details,omitempty"`
 		Uses                 *Uses              `json:"uses,omitempty"`
 		Edition              *Edition           `json:"edition,omitempty"`
 		MetadataUri          string             `json:"metadata_uri,omitempty"`
@@ -33,8 +34,11 @@ type (
 	Edition struct {
 		Type      string `json:"type,omitempty"`
 		Supply    uint64 `json:"supply,omitempty"`
-		MaxSupply uint64 `json:"max_supply,omitempty"`
+		MaxSupply uint64 `json:"max_supply,omitempty"` // 0 if UnlimitedSupply is set
 		Edition   uint64 `json:"edition,omitempty"`
+		Parent    string `json:"parent,omitempty"` // base58 encoded master edition account of the print edition; empty for the master edition
+
+		UnlimitedSupply bool `json:"unlimited_supply,omitempty"` // the master edition has no max supply, so any number of editions can be printed
 	}
 
 	EditionKey struct {
@@ -53,6 +57,11 @@ type (
 		Size     uint64 `json:"size,omitempty"`
 	}
 
+	// CollectionDetails is set on the sized collection NFT; nil for the unsized collections and other tokens.
+	CollectionDetails struct {
+		Size uint64 `json:"size"` // number of the verified collection items
+	}
+
 	Uses struct {
 		UseMethod string `json:"use_method"`
 		Total     uint64 `json:"total"`
@@ -68,3 +77,39 @@ type (
 	// GetAccountInfoFunc is a function that returns the account info of a given address.
 	getAccountInfoFunc func(ctx context.Context, base58Addr string) (client.AccountInfo, error)
 )
+
+// IsPrintEdition returns true if the token is a print edition of a master edition.
+func (m *Metadata) IsPrintEdition() bool {
+	return m.Edition != nil && m.Edition.Type == KeyPrintedEdition.String()
+}
+
+// EditionNumber returns the number of the print edition, e.g. 3 for "Edition 3 of 10",
+// where 10 is Edition.MaxSupply. Returns 0 if the token isn't a print edition.
+func (m *Metadata) EditionNumber() uint64 {
+	if !m.IsPrintEdition() {
+		return 0
+	}
+	return m.Edition.Edition
+}
+
+// MasterEdition returns the base58 encoded master edition account the token is printed from.
+// Returns an empty string if the token isn't a print edition.
+// The master edition mint isn't stored on-chain, use IsPrintOf to check a known master mint.
+func (m *Metadata) MasterEdition() string {
+	if !m.IsPrintEdition() {
+		return ""
+	}
+	return m.Edition.Parent
+}
+
+// IsPrintOf returns true if the token is a print edition of the given master edition mint.
+func (m *Metadata) IsPrintOf(masterMint common.PublicKey) bool {
+	if m.MasterEdition() == "" {
+		return false
+	}
+	masterEdition, err := DeriveEditionPubkey(masterMint)
+	if err != nil {
+		return false
+	}
+	return masterEdition.ToBase58() == m.Edition.Parent
+}
@@ -0,0 +1,151 @@
+package token_metadata_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dmitrymomot/solana/token_metadata"
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	metaplex_token_metadata "github.com/portto/solana-go-sdk/program/metaplex/token_metadata"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata_PrintEdition(t *testing.T) {
+	masterMint := types.NewAccount().PublicKey
+	masterEdition, err := token_metadata.DeriveEditionPubkey(masterMint)
+	require.NoError(t, err)
+
+	// print edition 3 of the master edition with max supply 10, 4 editions printed
+	printData, err := borsh.Serialize(token_metadata.EditionData{
+		Key:     metaplex_token_metadata.KeyEditionV1,
+		Parent:  masterEdition,
+		Edition: 3,
+	})
+	require.NoError(t, err)
+	maxSupply := uint64(10)
+	masterData, err := borsh.Serialize(metaplex_token_metadata.MasterEditionV2{
+		Key:       metaplex_token_metadata.KeyMasterEditionV2,
+		Supply:    4,
+		MaxSupply: &maxSupply,
+	})
+	require.NoError(t, err)
+
+	edition, err := token_metadata.DeserializeEdition(context.Background(), printData, func(ctx context.Context, base58Addr string) (client.AccountInfo, error) {
+		if base58Addr != masterEdition.ToBase58() {
+			return client.AccountInfo{}, fmt.Errorf("unexpected account: %s", base58Addr)
+		}
+		return client.AccountInfo{Owner: common.MetaplexTokenMetaProgramID, Data: masterData}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, token_metadata.KeyPrintedEdition.String(), edition.Type)
+	assert.Equal(t, masterEdition.ToBase58(), edition.Parent)
+	assert.EqualValues(t, 10, edition.MaxSupply)
+
+	md := &token_metadata.Metadata{Edition: edition}
+	assert.True(t, md.IsPrintEdition())
+	assert.EqualValues(t, 3, md.EditionNumber())
+	assert.Equal(t, masterEdition.ToBase58(), md.MasterEdition())
+	assert.True(t, md.IsPrintOf(masterMint))
+	assert.False(t, md.IsPrintOf(types.NewAccount().PublicKey))
+}
+
+func TestMetadata_MasterEdition(t *testing.T) {
+	maxSupply := uint64(10)
+	masterData, err := borsh.Serialize(metaplex_token_metadata.MasterEditionV2{
+		Key:       metaplex_token_metadata.KeyMasterEditionV2,
+		Supply:    4,
+		MaxSupply: &maxSupply,
+	})
+	require.NoError(t, err)
+
+	edition, err := token_metadata.DeserializeEdition(context.Background(), masterData, nil)
+	require.NoError(t, err)
+	assert.Empty(t, edition.Parent)
+
+	for _, md := range []*token_metadata.Metadata{{Edition: edition}, {}} {
+		assert.False(t, md.IsPrintEdition())
+		assert.Zero(t, md.EditionNumber())
+		assert.Empty(t, md.MasterEdition())
+		assert.False(t, md.IsPrintOf(types.NewAccount().PublicKey))
+	}
+}
+
+func TestDeserializeMasterEdition_UnlimitedSupply(t *testing.T) {
+	data, err := borsh.Serialize(metaplex_token_metadata.MasterEditionV2{
+		Key:    metaplex_token_metadata.KeyMasterEditionV2,
+		Supply: 4,
+	})
+	require.NoError(t, err)
+
+	edition, err := token_metadata.DeserializeMasterEdition(data)
+	require.NoError(t, err)
+	assert.True(t, edition.UnlimitedSupply)
+	assert.Zero(t, edition.MaxSupply)
+	assert.EqualValues(t, 4, edition.Supply)
+
+	edition, err = token_metadata.DeserializeEdition(context.Background(), data, nil)
+	require.NoError(t, err)
+	assert.True(t, edition.UnlimitedSupply)
+	assert.Zero(t, edition.MaxSupply)
+
+	maxSupply := uint64(0)
+	data, err = borsh.Serialize(metaplex_token_metadata.MasterEditionV2{
+		Key:       metaplex_token_metadata.KeyMasterEditionV2,
+		MaxSupply: &maxSupply,
+	})
+	require.NoError(t, err)
+
+	// zero max supply is not unlimited
+	edition, err = token_metadata.DeserializeMasterEdition(data)
+	require.NoError(t, err)
+	assert.False(t, edition.UnlimitedSupply)
+	assert.Zero(t, edition.MaxSupply)
+}
+
+func TestDeserializeMetadata_CollectionDetails(t *testing.T) {
+	serialize := func(details *metaplex_token_metadata.CollectionDetails) []byte {
+		data, err := borsh.Serialize(metaplex_token_metadata.Metadata{
+			Key:               metaplex_token_metadata.KeyMetadataV1,
+			UpdateAuthority:   types.NewAccount().PublicKey,
+			Mint:              types.NewAccount().PublicKey,
+			Data:              metaplex_token_metadata.Data{Name: "Collection", Symbol: "COL"},
+			CollectionDetails: details,
+		})
+		require.NoError(t, err)
+		return data
+	}
+
+	sized, err := token_metadata.DeserializeMetadata(context.Background(), serialize(&metaplex_token_metadata.CollectionDetails{
+		V1: metaplex_token_metadata.CollectionDetailsV1{Size: 42},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &token_metadata.CollectionDetails{Size: 42}, sized.CollectionDetails)
+	assert.Nil(t, sized.Collection)
+
+	unsized, err := token_metadata.DeserializeMetadata(context.Background(), serialize(nil))
+	require.NoError(t, err)
+	assert.Nil(t, unsized.CollectionDetails)
+}
+
+func TestDeserializeMetadata_UnreachableURI(t *testing.T) {
+	data, err := borsh.Serialize(metaplex_token_metadata.Metadata{
+		Key:             metaplex_token_metadata.KeyMetadataV1,
+		UpdateAuthority: types.NewAccount().PublicKey,
+		Mint:            types.NewAccount().PublicKey,
+		Data:            metaplex_token_metadata.Data{Name: "NFT", Symbol: "NFT", Uri: "ftp://example.com/1.json"},
+	})
+	require.NoError(t, err)
+
+	// the on-chain name and symbol are kept if the metadata json can't be downloaded
+	md, err := token_metadata.DeserializeMetadata(context.Background(), data)
+	require.NoError(t, err)
+	require.NotNil(t, md.Data)
+	assert.Equal(t, "NFT", md.Data.Name)
+	assert.Equal(t, "NFT", md.Data.Symbol)
+	assert.Equal(t, "ftp://example.com/1.json", md.MetadataUri)
+}
@@ -21,7 +21,26 @@ func DeriveTokenMetadataPubkey(mint common.PublicKey) (common.PublicKey, error)
 }
 
 // DeserializeMetadata deserializes the metadata.
-func DeserializeMetadata(data []byte) (*Metadata, error) {
+// The off-chain metadata is downloaded from the metadata URI within the given context;
+// the on-chain name and symbol are kept if the download fails.
+func DeserializeMetadata(ctx context.Context, data []byte) (*Metadata, error) {
+	m, err := DeserializeOnChainMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.MetadataUri != "" {
+		if mdp, err := metadata.MetadataFromURIWithContext(ctx, nil, m.MetadataUri); err == nil && mdp != nil {
+			m.Data = mdp
+		}
+	}
+
+	return m, nil
+}
+
+// DeserializeOnChainMetadata deserializes the metadata without downloading the off-chain metadata,
+// so Data holds only the on-chain name and symbol.
+func DeserializeOnChainMetadata(data []byte) (*Metadata, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("failed to deserialize metadata: data is empty")
 	}
@@ -39,25 +58,16 @@ func DeserializeMetadata(data []byte) (*Metadata, error) {
 		TokenStandard:        "unknown",
 		MetadataUri:          md.Data.Uri,
 		SellerFeeBasisPoints: md.Data.SellerFeeBasisPoints,
-		Data:                 &metadata.Metadata{},
+		Data: &metadata.Metadata{
+			Name:   md.Data.Name,
+			Symbol: md.Data.Symbol,
+		},
 	}
 
 	if md.TokenStandard != nil {
 		m.TokenStandard = CastToTokenStandard(*md.TokenStandard).String()
 	}
 
-	if md.Data.Uri != "" {
-		mdp, err := metadata.MetadataFromURI(md.Data.Uri)
-		if err != nil {
-			m.Data.Name = md.Data.Name
-			m.Data.Symbol = md.Data.Symbol
-		}
-		m.Data = mdp
-	} else {
-		m.Data.Name = md.Data.Name
-		m.Data.Symbol = md.Data.Symbol
-	}
-
 	if md.Collection != nil {
 		m.Collection = &Collection{
 			Verified: md.Collection.Verified,
@@ -68,6 +78,10 @@ func DeserializeMetadata(data []byte) (*Metadata, error) {
 		}
 	}
 
+	if md.CollectionDetails != nil {
+		m.CollectionDetails = &CollectionDetails{Size: md.CollectionDetails.V1.Size}
+	}
+
 	if md.Uses != nil {
 		m.Uses = &Uses{
 			UseMethod: CastMetadataUseMethod(md.Uses.UseMethod).String(),
@@ -103,10 +117,8 @@ func DeriveEditionPubkey(mint common.PublicKey) (common.PublicKey, error) {
 }
 
 // DeserializeEdition deserializes the edition.
-func DeserializeEdition(data []byte, getAccountInfo getAccountInfoFunc) (*Edition, error) {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
+// The parent master edition of a print is fetched with getAccountInfo within the given context.
+func DeserializeEdition(ctx context.Context, data []byte, getAccountInfo getAccountInfoFunc) (*Edition, error) {
 	var edition token_metadata.MasterEditionV2
 	if err := borsh.Deserialize(&edition, data); err != nil {
 		return nil, fmt.Errorf("failed to deserialize edition key: %w", err)
@@ -121,6 +133,7 @@ func DeserializeEdition(data []byte, getAccountInfo getAccountInfoFunc) (*Editio
 		}
 
 		e.MaxSupply = masterEdition.MaxSupply
+		e.UnlimitedSupply = masterEdition.UnlimitedSupply
 		e.Supply = masterEdition.Supply
 
 	} else if edition.Key == token_metadata.KeyEditionV1 {
@@ -130,6 +143,9 @@ func DeserializeEdition(data []byte, getAccountInfo getAccountInfoFunc) (*Editio
 		}
 
 		e.Edition = editionData.Edition
+		if editionData.Parent != PubNil {
+			e.Parent = editionData.Parent.ToBase58()
+		}
 
 		if editionData.Parent != PubNil && getAccountInfo != nil {
 			parent, err := getAccountInfo(ctx, editionData.Parent.ToBase58())
@@ -143,6 +159,7 @@ func DeserializeEdition(data []byte, getAccountInfo getAccountInfoFunc) (*Editio
 			}
 
 			e.MaxSupply = masterEdition.MaxSupply
+			e.UnlimitedSupply = masterEdition.UnlimitedSupply
 			e.Supply = masterEdition.Supply
 		}
 	}
@@ -151,16 +168,23 @@ func DeserializeEdition(data []byte, getAccountInfo getAccountInfoFunc) (*Editio
 }
 
 // DeserializeMasterEdition deserializes the master edition data.
+// The master edition without max supply results in Edition.UnlimitedSupply and zero Edition.MaxSupply.
 func DeserializeMasterEdition(data []byte) (*Edition, error) {
 	masterEdition := &token_metadata.MasterEditionV2{}
 	if err := borsh.Deserialize(masterEdition, data); err != nil {
 		return nil, fmt.Errorf("failed to deserialize master edition: %w", err)
 	}
-	return &Edition{
-		Type:      CastToKey(masterEdition.Key).String(),
-		MaxSupply: *masterEdition.MaxSupply,
-		Supply:    masterEdition.Supply,
-	}, nil
+
+	e := &Edition{
+		Type:            CastToKey(masterEdition.Key).String(),
+		Supply:          masterEdition.Supply,
+		UnlimitedSupply: masterEdition.MaxSupply == nil,
+	}
+	if masterEdition.MaxSupply != nil {
+		e.MaxSupply = *masterEdition.MaxSupply
+	}
+
+	return e, nil
 }
 
 // DeriveEditionMarkerPubkey returns the edition marker public key.
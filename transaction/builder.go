@@ -8,20 +8,29 @@ import (
 	"github.com/dmitrymomot/solana/instructions"
 	"github.com/dmitrymomot/solana/token_metadata"
 	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/system"
 	"github.com/portto/solana-go-sdk/program/token"
 	"github.com/portto/solana-go-sdk/types"
 )
 
 type (
 	// TransactionBuilder is a builder for transactions.
+	// The builder is not safe for concurrent use; create a builder per transaction,
+	// while the client passed to NewTransactionBuilder may be shared by the builders.
 	TransactionBuilder struct {
-		client           solanaClient                   // solana client wrapper
-		feePayer         *common.PublicKey              // transaction fee payer
-		signers          []types.Account                // additional transaction signers
-		instructions     []instructions.InstructionFunc // transaction instructions
-		isDurrableTx     bool                           // is durable transaction
-		durableNonce     *common.PublicKey              // durable nonce account
-		durableNonceAuth *common.PublicKey              // durable nonce auth account
+		client           solanaClient                      // solana client wrapper
+		feePayer         *common.PublicKey                 // transaction fee payer
+		signers          []types.Account                   // additional transaction signers
+		generatedSigners []types.Account                   // signers generated by NewSigner, also included in signers
+		instructions     []instructions.InstructionFunc    // transaction instructions
+		isDurrableTx     bool                              // is durable transaction
+		durableNonce     *common.PublicKey                 // durable nonce account
+		durableNonceAuth *common.PublicKey                 // durable nonce auth account
+		lookupTables     []types.AddressLookupTableAccount // address lookup tables of the v0 message
+		simulator        transactionSimulator              // simulates the built transaction; nil if the simulation is disabled
+		budgetEstimator  computeBudgetEstimator            // estimates the compute budget; nil if the budget isn't set automatically
+		feePercentile    *uint8                            // percentile of the recent prioritization fees used as the compute unit price
+		references       []common.PublicKey                // Solana Pay reference keys appended to the last instruction
 	}
 
 	// solanaClient is a wrapper for the solana client.
@@ -35,6 +44,11 @@ type (
 		NewTransaction(ctx context.Context, params client.NewTransactionParams) (string, error)
 		NewDurableTransaction(ctx context.Context, params client.NewDurableTransactionParams) (string, error)
 	}
+
+	// transactionSimulator simulates transactions, see client.Client.SimulateTransaction.
+	transactionSimulator interface {
+		SimulateTransaction(ctx context.Context, txSource string) (*client.SimulationResult, error)
+	}
 )
 
 // NewTransactionBuilder creates a new transaction builder.
@@ -42,11 +56,40 @@ func NewTransactionBuilder(c solanaClient) *TransactionBuilder {
 	return &TransactionBuilder{client: c}
 }
 
+// UseDurableNonce builds a durable transaction, which doesn't expire with the recent blockhash.
+// The current nonce is fetched from the nonce account at build time and used as the message blockhash,
+// and the instruction advancing the nonce is prepended to the transaction instructions.
+// The nonce authority must sign the transaction; it pays the fee unless SetFeePayer is called.
+// The latest blockhash is used by default.
+func (tb *TransactionBuilder) UseDurableNonce(nonceAccount, nonceAuthority common.PublicKey) *TransactionBuilder {
+	tb.isDurrableTx = true
+	tb.durableNonce = &nonceAccount
+	tb.durableNonceAuth = &nonceAuthority
+	return tb
+}
+
 // SetDurableNonce sets the transaction as durable via nonce account.
+// It's the same as UseDurableNonce.
 func (tb *TransactionBuilder) SetDurableNonce(nonce, nonceAuth common.PublicKey) *TransactionBuilder {
-	tb.isDurrableTx = true
-	tb.durableNonce = &nonce
-	tb.durableNonceAuth = &nonceAuth
+	return tb.UseDurableNonce(nonce, nonceAuth)
+}
+
+// UseVersionedMessage builds the transaction with a v0 message,
+// which references the accounts found in the given address lookup tables by index
+// instead of including their public keys. This allows to fit more accounts into a transaction.
+// Signers, the fee payer and invoked programs are never looked up.
+// The legacy message is used if no tables are given.
+func (tb *TransactionBuilder) UseVersionedMessage(tables ...types.AddressLookupTableAccount) *TransactionBuilder {
+	tb.lookupTables = tables
+	return tb
+}
+
+// SimulateBeforeBuild makes Build simulate the built transaction with the given client
+// and return an error if the transaction would fail.
+// The returned error wraps *client.SimulationError, which includes the program logs.
+// The simulation is an extra request to the RPC node, so it's disabled by default.
+func (tb *TransactionBuilder) SimulateBeforeBuild(c transactionSimulator) *TransactionBuilder {
+	tb.simulator = c
 	return tb
 }
 
@@ -56,6 +99,15 @@ func (tb *TransactionBuilder) AddInstruction(instruction instructions.Instructio
 	return tb
 }
 
+// AddReference adds the Solana Pay reference public key to the transaction,
+// so the payment can be found by it, see client.Client.ValidateTransactionByReference.
+// The reference is appended as a read-only non-signer account to the last instruction
+// which is not a memo, since the memo program requires all its accounts to sign.
+func (tb *TransactionBuilder) AddReference(ref common.PublicKey) *TransactionBuilder {
+	tb.references = append(tb.references, ref)
+	return tb
+}
+
 // SetFeePayer sets the transaction fee payer.
 func (tb *TransactionBuilder) SetFeePayer(feePayer common.PublicKey) *TransactionBuilder {
 	tb.feePayer = &feePayer
@@ -68,47 +120,186 @@ func (tb *TransactionBuilder) AddSigner(signer types.Account) *TransactionBuilde
 	return tb
 }
 
+// NewSigner generates a new keypair, e.g. for a new mint or nonce account,
+// and adds it to the transaction signers.
+// Returns the generated keypair; all of them are also returned by GeneratedSigners,
+// so the private keys can be persisted after the build.
+func (tb *TransactionBuilder) NewSigner() types.Account {
+	signer := types.NewAccount()
+	tb.generatedSigners = append(tb.generatedSigners, signer)
+	tb.signers = append(tb.signers, signer)
+	return signer
+}
+
+// GeneratedSigners returns the keypairs generated by the builder, in the generation order.
+// The keypairs added via AddSigner are not included.
+func (tb *TransactionBuilder) GeneratedSigners() []types.Account {
+	result := make([]types.Account, len(tb.generatedSigners))
+	copy(result, tb.generatedSigners)
+	return result
+}
+
 // Build builds the transaction.
+// If SimulateBeforeBuild is set, the built transaction is simulated
+// and the simulation error is returned if the transaction would fail.
 // Returns the base64 encoded transaction or an error.
 func (tb *TransactionBuilder) Build(ctx context.Context) (string, error) {
-	instructions := make([]types.Instruction, 0, len(tb.instructions))
-	for _, instruction := range tb.instructions {
-		subInstructions, err := instruction(ctx, tb.client)
+	tx, err := tb.build(ctx)
+	if err != nil || tb.simulator == nil {
+		return tx, err
+	}
+
+	result, err := tb.simulator.SimulateTransaction(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to build transaction: %w", err)
+	}
+	if err := result.ToError(); err != nil {
+		return "", fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// build builds the transaction without the simulation.
+func (tb *TransactionBuilder) build(ctx context.Context) (string, error) {
+	instructions, err := tb.buildInstructions(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	if tb.budgetEstimator != nil {
+		instructions, err = tb.prependComputeBudget(ctx, instructions)
 		if err != nil {
 			return "", fmt.Errorf("failed to build transaction: %w", err)
 		}
-		if len(subInstructions) > 0 {
-			instructions = append(instructions, subInstructions...)
-		}
 	}
 
+	tx, err := tb.newTransaction(ctx, instructions)
+	if err != nil {
+		return "", fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// newTransaction creates the transaction with the given instructions.
+func (tb *TransactionBuilder) newTransaction(ctx context.Context, instructions []types.Instruction) (string, error) {
 	if tb.isDurrableTx {
 		if tb.durableNonce == nil || *tb.durableNonce == (common.PublicKey{}) {
-			return "", fmt.Errorf("failed to build transaction: missing or invalid durable nonce public key")
+			return "", fmt.Errorf("missing or invalid durable nonce public key")
 		}
 		if tb.durableNonceAuth == nil || *tb.durableNonceAuth == (common.PublicKey{}) {
-			return "", fmt.Errorf("failed to build transaction: missing or invalid durable nonce auth public key")
+			return "", fmt.Errorf("missing or invalid durable nonce auth public key")
 		}
 		if tb.feePayer == nil || *tb.feePayer == (common.PublicKey{}) {
 			tb.feePayer = tb.durableNonceAuth
 		}
 
 		return tb.client.NewDurableTransaction(ctx, client.NewDurableTransactionParams{
-			FeePayer:     tb.feePayer,
-			Instructions: instructions,
-			Signers:      tb.signers,
-			DurableNonce: *tb.durableNonce,
-			NonceAuth:    *tb.durableNonceAuth,
+			FeePayer:            tb.feePayer,
+			Instructions:        instructions,
+			Signers:             tb.signers,
+			DurableNonce:        *tb.durableNonce,
+			NonceAuth:           *tb.durableNonceAuth,
+			AddressLookupTables: tb.lookupTables,
 		})
 	}
 
 	if tb.feePayer == nil || *tb.feePayer == (common.PublicKey{}) {
-		return "", fmt.Errorf("failed to build transaction: missing or invalid fee payer public key")
+		return "", fmt.Errorf("missing or invalid fee payer public key")
 	}
 
 	return tb.client.NewTransaction(ctx, client.NewTransactionParams{
-		FeePayer:     *tb.feePayer,
-		Instructions: instructions,
-		Signers:      tb.signers,
+		FeePayer:            *tb.feePayer,
+		Instructions:        instructions,
+		Signers:             tb.signers,
+		AddressLookupTables: tb.lookupTables,
 	})
 }
+
+// WillExceedSizeLimit returns true if the built transaction will exceed MaxTransactionSize.
+// It resolves all the added instructions within the given context, so it may send requests to the RPC node.
+// Returns false if the size can't be estimated, e.g. because of the missing fee payer,
+// a failed instruction or the done context; Build reports such errors.
+func (tb *TransactionBuilder) WillExceedSizeLimit(ctx context.Context) bool {
+	instructions, err := tb.buildInstructions(ctx)
+	if err != nil {
+		return false
+	}
+
+	feePayer := tb.feePayer
+	if tb.isDurrableTx {
+		if tb.durableNonce == nil || tb.durableNonceAuth == nil {
+			return false
+		}
+		if feePayer == nil || *feePayer == (common.PublicKey{}) {
+			feePayer = tb.durableNonceAuth
+		}
+		instructions = append([]types.Instruction{
+			system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{
+				Nonce: *tb.durableNonce,
+				Auth:  *tb.durableNonceAuth,
+			}),
+		}, instructions...)
+	}
+	if feePayer == nil {
+		return false
+	}
+
+	size, err := EstimateSize(instructions, *feePayer, tb.lookupTables...)
+	if err != nil {
+		return false
+	}
+
+	return size > MaxTransactionSize
+}
+
+// buildInstructions resolves the instruction functions into the list of instructions.
+func (tb *TransactionBuilder) buildInstructions(ctx context.Context) ([]types.Instruction, error) {
+	instructions := make([]types.Instruction, 0, len(tb.instructions))
+	for _, instruction := range tb.instructions {
+		// the instructions may not send requests, so stop explicitly once the caller gave up
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		subInstructions, err := instruction(ctx, tb.client)
+		if err != nil {
+			return nil, err
+		}
+		if len(subInstructions) > 0 {
+			instructions = append(instructions, subInstructions...)
+		}
+	}
+
+	if len(tb.references) > 0 {
+		if err := appendReferences(instructions, tb.references); err != nil {
+			return nil, err
+		}
+	}
+
+	return instructions, nil
+}
+
+// appendReferences appends the reference keys as read-only non-signer accounts
+// to the last instruction which is not a memo.
+func appendReferences(instructions []types.Instruction, references []common.PublicKey) error {
+	for i := len(instructions) - 1; i >= 0; i-- {
+		if instructions[i].ProgramID == common.MemoProgramID {
+			continue
+		}
+
+		accounts := make([]types.AccountMeta, 0, len(instructions[i].Accounts)+len(references))
+		accounts = append(accounts, instructions[i].Accounts...)
+		for _, ref := range references {
+			if ref == (common.PublicKey{}) {
+				return fmt.Errorf("invalid reference public key")
+			}
+			accounts = append(accounts, types.AccountMeta{PubKey: ref, IsSigner: false, IsWritable: false})
+		}
+		instructions[i].Accounts = accounts
+
+		return nil
+	}
+
+	return fmt.Errorf("no instruction to attach the reference to")
+}
@@ -0,0 +1,393 @@
+package transaction_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/transaction"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/system"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBlockhashClient returns a client which talks to a mock RPC node serving the latest blockhash
+// and the minimum balance for rent exemption.
+func newBlockhashClient(t *testing.T) *client.Client {
+	t.Helper()
+
+	return newRPCClient(t, map[string]func() interface{}{
+		"getLatestBlockhash": func() interface{} {
+			return map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": map[string]interface{}{
+					"blockhash":            types.NewAccount().PublicKey.ToBase58(),
+					"lastValidBlockHeight": 100,
+				},
+			}
+		},
+		"getMinimumBalanceForRentExemption": func() interface{} { return 1_000_000 },
+	})
+}
+
+// newRPCClient returns a client which talks to a mock RPC node serving the results of the given methods.
+func newRPCClient(t *testing.T, results map[string]func() interface{}) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result interface{}
+		if fn, ok := results[req.Method]; ok {
+			result = fn()
+		} else {
+			t.Errorf("unexpected RPC method: %s", req.Method)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	return client.New(client.SetSolanaEndpoint(srv.URL))
+}
+
+func TestTransactionBuilder_UseVersionedMessage(t *testing.T) {
+	payer := types.NewAccount()
+	recipients := make([]common.PublicKey, 20)
+	for i := range recipients {
+		recipients[i] = types.NewAccount().PublicKey
+	}
+
+	build := func(tables ...types.AddressLookupTableAccount) types.Message {
+		tb := transaction.NewTransactionBuilder(newBlockhashClient(t)).
+			SetFeePayer(payer.PublicKey).
+			AddSigner(payer).
+			UseVersionedMessage(tables...)
+		for _, recipient := range recipients {
+			tb.AddInstruction(instructions.TransferSOL(instructions.TransferSOLParams{
+				Sender:    payer.PublicKey,
+				Recipient: recipient,
+				Amount:    1000,
+			}))
+		}
+
+		txStr, err := tb.Build(context.Background())
+		require.NoError(t, err)
+		tx, err := utils.DecodeTransaction(txStr)
+		require.NoError(t, err)
+		return tx.Message
+	}
+
+	legacy := build()
+	assert.Equal(t, types.MessageVersion(types.MessageVersionLegacy), legacy.Version)
+	// fee payer, recipients, system program
+	assert.Len(t, legacy.Accounts, len(recipients)+2)
+
+	table := types.AddressLookupTableAccount{
+		Key:       types.NewAccount().PublicKey,
+		Addresses: append([]common.PublicKey{common.SystemProgramID}, recipients...),
+	}
+	v0 := build(table)
+	assert.Equal(t, types.MessageVersion(types.MessageVersionV0), v0.Version)
+	// the invoked system program is never looked up
+	assert.Len(t, v0.Accounts, 2)
+	require.Len(t, v0.AddressLookupTables, 1)
+	assert.Equal(t, table.Key, v0.AddressLookupTables[0].AccountKey)
+	assert.Len(t, v0.AddressLookupTables[0].WritableIndexes, len(recipients))
+
+	legacySize, err := legacy.Serialize()
+	require.NoError(t, err)
+	v0Size, err := v0.Serialize()
+	require.NoError(t, err)
+	assert.Less(t, len(v0Size), len(legacySize))
+}
+
+// simulatorFunc is a transaction simulator backed by a function.
+type simulatorFunc func(ctx context.Context, txSource string) (*client.SimulationResult, error)
+
+func (f simulatorFunc) SimulateTransaction(ctx context.Context, txSource string) (*client.SimulationResult, error) {
+	return f(ctx, txSource)
+}
+
+func TestTransactionBuilder_SimulateBeforeBuild(t *testing.T) {
+	payer := types.NewAccount()
+	recipient := types.NewAccount().PublicKey
+
+	// the simulated payer has no lamports, so any transfer fails
+	logs := []string{
+		"Program 11111111111111111111111111111111 invoke [1]",
+		"Transfer: insufficient lamports 0, need 1000",
+		"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+	}
+	code := uint32(1)
+	var simulated []string
+	simulator := simulatorFunc(func(_ context.Context, txSource string) (*client.SimulationResult, error) {
+		simulated = append(simulated, txSource)
+
+		tx, err := utils.DecodeTransaction(txSource)
+		require.NoError(t, err)
+		if len(tx.Message.Instructions) == 0 {
+			return &client.SimulationResult{}, nil
+		}
+		return &client.SimulationResult{
+			Err: &client.TransactionError{
+				Type:             client.TransactionErrorInstructionError,
+				InstructionError: "Custom",
+				CustomCode:       &code,
+			},
+			Logs: logs,
+		}, nil
+	})
+
+	newBuilder := func() *transaction.TransactionBuilder {
+		return transaction.NewTransactionBuilder(newBlockhashClient(t)).
+			SetFeePayer(payer.PublicKey).
+			AddSigner(payer).
+			AddInstruction(instructions.TransferSOL(instructions.TransferSOLParams{
+				Sender:    payer.PublicKey,
+				Recipient: recipient,
+				Amount:    1000,
+			}))
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		simulated = nil
+
+		txStr, err := newBuilder().Build(context.Background())
+		require.NoError(t, err)
+		assert.NotEmpty(t, txStr)
+		assert.Empty(t, simulated)
+	})
+
+	t.Run("failed simulation", func(t *testing.T) {
+		simulated = nil
+
+		txStr, err := newBuilder().SimulateBeforeBuild(simulator).Build(context.Background())
+		require.Error(t, err)
+		assert.Empty(t, txStr)
+		require.Len(t, simulated, 1)
+
+		var simErr *client.SimulationError
+		require.ErrorAs(t, err, &simErr)
+		assert.Equal(t, logs, simErr.Logs)
+		assert.Contains(t, err.Error(), "custom program error: 0x1")
+		assert.Contains(t, err.Error(), "Transfer: insufficient lamports 0, need 1000")
+
+		var txErr *client.TransactionError
+		require.ErrorAs(t, err, &txErr)
+		assert.Equal(t, code, *txErr.CustomCode)
+	})
+
+	t.Run("successful simulation", func(t *testing.T) {
+		simulated = nil
+
+		txStr, err := transaction.NewTransactionBuilder(newBlockhashClient(t)).
+			SetFeePayer(payer.PublicKey).
+			AddSigner(payer).
+			SimulateBeforeBuild(simulator).
+			Build(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{txStr}, simulated)
+	})
+
+	t.Run("simulation request failed", func(t *testing.T) {
+		_, err := newBuilder().
+			SimulateBeforeBuild(simulatorFunc(func(context.Context, string) (*client.SimulationResult, error) {
+				return nil, client.ErrSimulateTransaction
+			})).
+			Build(context.Background())
+		require.ErrorIs(t, err, client.ErrSimulateTransaction)
+	})
+}
+
+func TestTransactionBuilder_AddReference(t *testing.T) {
+	payer := types.NewAccount()
+	recipient := types.NewAccount().PublicKey
+	reference := types.NewAccount().PublicKey
+
+	txStr, err := transaction.NewTransactionBuilder(newBlockhashClient(t)).
+		SetFeePayer(payer.PublicKey).
+		AddSigner(payer).
+		AddInstruction(instructions.TransferSOL(instructions.TransferSOLParams{
+			Sender:    payer.PublicKey,
+			Recipient: recipient,
+			Amount:    1000,
+		})).
+		AddInstruction(instructions.Memo("order #1", payer.PublicKey)).
+		AddReference(reference).
+		Build(context.Background())
+	require.NoError(t, err)
+
+	tx, err := utils.DecodeTransaction(txStr)
+	require.NoError(t, err)
+
+	idx := -1
+	for i, acc := range tx.Message.Accounts {
+		if acc == reference {
+			idx = i
+		}
+	}
+	require.NotEqual(t, -1, idx, "reference is not in the account keys")
+
+	// non-signer read-only accounts are at the end of the account keys
+	header := tx.Message.Header
+	assert.GreaterOrEqual(t, idx, int(header.NumRequireSignatures))
+	assert.GreaterOrEqual(t, idx, len(tx.Message.Accounts)-int(header.NumReadonlyUnsignedAccounts))
+
+	// the reference is attached to the transfer, not to the memo
+	instrs := tx.Message.DecompileInstructions()
+	require.Len(t, instrs, 2)
+	assert.Equal(t, common.SystemProgramID, instrs[0].ProgramID)
+	require.Len(t, instrs[0].Accounts, 3)
+	assert.Equal(t, types.AccountMeta{PubKey: reference}, instrs[0].Accounts[2])
+	assert.Equal(t, common.MemoProgramID, instrs[1].ProgramID)
+	assert.Len(t, instrs[1].Accounts, 1)
+
+	// nothing to attach the reference to
+	_, err = transaction.NewTransactionBuilder(newBlockhashClient(t)).
+		SetFeePayer(payer.PublicKey).
+		AddInstruction(instructions.Memo("order #1")).
+		AddReference(reference).
+		Build(context.Background())
+	require.Error(t, err)
+}
+
+func TestTransactionBuilder_UseDurableNonce(t *testing.T) {
+	payer := types.NewAccount()
+	nonceAuth := types.NewAccount()
+	nonceAccount := types.NewAccount().PublicKey
+	recipient := types.NewAccount().PublicKey
+	nonce := types.NewAccount().PublicKey
+
+	// the nonce is requested as a slice of the nonce account data, so only the nonce is served
+	c := newRPCClient(t, map[string]func() interface{}{
+		"getAccountInfo": func() interface{} {
+			return map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": map[string]interface{}{
+					"lamports":   1_447_680,
+					"owner":      common.SystemProgramID.ToBase58(),
+					"executable": false,
+					"rentEpoch":  0,
+					"data":       []string{base64.StdEncoding.EncodeToString(nonce.Bytes()), "base64"},
+				},
+			}
+		},
+	})
+
+	build := func(tb *transaction.TransactionBuilder) types.Transaction {
+		txStr, err := tb.
+			AddSigner(nonceAuth).
+			AddInstruction(instructions.TransferSOL(instructions.TransferSOLParams{
+				Sender:    nonceAuth.PublicKey,
+				Recipient: recipient,
+				Amount:    1000,
+			})).
+			UseDurableNonce(nonceAccount, nonceAuth.PublicKey).
+			Build(context.Background())
+		require.NoError(t, err)
+
+		tx, err := utils.DecodeTransaction(txStr)
+		require.NoError(t, err)
+		return tx
+	}
+
+	t.Run("nonce authority pays the fee", func(t *testing.T) {
+		tx := build(transaction.NewTransactionBuilder(c))
+
+		assert.Equal(t, nonce.ToBase58(), tx.Message.RecentBlockHash)
+		assert.Equal(t, nonceAuth.PublicKey, tx.Message.Accounts[0])
+
+		instrs := tx.Message.DecompileInstructions()
+		require.Len(t, instrs, 2)
+		advance := system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{
+			Nonce: nonceAccount,
+			Auth:  nonceAuth.PublicKey,
+		})
+		assert.Equal(t, advance.ProgramID, instrs[0].ProgramID)
+		assert.Equal(t, advance.Data, instrs[0].Data)
+		require.Len(t, instrs[0].Accounts, len(advance.Accounts))
+		for i, acc := range advance.Accounts {
+			assert.Equal(t, acc.PubKey, instrs[0].Accounts[i].PubKey)
+		}
+		assert.Equal(t, common.SystemProgramID, instrs[1].ProgramID)
+	})
+
+	t.Run("custom fee payer", func(t *testing.T) {
+		tx := build(transaction.NewTransactionBuilder(c).SetFeePayer(payer.PublicKey).AddSigner(payer))
+
+		assert.Equal(t, nonce.ToBase58(), tx.Message.RecentBlockHash)
+		assert.Equal(t, payer.PublicKey, tx.Message.Accounts[0])
+		assert.Len(t, tx.Signatures, 2)
+	})
+}
+
+func TestTransactionBuilder_GeneratedSigners(t *testing.T) {
+	payer := types.NewAccount()
+
+	tb := transaction.NewTransactionBuilder(newBlockhashClient(t)).
+		SetFeePayer(payer.PublicKey).
+		AddSigner(payer)
+	assert.Empty(t, tb.GeneratedSigners())
+
+	nonce := tb.NewSigner()
+	txStr, err := tb.
+		AddInstruction(instructions.CreateNonceAccount(instructions.CreateNonceAccountParams{
+			FeePayer:               payer.PublicKey,
+			Nonce:                  nonce.PublicKey,
+			NonceAccountMinBalance: 1_447_680,
+		})).
+		Build(context.Background())
+	require.NoError(t, err)
+
+	generated := tb.GeneratedSigners()
+	require.Len(t, generated, 1)
+	assert.Equal(t, nonce, generated[0])
+
+	// the generated keypair signs the transaction
+	tx, err := utils.DecodeTransaction(txStr)
+	require.NoError(t, err)
+	require.Len(t, tx.Signatures, 2)
+	msg, err := tx.Message.Serialize()
+	require.NoError(t, err)
+	idx := indexOf(tx.Message.Accounts, nonce.PublicKey)
+	require.NotEqual(t, -1, idx)
+	assert.Equal(t, types.Signature(nonce.Sign(msg)), tx.Signatures[idx])
+}
+
+func TestTransactionBuilder_Build_CancelledContext(t *testing.T) {
+	payer := types.NewAccount()
+
+	var resolved bool
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tb := transaction.NewTransactionBuilder(newRPCClient(t, nil)).
+		SetFeePayer(payer.PublicKey).
+		AddSigner(payer).
+		AddInstruction(func(ctx context.Context, c instructions.Client) ([]types.Instruction, error) {
+			resolved = true
+			return nil, nil
+		})
+
+	_, err := tb.Build(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.False(t, tb.WillExceedSizeLimit(ctx))
+	assert.False(t, resolved, "instructions must not be resolved after the context is cancelled")
+}
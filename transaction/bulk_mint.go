@@ -0,0 +1,162 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+type (
+	// BulkMintBuilder builds the transactions for minting a batch of NFTs,
+	// which share the fee payer and the collection.
+	// Each transaction is packed with as many mints as fit into it.
+	BulkMintBuilder struct {
+		client               solanaClient                      // solana client wrapper
+		feePayer             *common.PublicKey                 // transactions fee payer
+		collection           *common.PublicKey                 // collection mint
+		collectionAuthority  *common.PublicKey                 // collection authority
+		sellerFeeBasisPoints uint16                            // seller fee basis points of each NFT
+		items                []BulkMintItem                    // NFTs to mint
+		lookupTables         []types.AddressLookupTableAccount // address lookup tables of the v0 messages
+	}
+
+	// BulkMintItem defines a single NFT of the bulk mint.
+	BulkMintItem struct {
+		Owner       common.PublicKey // required; The wallet to mint the NFT to; must sign the transaction
+		MetadataURI string           // optional; URI of the NFT metadata
+		TokenName   string           // optional; Name of the NFT; required if MetadataURI is not set
+		TokenSymbol string           // optional; Symbol of the NFT; required if MetadataURI is not set
+	}
+
+	// BulkMintTransaction is a transaction of the bulk mint.
+	BulkMintTransaction struct {
+		Transaction string          // base64 encoded transaction signed by the mint accounts
+		Mints       []types.Account // generated mint accounts of the NFTs minted by the transaction, in the items order
+	}
+)
+
+// NewBulkMintBuilder creates a new bulk mint builder.
+func NewBulkMintBuilder(c solanaClient) *BulkMintBuilder {
+	return &BulkMintBuilder{client: c}
+}
+
+// SetFeePayer sets the fee payer of all the transactions.
+func (b *BulkMintBuilder) SetFeePayer(feePayer common.PublicKey) *BulkMintBuilder {
+	b.feePayer = &feePayer
+	return b
+}
+
+// SetCollection sets the sized collection of the minted NFTs.
+// The items are verified by the collection authority, which must sign each transaction.
+func (b *BulkMintBuilder) SetCollection(collection, collectionAuthority common.PublicKey) *BulkMintBuilder {
+	b.collection = &collection
+	b.collectionAuthority = &collectionAuthority
+	return b
+}
+
+// SetSellerFeeBasisPoints sets the seller fee basis points of each minted NFT.
+func (b *BulkMintBuilder) SetSellerFeeBasisPoints(bps uint16) *BulkMintBuilder {
+	b.sellerFeeBasisPoints = bps
+	return b
+}
+
+// UseVersionedMessage builds the transactions with v0 messages, see TransactionBuilder.UseVersionedMessage.
+func (b *BulkMintBuilder) UseVersionedMessage(tables ...types.AddressLookupTableAccount) *BulkMintBuilder {
+	b.lookupTables = tables
+	return b
+}
+
+// AddItem adds NFTs to mint.
+func (b *BulkMintBuilder) AddItem(items ...BulkMintItem) *BulkMintBuilder {
+	b.items = append(b.items, items...)
+	return b
+}
+
+// Build generates a mint account for each item and packs the mint instructions into transactions,
+// keeping the items order. An item is never split between transactions.
+// The transactions are signed by the generated mint accounts only;
+// the fee payer, the item owners and the collection authority must sign them before sending.
+// Returns an error if a single item does not fit into a transaction.
+func (b *BulkMintBuilder) Build(ctx context.Context) ([]BulkMintTransaction, error) {
+	if b.feePayer == nil || *b.feePayer == (common.PublicKey{}) {
+		return nil, fmt.Errorf("failed to build bulk mint: missing or invalid fee payer public key")
+	}
+
+	var (
+		result  []BulkMintTransaction
+		current []types.Instruction
+		mints   []types.Account
+	)
+	flush := func() error {
+		if len(mints) == 0 {
+			return nil
+		}
+		tb := NewTransactionBuilder(b.client).
+			SetFeePayer(*b.feePayer).
+			UseVersionedMessage(b.lookupTables...).
+			AddInstruction(staticInstructions(current))
+		for _, mint := range mints {
+			tb.AddSigner(mint)
+		}
+		tx, err := tb.Build(ctx)
+		if err != nil {
+			return err
+		}
+		result = append(result, BulkMintTransaction{Transaction: tx, Mints: mints})
+		current, mints = nil, nil
+		return nil
+	}
+
+	for i, item := range b.items {
+		mint := types.NewAccount()
+		instr, err := instructions.MintNonFungible(instructions.MintNonFungibleParam{
+			Mint:                 mint.PublicKey,
+			Owner:                item.Owner,
+			FeePayer:             b.feePayer,
+			Collection:           b.collection,
+			CollectionAuthority:  b.collectionAuthority,
+			MetadataURI:          item.MetadataURI,
+			TokenName:            item.TokenName,
+			TokenSymbol:          item.TokenSymbol,
+			SellerFeeBasisPoints: b.sellerFeeBasisPoints,
+		})(ctx, b.client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build bulk mint: item #%d: %w", i, err)
+		}
+
+		size, err := EstimateSize(append(current, instr...), *b.feePayer, b.lookupTables...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build bulk mint: %w", err)
+		}
+		if size > MaxTransactionSize && len(mints) > 0 {
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("failed to build bulk mint: %w", err)
+			}
+			if size, err = EstimateSize(instr, *b.feePayer, b.lookupTables...); err != nil {
+				return nil, fmt.Errorf("failed to build bulk mint: %w", err)
+			}
+		}
+		if size > MaxTransactionSize {
+			return nil, fmt.Errorf("failed to build bulk mint: item #%d exceeds the transaction size limit: %d > %d", i, size, MaxTransactionSize)
+		}
+
+		current = append(current, instr...)
+		mints = append(mints, mint)
+	}
+
+	if err := flush(); err != nil {
+		return nil, fmt.Errorf("failed to build bulk mint: %w", err)
+	}
+
+	return result, nil
+}
+
+// staticInstructions returns the instruction function which returns the already prepared instructions.
+func staticInstructions(instr []types.Instruction) instructions.InstructionFunc {
+	return func(ctx context.Context, c instructions.Client) ([]types.Instruction, error) {
+		return instr, nil
+	}
+}
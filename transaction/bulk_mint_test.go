@@ -0,0 +1,110 @@
+package transaction_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/transaction"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkMintBuilder(t *testing.T) {
+	feePayer := types.NewAccount().PublicKey
+	owner := types.NewAccount().PublicKey
+
+	const count = 5
+	items := make([]transaction.BulkMintItem, count)
+	for i := range items {
+		items[i] = transaction.BulkMintItem{
+			Owner:       owner,
+			TokenName:   fmt.Sprintf("NFT #%d", i),
+			TokenSymbol: "NFT",
+		}
+	}
+
+	txs, err := transaction.NewBulkMintBuilder(newBlockhashClient(t)).
+		SetFeePayer(feePayer).
+		AddItem(items...).
+		Build(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, txs)
+
+	total := 0
+	seen := map[common.PublicKey]bool{}
+	for _, btx := range txs {
+		require.NotEmpty(t, btx.Mints)
+		total += len(btx.Mints)
+
+		assert.LessOrEqual(t, len(btx.Transaction)*3/4, transaction.MaxTransactionSize)
+		tx, err := utils.DecodeTransaction(btx.Transaction)
+		require.NoError(t, err)
+		raw, err := tx.Serialize()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(raw), transaction.MaxTransactionSize)
+
+		// each mint account is a required signer and has signed the transaction
+		signers := tx.Message.Accounts[:tx.Message.Header.NumRequireSignatures]
+		assert.Equal(t, feePayer, signers[0])
+		for _, mint := range btx.Mints {
+			assert.False(t, seen[mint.PublicKey], "mint is used twice")
+			seen[mint.PublicKey] = true
+
+			idx := indexOf(signers, mint.PublicKey)
+			require.GreaterOrEqual(t, idx, 0, "mint is not a signer")
+			assert.NotEqual(t, make([]byte, 64), []byte(tx.Signatures[idx]))
+		}
+	}
+	assert.Equal(t, count, total)
+
+	// two NFT mints don't fit into a legacy transaction, so each one is packed separately
+	var pair []types.Instruction
+	for i := 0; i < 2; i++ {
+		instr, err := instructions.MintNonFungible(instructions.MintNonFungibleParam{
+			Mint:        types.NewAccount().PublicKey,
+			Owner:       owner,
+			FeePayer:    &feePayer,
+			TokenName:   items[i].TokenName,
+			TokenSymbol: items[i].TokenSymbol,
+		})(context.Background(), newBlockhashClient(t))
+		require.NoError(t, err)
+		pair = append(pair, instr...)
+	}
+	size, err := transaction.EstimateSize(pair, feePayer)
+	require.NoError(t, err)
+	require.Greater(t, size, transaction.MaxTransactionSize)
+	assert.Len(t, txs, count)
+}
+
+func TestBulkMintBuilder_Errors(t *testing.T) {
+	_, err := transaction.NewBulkMintBuilder(newBlockhashClient(t)).
+		AddItem(transaction.BulkMintItem{Owner: types.NewAccount().PublicKey, TokenName: "NFT", TokenSymbol: "NFT"}).
+		Build(context.Background())
+	require.ErrorContains(t, err, "fee payer")
+
+	_, err = transaction.NewBulkMintBuilder(newBlockhashClient(t)).
+		SetFeePayer(types.NewAccount().PublicKey).
+		AddItem(transaction.BulkMintItem{Owner: types.NewAccount().PublicKey}).
+		Build(context.Background())
+	require.ErrorContains(t, err, "item #0")
+
+	txs, err := transaction.NewBulkMintBuilder(newBlockhashClient(t)).
+		SetFeePayer(types.NewAccount().PublicKey).
+		Build(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+}
+
+func indexOf(keys []common.PublicKey, key common.PublicKey) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
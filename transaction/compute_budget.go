@@ -0,0 +1,132 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/compute_budget"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+const (
+	// MaxComputeUnitLimit is the maximum number of compute units a transaction can consume.
+	MaxComputeUnitLimit uint32 = 1_400_000
+	// DefaultPriorityFeePercentile is the percentile of the recent prioritization fees
+	// used as the compute unit price by AutoComputeBudget.
+	DefaultPriorityFeePercentile uint8 = 75
+	// computeUnitLimitMargin is the share of the simulated compute units added to the limit,
+	// since the consumption may differ between the simulation and the execution.
+	computeUnitLimitMargin = 10 // percent
+)
+
+// computeBudgetEstimator estimates the compute budget of transactions,
+// see client.Client.SimulateTransaction and client.Client.GetRecentPrioritizationFees.
+type computeBudgetEstimator interface {
+	transactionSimulator
+	GetRecentPrioritizationFees(ctx context.Context, accounts ...common.PublicKey) ([]client.PrioritizationFee, error)
+}
+
+// AutoComputeBudget makes Build prepend the SetComputeUnitLimit and SetComputeUnitPrice instructions.
+// The limit is the compute units consumed by the simulated transaction plus a 10% margin.
+// The price is the percentile of the recent prioritization fees paid for the writable accounts
+// of the transaction, DefaultPriorityFeePercentile unless set by SetPriorityFeePercentile.
+// The estimation takes extra requests to the RPC node, so it's disabled by default.
+func (tb *TransactionBuilder) AutoComputeBudget(c computeBudgetEstimator) *TransactionBuilder {
+	tb.budgetEstimator = c
+	return tb
+}
+
+// SetPriorityFeePercentile sets the percentile (0-100) of the recent prioritization fees
+// used as the compute unit price by AutoComputeBudget.
+func (tb *TransactionBuilder) SetPriorityFeePercentile(percentile uint8) *TransactionBuilder {
+	tb.feePercentile = &percentile
+	return tb
+}
+
+// prependComputeBudget estimates the compute budget of the transaction with the given instructions
+// and prepends the compute budget instructions to them.
+func (tb *TransactionBuilder) prependComputeBudget(ctx context.Context, instructions []types.Instruction) ([]types.Instruction, error) {
+	percentile := DefaultPriorityFeePercentile
+	if tb.feePercentile != nil {
+		percentile = *tb.feePercentile
+	}
+	if percentile > 100 {
+		return nil, fmt.Errorf("invalid priority fee percentile: %d", percentile)
+	}
+
+	// simulate with the maximum limit, so the consumption isn't capped by the default one
+	tx, err := tb.newTransaction(ctx, append([]types.Instruction{
+		compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: MaxComputeUnitLimit}),
+		compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: 0}),
+	}, instructions...))
+	if err != nil {
+		return nil, err
+	}
+	result, err := tb.budgetEstimator.SimulateTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate compute units: %w", err)
+	}
+	if err := result.ToError(); err != nil {
+		return nil, fmt.Errorf("failed to estimate compute units: %w", err)
+	}
+
+	units := result.UnitsConsumed + result.UnitsConsumed*computeUnitLimitMargin/100
+	if units > uint64(MaxComputeUnitLimit) {
+		units = uint64(MaxComputeUnitLimit)
+	}
+
+	fees, err := tb.budgetEstimator.GetRecentPrioritizationFees(ctx, writableAccounts(instructions)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate compute unit price: %w", err)
+	}
+
+	return append([]types.Instruction{
+		compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: uint32(units)}),
+		compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{
+			MicroLamports: feePercentile(fees, percentile),
+		}),
+	}, instructions...), nil
+}
+
+// writableAccounts returns the unique writable accounts of the instructions,
+// up to client.MaxPrioritizationFeesAccounts.
+func writableAccounts(instructions []types.Instruction) []common.PublicKey {
+	seen := make(map[common.PublicKey]bool)
+	accounts := make([]common.PublicKey, 0)
+	for _, instruction := range instructions {
+		for _, meta := range instruction.Accounts {
+			if !meta.IsWritable || seen[meta.PubKey] {
+				continue
+			}
+			if len(accounts) == client.MaxPrioritizationFeesAccounts {
+				return accounts
+			}
+			seen[meta.PubKey] = true
+			accounts = append(accounts, meta.PubKey)
+		}
+	}
+	return accounts
+}
+
+// feePercentile returns the nearest-rank percentile of the prioritization fees.
+// Returns 0 if there are no fees.
+func feePercentile(fees []client.PrioritizationFee, percentile uint8) uint64 {
+	if len(fees) == 0 {
+		return 0
+	}
+
+	values := make([]uint64, 0, len(fees))
+	for _, fee := range fees {
+		values = append(values, fee.PrioritizationFee)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	rank := (int(percentile)*len(values) + 99) / 100
+	if rank > 0 {
+		rank--
+	}
+	return values[rank]
+}
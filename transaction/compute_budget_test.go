@@ -0,0 +1,136 @@
+package transaction_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dmitrymomot/solana/client"
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/transaction"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/compute_budget"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// budgetEstimator is a compute budget estimator with the fixed estimates.
+type budgetEstimator struct {
+	unitsConsumed uint64
+	simErr        *client.TransactionError
+	fees          []client.PrioritizationFee
+
+	simulated []types.Instruction
+	accounts  []common.PublicKey
+}
+
+func (e *budgetEstimator) SimulateTransaction(_ context.Context, txSource string) (*client.SimulationResult, error) {
+	tx, err := utils.DecodeTransaction(txSource)
+	if err != nil {
+		return nil, err
+	}
+	e.simulated = tx.Message.DecompileInstructions()
+	return &client.SimulationResult{Err: e.simErr, UnitsConsumed: e.unitsConsumed}, nil
+}
+
+func (e *budgetEstimator) GetRecentPrioritizationFees(_ context.Context, accounts ...common.PublicKey) ([]client.PrioritizationFee, error) {
+	e.accounts = accounts
+	return e.fees, nil
+}
+
+func TestTransactionBuilder_AutoComputeBudget(t *testing.T) {
+	payer := types.NewAccount()
+	recipient := types.NewAccount().PublicKey
+	fees := []client.PrioritizationFee{
+		{Slot: 1, PrioritizationFee: 400},
+		{Slot: 2, PrioritizationFee: 100},
+		{Slot: 3, PrioritizationFee: 0},
+		{Slot: 4, PrioritizationFee: 300},
+		{Slot: 5, PrioritizationFee: 200},
+	}
+
+	build := func(t *testing.T, estimator *budgetEstimator, percentile *uint8) ([]types.Instruction, error) {
+		tb := transaction.NewTransactionBuilder(newBlockhashClient(t)).
+			SetFeePayer(payer.PublicKey).
+			AddSigner(payer).
+			AddInstruction(instructions.TransferSOL(instructions.TransferSOLParams{
+				Sender:    payer.PublicKey,
+				Recipient: recipient,
+				Amount:    1000,
+			})).
+			AutoComputeBudget(estimator)
+		if percentile != nil {
+			tb.SetPriorityFeePercentile(*percentile)
+		}
+
+		txStr, err := tb.Build(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		tx, err := utils.DecodeTransaction(txStr)
+		require.NoError(t, err)
+		return tx.Message.DecompileInstructions(), nil
+	}
+	limit := func(units uint32) types.Instruction {
+		return compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: units})
+	}
+	price := func(microLamports uint64) types.Instruction {
+		return compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: microLamports})
+	}
+
+	t.Run("default percentile", func(t *testing.T) {
+		estimator := &budgetEstimator{unitsConsumed: 1000, fees: fees}
+
+		instrs, err := build(t, estimator, nil)
+		require.NoError(t, err)
+		require.Len(t, instrs, 3)
+		// consumed units plus 10%
+		assert.Equal(t, limit(1100).Data, instrs[0].Data)
+		// 75th percentile of 0, 100, 200, 300, 400
+		assert.Equal(t, price(300).Data, instrs[1].Data)
+		assert.Equal(t, common.ComputeBudgetProgramID, instrs[0].ProgramID)
+		assert.Equal(t, common.ComputeBudgetProgramID, instrs[1].ProgramID)
+		assert.Equal(t, common.SystemProgramID, instrs[2].ProgramID)
+
+		// the simulation runs with the maximum limit
+		require.Len(t, estimator.simulated, 3)
+		assert.Equal(t, limit(transaction.MaxComputeUnitLimit).Data, estimator.simulated[0].Data)
+		// fees are requested for the writable accounts
+		assert.ElementsMatch(t, []common.PublicKey{payer.PublicKey, recipient}, estimator.accounts)
+	})
+
+	t.Run("custom percentile", func(t *testing.T) {
+		for percentile, expected := range map[uint8]uint64{0: 0, 50: 200, 100: 400} {
+			percentile := percentile
+			instrs, err := build(t, &budgetEstimator{unitsConsumed: 1000, fees: fees}, &percentile)
+			require.NoError(t, err)
+			assert.Equal(t, price(expected).Data, instrs[1].Data, "percentile %d", percentile)
+		}
+	})
+
+	t.Run("no recent fees", func(t *testing.T) {
+		instrs, err := build(t, &budgetEstimator{unitsConsumed: 1000}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, price(0).Data, instrs[1].Data)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		instrs, err := build(t, &budgetEstimator{unitsConsumed: uint64(transaction.MaxComputeUnitLimit)}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, limit(transaction.MaxComputeUnitLimit).Data, instrs[0].Data)
+	})
+
+	t.Run("failed simulation", func(t *testing.T) {
+		_, err := build(t, &budgetEstimator{simErr: &client.TransactionError{Type: "AccountNotFound"}}, nil)
+		var simErr *client.SimulationError
+		require.ErrorAs(t, err, &simErr)
+		assert.Equal(t, "AccountNotFound", simErr.Err.Type)
+	})
+
+	t.Run("invalid percentile", func(t *testing.T) {
+		percentile := uint8(101)
+		_, err := build(t, &budgetEstimator{}, &percentile)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,81 @@
+package transaction
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// MaxTransactionSize is the maximum size of a serialized transaction in bytes (IPv6 MTU minus headers).
+const MaxTransactionSize = 1232
+
+// EstimateSize returns the size of the serialized and signed transaction in bytes,
+// which contains the given instructions and is paid by the given fee payer.
+// The recent blockhash does not affect the size, so it's not required.
+// The size of a v0 message is estimated if address lookup tables are given.
+func EstimateSize(instructions []types.Instruction, feePayer common.PublicKey, lookupTables ...types.AddressLookupTableAccount) (int, error) {
+	if feePayer == (common.PublicKey{}) {
+		return 0, fmt.Errorf("failed to estimate transaction size: missing or invalid fee payer public key")
+	}
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:                   feePayer,
+		Instructions:               instructions,
+		RecentBlockhash:            common.PublicKey{}.ToBase58(),
+		AddressLookupTableAccounts: lookupTables,
+	})
+	messageData, err := message.Serialize()
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate transaction size: %w", err)
+	}
+
+	signatures := int(message.Header.NumRequireSignatures)
+
+	return shortVecLength(signatures) + signatures*ed25519.SignatureSize + len(messageData), nil
+}
+
+// SplitInstructions partitions the instructions into groups in the original order,
+// so that each group fits into a single transaction paid by the given fee payer.
+// The max is the maximum transaction size in bytes; MaxTransactionSize is used if it's not positive.
+// Returns an error if any instruction doesn't fit into a transaction on its own.
+func SplitInstructions(instructions []types.Instruction, feePayer common.PublicKey, max int) ([][]types.Instruction, error) {
+	if max <= 0 {
+		max = MaxTransactionSize
+	}
+
+	var groups [][]types.Instruction
+	var current []types.Instruction
+	for i, instruction := range instructions {
+		size, err := EstimateSize(append(current, instruction), feePayer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to split instructions: %w", err)
+		}
+		if size > max && len(current) > 0 {
+			groups = append(groups, current)
+			current = nil
+			if size, err = EstimateSize([]types.Instruction{instruction}, feePayer); err != nil {
+				return nil, fmt.Errorf("failed to split instructions: %w", err)
+			}
+		}
+		if size > max {
+			return nil, fmt.Errorf("failed to split instructions: instruction #%d exceeds the transaction size limit: %d > %d", i, size, max)
+		}
+		current = append(current, instruction)
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+
+	return groups, nil
+}
+
+// shortVecLength returns the length of the compact-u16 encoded number.
+func shortVecLength(n int) int {
+	length := 1
+	for n >>= 7; n > 0; n >>= 7 {
+		length++
+	}
+	return length
+}
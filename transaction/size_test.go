@@ -0,0 +1,161 @@
+package transaction_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dmitrymomot/solana/instructions"
+	"github.com/dmitrymomot/solana/transaction"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/memo"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func memoInstruction(size int) types.Instruction {
+	return memo.BuildMemo(memo.BuildMemoParam{Memo: []byte(strings.Repeat("a", size))})
+}
+
+// rawMemo returns the memo instruction func bypassing the memo length validation,
+// so a single instruction can fill the transaction.
+func rawMemo(size int) instructions.InstructionFunc {
+	return func(ctx context.Context, c instructions.Client) ([]types.Instruction, error) {
+		return []types.Instruction{memoInstruction(size)}, nil
+	}
+}
+
+func TestEstimateSize(t *testing.T) {
+	payer := types.NewAccount()
+	signer := types.NewAccount()
+
+	instr := []types.Instruction{
+		memoInstruction(10),
+		memo.BuildMemo(memo.BuildMemoParam{SignerPubkeys: []common.PublicKey{signer.PublicKey}, Memo: []byte("signed")}),
+	}
+
+	size, err := transaction.EstimateSize(instr, payer.PublicKey)
+	require.NoError(t, err)
+
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        payer.PublicKey,
+			RecentBlockhash: types.NewAccount().PublicKey.ToBase58(),
+			Instructions:    instr,
+		}),
+		Signers: []types.Account{payer, signer},
+	})
+	require.NoError(t, err)
+	rawTx, err := tx.Serialize()
+	require.NoError(t, err)
+	assert.Equal(t, len(rawTx), size)
+
+	_, err = transaction.EstimateSize(instr, common.PublicKey{})
+	require.Error(t, err)
+}
+
+func TestEstimateSize_Boundary(t *testing.T) {
+	payer := types.NewAccount().PublicKey
+
+	// find the memo size which fills the transaction exactly;
+	// the data length prefix is 2 bytes long for the data longer than 127 bytes
+	size, err := transaction.EstimateSize([]types.Instruction{memoInstruction(200)}, payer)
+	require.NoError(t, err)
+	memoSize := 200 + transaction.MaxTransactionSize - size
+
+	size, err = transaction.EstimateSize([]types.Instruction{memoInstruction(memoSize)}, payer)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.MaxTransactionSize, size)
+
+	size, err = transaction.EstimateSize([]types.Instruction{memoInstruction(memoSize + 1)}, payer)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.MaxTransactionSize+1, size)
+}
+
+func TestTransactionBuilder_WillExceedSizeLimit(t *testing.T) {
+	payer := types.NewAccount().PublicKey
+
+	// each memo instruction without signers adds 1 (program id index) + 1 (accounts) + 1 (data length) + 100 bytes
+	const memoSize = 100
+	base, err := transaction.EstimateSize([]types.Instruction{memoInstruction(memoSize)}, payer)
+	require.NoError(t, err)
+	fits := 1 + (transaction.MaxTransactionSize-base)/(memoSize+3)
+
+	tb := transaction.NewTransactionBuilder(nil).SetFeePayer(payer)
+	for i := 0; i < fits; i++ {
+		tb.AddInstruction(instructions.Memo(strings.Repeat("a", memoSize)))
+	}
+	assert.False(t, tb.WillExceedSizeLimit(context.Background()))
+
+	tb.AddInstruction(instructions.Memo(strings.Repeat("a", memoSize)))
+	assert.True(t, tb.WillExceedSizeLimit(context.Background()))
+
+	// missing fee payer
+	assert.False(t, transaction.NewTransactionBuilder(nil).
+		AddInstruction(rawMemo(2000)).
+		WillExceedSizeLimit(context.Background()))
+}
+
+func TestTransactionBuilder_WillExceedSizeLimit_Durable(t *testing.T) {
+	nonce := types.NewAccount().PublicKey
+	nonceAuth := types.NewAccount().PublicKey
+
+	// the advance nonce instruction takes space too
+	size, err := transaction.EstimateSize([]types.Instruction{memoInstruction(200)}, nonceAuth)
+	require.NoError(t, err)
+	memoSize := 200 + transaction.MaxTransactionSize - size
+
+	tb := transaction.NewTransactionBuilder(nil).
+		SetDurableNonce(nonce, nonceAuth).
+		AddInstruction(rawMemo(memoSize))
+	assert.False(t, transaction.NewTransactionBuilder(nil).
+		SetFeePayer(nonceAuth).
+		AddInstruction(rawMemo(memoSize)).
+		WillExceedSizeLimit(context.Background()))
+	assert.True(t, tb.WillExceedSizeLimit(context.Background()))
+}
+
+func TestSplitInstructions(t *testing.T) {
+	payer := types.NewAccount().PublicKey
+
+	const memoSize = 100
+	base, err := transaction.EstimateSize([]types.Instruction{memoInstruction(memoSize)}, payer)
+	require.NoError(t, err)
+	fits := 1 + (transaction.MaxTransactionSize-base)/(memoSize+3)
+
+	instr := make([]types.Instruction, 0, fits*2+1)
+	for i := 0; i < fits*2+1; i++ {
+		instr = append(instr, memoInstruction(memoSize))
+	}
+
+	groups, err := transaction.SplitInstructions(instr, payer, 0)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+	assert.Len(t, groups[0], fits)
+	assert.Len(t, groups[1], fits)
+	assert.Len(t, groups[2], 1)
+	for _, group := range groups {
+		size, err := transaction.EstimateSize(group, payer)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, size, transaction.MaxTransactionSize)
+	}
+
+	// exactly fits into a single transaction
+	groups, err = transaction.SplitInstructions(instr[:fits], payer, transaction.MaxTransactionSize)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+
+	// custom limit
+	groups, err = transaction.SplitInstructions(instr[:4], payer, base)
+	require.NoError(t, err)
+	require.Len(t, groups, 4)
+
+	// instruction is too large on its own
+	_, err = transaction.SplitInstructions([]types.Instruction{memoInstruction(10), memoInstruction(2000)}, payer, 0)
+	require.Error(t, err)
+
+	groups, err = transaction.SplitInstructions(nil, payer, 0)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
@@ -0,0 +1,11 @@
+package types
+
+import "errors"
+
+// Predefined package errors
+var (
+	ErrTokenAmountOverflow         = errors.New("token amount overflow")
+	ErrTokenAmountUnderflow        = errors.New("token amount underflow")
+	ErrTokenAmountDecimalsMismatch = errors.New("token amounts have different decimals")
+	ErrInvalidTokenAmount          = errors.New("invalid token amount")
+)
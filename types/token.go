@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/dmitrymomot/solana/utils"
 	"github.com/portto/solana-go-sdk/common"
@@ -17,6 +18,9 @@ const (
 	// SPL token default decimals
 	SPLTokenDefaultDecimals uint8 = 9
 
+	// SPL token max decimals supported by the wallets and the token metadata
+	SPLTokenMaxDecimals uint8 = 9
+
 	// SPL token default multiplier for decimals
 	SPLTokenDefaultMultiplier uint64 = 1e9
 
@@ -37,12 +41,11 @@ type TokenAmount struct {
 
 // TokenAmountFromLamports converts the given lamports to a token amount.
 func NewTokenAmountFromLamports(lamports uint64, decimals uint8) TokenAmount {
-	uiAmount := utils.AmountToFloat64(lamports, decimals)
 	return TokenAmount{
 		Amount:         lamports,
 		Decimals:       decimals,
-		UIAmount:       uiAmount,
-		UIAmountString: utils.Float64ToString(uiAmount),
+		UIAmount:       utils.AmountToFloat64(lamports, decimals),
+		UIAmountString: utils.LamportsToDecimalString(lamports, decimals),
 	}
 }
 
@@ -161,6 +164,23 @@ func (a TokenAccount) IsFungibleAsset() bool {
 	return a.Balance.Decimals == 0 && a.Balance.Amount > 1
 }
 
+// IsFrozen returns true if the token account is frozen by the mint freeze authority,
+// so its tokens can't be transferred or burned.
+func (a TokenAccount) IsFrozen() bool {
+	return a.State == TokenAccountFrozen
+}
+
+// HasDelegate returns true if the token account has a delegate.
+func (a TokenAccount) HasDelegate() bool {
+	return a.Delegate != nil
+}
+
+// DelegatedEnough returns true if the token account has a delegate
+// which is allowed to transfer at least the given amount of tokens (in token lamports).
+func (a TokenAccount) DelegatedEnough(amount uint64) bool {
+	return a.HasDelegate() && a.DelegatedBalance != nil && a.DelegatedBalance.Amount >= amount
+}
+
 // String returns the string representation of the token account state.
 func (s TokenAccountState) String() string {
 	return string(s)
@@ -207,8 +227,9 @@ func NewTokenAccount(data []byte) (TokenAccount, error) {
 		*rpcResponse.Account.Data.Parsed.Info.Delegate != "" {
 		delegate = utils.Pointer(common.PublicKeyFromString(*rpcResponse.Account.Data.Parsed.Info.Delegate))
 	}
+	// the ui amount may be omitted, e.g. by the token-2022 program, so the raw amount is checked
 	if rpcResponse.Account.Data.Parsed.Info.DelegatedAmount != nil &&
-		rpcResponse.Account.Data.Parsed.Info.DelegatedAmount.UIAmount > 0 {
+		rpcResponse.Account.Data.Parsed.Info.DelegatedAmount.Amount != "" {
 		dAmount, err := strconv.ParseUint(rpcResponse.Account.Data.Parsed.Info.DelegatedAmount.Amount, 10, 64)
 		if err != nil {
 			return TokenAccount{}, fmt.Errorf("could not parse delegated balance amount: %w", err)
@@ -225,10 +246,17 @@ func NewTokenAccount(data []byte) (TokenAccount, error) {
 		Pubkey:           common.PublicKeyFromString(rpcResponse.Pubkey),
 		Mint:             common.PublicKeyFromString(rpcResponse.Account.Data.Parsed.Info.Mint),
 		Owner:            common.PublicKeyFromString(rpcResponse.Account.Data.Parsed.Info.Owner),
-		State:            TokenAccountState(rpcResponse.Account.Data.Parsed.Info.State),
+		State:            parseTokenAccountState(rpcResponse.Account.Data.Parsed.Info.State),
 		IsNative:         rpcResponse.Account.Data.Parsed.Info.IsNative,
 		Balance:          balance,
 		Delegate:         delegate,
 		DelegatedBalance: delegateBalance,
 	}, nil
 }
+
+// parseTokenAccountState converts the state of the parsed token account to TokenAccountState.
+// Both token programs report the state in lower case, e.g. "frozen",
+// but the state is normalized in case a node reports the enum variant name, e.g. "Frozen".
+func parseTokenAccountState(state string) TokenAccountState {
+	return TokenAccountState(strings.ToLower(state))
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// Add returns the sum of the two token amounts.
+// Returns an error if the amounts have different decimals or the result overflows uint64.
+func (a TokenAmount) Add(b TokenAmount) (TokenAmount, error) {
+	if a.Decimals != b.Decimals {
+		return TokenAmount{}, ErrTokenAmountDecimalsMismatch
+	}
+
+	sum, carry := bits.Add64(a.Amount, b.Amount, 0)
+	if carry != 0 {
+		return TokenAmount{}, ErrTokenAmountOverflow
+	}
+
+	return NewTokenAmountFromLamports(sum, a.Decimals), nil
+}
+
+// Sub returns the difference of the two token amounts.
+// Returns an error if the amounts have different decimals or b is greater than a.
+func (a TokenAmount) Sub(b TokenAmount) (TokenAmount, error) {
+	if a.Decimals != b.Decimals {
+		return TokenAmount{}, ErrTokenAmountDecimalsMismatch
+	}
+
+	diff, borrow := bits.Sub64(a.Amount, b.Amount, 0)
+	if borrow != 0 {
+		return TokenAmount{}, ErrTokenAmountUnderflow
+	}
+
+	return NewTokenAmountFromLamports(diff, a.Decimals), nil
+}
+
+// Mul returns the token amount multiplied by the given factor.
+// Returns an error if the result overflows uint64.
+func (a TokenAmount) Mul(factor uint64) (TokenAmount, error) {
+	hi, lo := bits.Mul64(a.Amount, factor)
+	if hi != 0 {
+		return TokenAmount{}, ErrTokenAmountOverflow
+	}
+
+	return NewTokenAmountFromLamports(lo, a.Decimals), nil
+}
+
+// Cmp compares the two token amounts and returns:
+//
+//	-1 if a < b
+//	 0 if a == b
+//	+1 if a > b
+//
+// Returns an error if the amounts have different decimals.
+func (a TokenAmount) Cmp(b TokenAmount) (int, error) {
+	if a.Decimals != b.Decimals {
+		return 0, ErrTokenAmountDecimalsMismatch
+	}
+
+	switch {
+	case a.Amount < b.Amount:
+		return -1, nil
+	case a.Amount > b.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// ParseTokenAmount converts the given human readable amount, e.g. "1.5", to a token amount
+// with the given decimals without float rounding.
+// Returns an error if the string is not a valid non-negative decimal number, has more
+// fractional digits than decimals, or the amount in lamports overflows uint64.
+func ParseTokenAmount(ui string, decimals uint8) (TokenAmount, error) {
+	ui = strings.TrimSpace(ui)
+	if ui == "" {
+		return TokenAmount{}, fmt.Errorf("%w: empty string", ErrInvalidTokenAmount)
+	}
+
+	intPart, fracPart, _ := strings.Cut(ui, ".")
+	if intPart == "" && fracPart == "" {
+		return TokenAmount{}, fmt.Errorf("%w: %s", ErrInvalidTokenAmount, ui)
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return TokenAmount{}, fmt.Errorf("%w: %s", ErrInvalidTokenAmount, ui)
+	}
+
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > int(decimals) {
+		return TokenAmount{}, fmt.Errorf("%w: %s has more than %d decimals", ErrInvalidTokenAmount, ui, decimals)
+	}
+	fracPart += strings.Repeat("0", int(decimals)-len(fracPart))
+
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if digits == "" {
+		return NewTokenAmountFromLamports(0, decimals), nil
+	}
+
+	lamports, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return TokenAmount{}, fmt.Errorf("%w: %s", ErrInvalidTokenAmount, ui)
+	}
+	if !lamports.IsUint64() {
+		return TokenAmount{}, ErrTokenAmountOverflow
+	}
+
+	return NewTokenAmountFromLamports(lamports.Uint64(), decimals), nil
+}
+
+// isDigits returns true if the given string contains only decimal digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
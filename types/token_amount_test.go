@@ -0,0 +1,110 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dmitrymomot/solana/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAmount_Add(t *testing.T) {
+	a := types.NewTokenAmountFromLamports(1500, 3)
+	b := types.NewTokenAmountFromLamports(500, 3)
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2000), sum.Amount)
+	assert.Equal(t, uint8(3), sum.Decimals)
+	assert.Equal(t, "2", sum.UIAmountString)
+
+	_, err = types.NewTokenAmountFromLamports(math.MaxUint64, 9).Add(types.NewTokenAmountFromLamports(1, 9))
+	assert.ErrorIs(t, err, types.ErrTokenAmountOverflow)
+
+	_, err = a.Add(types.NewTokenAmountFromLamports(500, 6))
+	assert.ErrorIs(t, err, types.ErrTokenAmountDecimalsMismatch)
+}
+
+func TestTokenAmount_Sub(t *testing.T) {
+	a := types.NewTokenAmountFromLamports(1500, 3)
+	b := types.NewTokenAmountFromLamports(500, 3)
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), diff.Amount)
+	assert.Equal(t, uint8(3), diff.Decimals)
+
+	_, err = b.Sub(a)
+	assert.ErrorIs(t, err, types.ErrTokenAmountUnderflow)
+
+	_, err = a.Sub(types.NewTokenAmountFromLamports(500, 0))
+	assert.ErrorIs(t, err, types.ErrTokenAmountDecimalsMismatch)
+}
+
+func TestTokenAmount_Mul(t *testing.T) {
+	a := types.NewTokenAmountFromLamports(1500, 3)
+
+	res, err := a.Mul(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4500), res.Amount)
+	assert.Equal(t, uint8(3), res.Decimals)
+
+	_, err = types.NewTokenAmountFromLamports(math.MaxUint64/2+1, 9).Mul(2)
+	assert.ErrorIs(t, err, types.ErrTokenAmountOverflow)
+}
+
+func TestTokenAmount_Cmp(t *testing.T) {
+	a := types.NewTokenAmountFromLamports(1500, 3)
+	b := types.NewTokenAmountFromLamports(500, 3)
+
+	res, err := a.Cmp(b)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res)
+
+	res, err = b.Cmp(a)
+	require.NoError(t, err)
+	assert.Equal(t, -1, res)
+
+	res, err = a.Cmp(a)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res)
+
+	_, err = a.Cmp(types.NewTokenAmountFromLamports(1500, 9))
+	assert.ErrorIs(t, err, types.ErrTokenAmountDecimalsMismatch)
+}
+
+func TestParseTokenAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		ui       string
+		decimals uint8
+		want     uint64
+		wantErr  error
+	}{
+		{name: "integer", ui: "10", decimals: 9, want: 10_000_000_000},
+		{name: "fraction", ui: "1.5", decimals: 9, want: 1_500_000_000},
+		{name: "max precision", ui: "0.000000001", decimals: 9, want: 1},
+		{name: "leading dot", ui: ".25", decimals: 2, want: 25},
+		{name: "trailing zeros", ui: "1.2300", decimals: 2, want: 123},
+		{name: "zero decimals", ui: "7", decimals: 0, want: 7},
+		{name: "zero", ui: "0.0", decimals: 6, want: 0},
+		{name: "too many decimals", ui: "1.001", decimals: 2, wantErr: types.ErrInvalidTokenAmount},
+		{name: "negative", ui: "-1", decimals: 9, wantErr: types.ErrInvalidTokenAmount},
+		{name: "not a number", ui: "abc", decimals: 9, wantErr: types.ErrInvalidTokenAmount},
+		{name: "empty", ui: "", decimals: 9, wantErr: types.ErrInvalidTokenAmount},
+		{name: "overflow", ui: "18446744074", decimals: 9, wantErr: types.ErrTokenAmountOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := types.ParseTokenAmount(tt.ui, tt.decimals)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Amount)
+			assert.Equal(t, tt.decimals, got.Decimals)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/solana/types"
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAccount_IsFrozen(t *testing.T) {
+	assert.True(t, types.TokenAccount{State: types.TokenAccountFrozen}.IsFrozen())
+	assert.False(t, types.TokenAccount{State: types.TokenAccountStateInitialized}.IsFrozen())
+	assert.False(t, types.TokenAccount{}.IsFrozen())
+}
+
+func TestTokenAccount_HasDelegate(t *testing.T) {
+	delegate := common.PublicKeyFromString("9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ")
+
+	assert.True(t, types.TokenAccount{Delegate: &delegate}.HasDelegate())
+	assert.False(t, types.TokenAccount{}.HasDelegate())
+}
+
+func TestTokenAccount_DelegatedEnough(t *testing.T) {
+	delegate := common.PublicKeyFromString("9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ")
+	acc := types.TokenAccount{
+		Delegate:         &delegate,
+		DelegatedBalance: utils.Pointer(types.NewTokenAmountFromLamports(500, 2)),
+	}
+
+	assert.True(t, acc.DelegatedEnough(0))
+	assert.True(t, acc.DelegatedEnough(499))
+	assert.True(t, acc.DelegatedEnough(500))
+	assert.False(t, acc.DelegatedEnough(501))
+
+	// no delegate
+	assert.False(t, types.TokenAccount{DelegatedBalance: acc.DelegatedBalance}.DelegatedEnough(1))
+	// delegate without the delegated amount
+	assert.False(t, types.TokenAccount{Delegate: &delegate}.DelegatedEnough(1))
+}
+
+func TestNewTokenAccount_Token2022(t *testing.T) {
+	data := []byte(`{
+		"pubkey": "DUNMHHh3qLwd7zVfckWHK7DoAk7jaeHiJgouVEQGraEe",
+		"account": {
+			"lamports": 2074080,
+			"owner": "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb",
+			"rentEpoch": 0,
+			"executable": false,
+			"data": {
+				"program": "spl-token-2022",
+				"space": 170,
+				"parsed": {
+					"type": "account",
+					"info": {
+						"isNative": false,
+						"mint": "7Ucm1SKXbwfPZfxGbvXBqkpAiUq4ttqkJmbGpQkfLeYV",
+						"owner": "9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ",
+						"state": "frozen",
+						"tokenAmount": {"amount": "1000", "decimals": 2, "uiAmount": 10, "uiAmountString": "10"},
+						"delegate": "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T",
+						"delegatedAmount": {"amount": "250", "decimals": 2, "uiAmountString": "2.5"},
+						"extensions": [{"extension": "immutableOwner"}]
+					}
+				}
+			}
+		}
+	}`)
+
+	acc, err := types.NewTokenAccount(data)
+	require.NoError(t, err)
+	assert.Equal(t, types.TokenAccountFrozen, acc.State)
+	assert.True(t, acc.IsFrozen())
+	assert.True(t, acc.HasDelegate())
+	assert.Equal(t, "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T", acc.Delegate.ToBase58())
+	require.NotNil(t, acc.DelegatedBalance)
+	assert.EqualValues(t, 250, acc.DelegatedBalance.Amount)
+	assert.True(t, acc.DelegatedEnough(250))
+	assert.False(t, acc.DelegatedEnough(251))
+	assert.EqualValues(t, 1000, acc.Balance.Amount)
+}
+
+func TestNewTokenAmountFromLamports_UIAmountString(t *testing.T) {
+	assert.Equal(t, "123456789.123456789", types.NewTokenAmountFromLamports(123456789123456789, 9).UIAmountString)
+	assert.Equal(t, "18446744073.709551615", types.NewTokenAmountFromLamports(18446744073709551615, 9).UIAmountString)
+	assert.Equal(t, "1.5", types.NewDefaultTokenAmount(1_500_000_000).UIAmountString)
+	assert.Equal(t, "0", types.NewDefaultTokenAmount(0).UIAmountString)
+}
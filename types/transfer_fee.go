@@ -0,0 +1,128 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// Token-2022 mint account layout
+const (
+	Token2022AccountTypeOffset         = 165 // the account type follows the base account, padded to the token account size
+	Token2022AccountTypeMint      byte = 1   // the account type of the mint
+	Token2022ExtensionTransferFee      = 1   // the extension type of the transfer fee config
+	TransferFeeConfigSize              = 108 // the size of the transfer fee config extension
+	MaxTransferFeeBasisPoints          = 10_000
+)
+
+type (
+	// TransferFee is the transfer fee of the Token-2022 mint effective since the epoch.
+	TransferFee struct {
+		Epoch       uint64 // the epoch since which the fee is effective
+		MaximumFee  uint64 // the maximum fee in token lamports
+		BasisPoints uint16 // the fee rate in basis points of the transfer amount, 1/100 of a percent
+	}
+
+	// TransferFeeConfig is the transfer fee extension of the Token-2022 mint.
+	TransferFeeConfig struct {
+		ConfigAuthority   *common.PublicKey // authority allowed to update the fee; nil if not set
+		WithdrawAuthority *common.PublicKey // authority allowed to withdraw the withheld fees; nil if not set
+		WithheldAmount    uint64            // fees withheld on the mint
+		OlderTransferFee  TransferFee       // the fee effective before NewerTransferFee.Epoch
+		NewerTransferFee  TransferFee       // the fee effective since its epoch
+	}
+)
+
+// Calculate returns the fee of transferring the given amount, rounded up and clamped to the maximum fee.
+func (f TransferFee) Calculate(amount uint64) uint64 {
+	if f.BasisPoints == 0 || amount == 0 {
+		return 0
+	}
+	basisPoints := uint64(f.BasisPoints)
+	if basisPoints > MaxTransferFeeBasisPoints {
+		basisPoints = MaxTransferFeeBasisPoints
+	}
+
+	// ceil(amount * basis points / 10000) without overflowing uint64
+	hi, lo := bits.Mul64(amount, basisPoints)
+	lo, carry := bits.Add64(lo, MaxTransferFeeBasisPoints-1, 0)
+	hi += carry
+	fee, _ := bits.Div64(hi, lo, MaxTransferFeeBasisPoints)
+
+	if fee > f.MaximumFee {
+		return f.MaximumFee
+	}
+	return fee
+}
+
+// TransferFee returns the transfer fee effective at the given epoch.
+func (c TransferFeeConfig) TransferFee(epoch uint64) TransferFee {
+	if epoch >= c.NewerTransferFee.Epoch {
+		return c.NewerTransferFee
+	}
+	return c.OlderTransferFee
+}
+
+// Fee returns the fee of transferring the given amount at the given epoch.
+func (c TransferFeeConfig) Fee(epoch, amount uint64) uint64 {
+	return c.TransferFee(epoch).Calculate(amount)
+}
+
+// ParseTransferFeeConfig parses the transfer fee extension from the Token-2022 mint account data.
+// Returns nil if the mint has no such extension, e.g. it's a classic SPL Token mint.
+func ParseTransferFeeConfig(mintData []byte) (*TransferFeeConfig, error) {
+	if len(mintData) <= Token2022AccountTypeOffset {
+		return nil, nil
+	}
+	if mintData[Token2022AccountTypeOffset] != Token2022AccountTypeMint {
+		return nil, fmt.Errorf("account is not a mint: account type %d", mintData[Token2022AccountTypeOffset])
+	}
+
+	// the extensions are encoded as type (u16), length (u16), value entries
+	for offset := Token2022AccountTypeOffset + 1; offset+4 <= len(mintData); {
+		extType := binary.LittleEndian.Uint16(mintData[offset:])
+		extLen := int(binary.LittleEndian.Uint16(mintData[offset+2:]))
+		offset += 4
+		if offset+extLen > len(mintData) {
+			return nil, fmt.Errorf("extension %d is out of the account data", extType)
+		}
+
+		if extType == Token2022ExtensionTransferFee {
+			if extLen != TransferFeeConfigSize {
+				return nil, fmt.Errorf("invalid transfer fee config size: %d", extLen)
+			}
+			return parseTransferFeeConfig(mintData[offset : offset+extLen]), nil
+		}
+		offset += extLen
+	}
+
+	return nil, nil
+}
+
+// parseTransferFeeConfig decodes the transfer fee config extension value.
+func parseTransferFeeConfig(data []byte) *TransferFeeConfig {
+	optionalPubkey := func(b []byte) *common.PublicKey {
+		key := common.PublicKeyFromBytes(b)
+		if key == (common.PublicKey{}) {
+			return nil
+		}
+		return &key
+	}
+	transferFee := func(b []byte) TransferFee {
+		return TransferFee{
+			Epoch:       binary.LittleEndian.Uint64(b[0:8]),
+			MaximumFee:  binary.LittleEndian.Uint64(b[8:16]),
+			BasisPoints: binary.LittleEndian.Uint16(b[16:18]),
+		}
+	}
+
+	return &TransferFeeConfig{
+		ConfigAuthority:   optionalPubkey(data[0:32]),
+		WithdrawAuthority: optionalPubkey(data[32:64]),
+		WithheldAmount:    binary.LittleEndian.Uint64(data[64:72]),
+		OlderTransferFee:  transferFee(data[72:90]),
+		NewerTransferFee:  transferFee(data[90:108]),
+	}
+}
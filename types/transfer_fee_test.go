@@ -0,0 +1,106 @@
+package types_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/dmitrymomot/solana/types"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transferFeeMintData encodes a Token-2022 mint with the transfer fee config extension.
+func transferFeeMintData(authority common.PublicKey, older, newer types.TransferFee) []byte {
+	data := make([]byte, types.Token2022AccountTypeOffset+1+4+types.TransferFeeConfigSize)
+	data[types.Token2022AccountTypeOffset] = types.Token2022AccountTypeMint
+
+	ext := data[types.Token2022AccountTypeOffset+1:]
+	binary.LittleEndian.PutUint16(ext[0:2], types.Token2022ExtensionTransferFee)
+	binary.LittleEndian.PutUint16(ext[2:4], types.TransferFeeConfigSize)
+
+	value := ext[4:]
+	copy(value[0:32], authority.Bytes())
+	binary.LittleEndian.PutUint64(value[64:72], 7)
+	for i, fee := range []types.TransferFee{older, newer} {
+		b := value[72+i*18:]
+		binary.LittleEndian.PutUint64(b[0:8], fee.Epoch)
+		binary.LittleEndian.PutUint64(b[8:16], fee.MaximumFee)
+		binary.LittleEndian.PutUint16(b[16:18], fee.BasisPoints)
+	}
+
+	return data
+}
+
+func TestTransferFee_Calculate(t *testing.T) {
+	tests := []struct {
+		name   string
+		fee    types.TransferFee
+		amount uint64
+		want   uint64
+	}{
+		{"no fee", types.TransferFee{MaximumFee: 100}, 1000, 0},
+		{"zero amount", types.TransferFee{BasisPoints: 50, MaximumFee: 100}, 0, 0},
+		{"exact", types.TransferFee{BasisPoints: 50, MaximumFee: 100}, 10_000, 50},
+		{"rounded up", types.TransferFee{BasisPoints: 50, MaximumFee: 100}, 10_001, 51},
+		{"clamped", types.TransferFee{BasisPoints: 50, MaximumFee: 100}, 1_000_000, 100},
+		{"large amount", types.TransferFee{BasisPoints: 10_000, MaximumFee: math.MaxUint64}, math.MaxUint64, math.MaxUint64},
+		{"invalid basis points", types.TransferFee{BasisPoints: math.MaxUint16, MaximumFee: math.MaxUint64}, 1000, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fee.Calculate(tt.amount))
+		})
+	}
+}
+
+func TestParseTransferFeeConfig(t *testing.T) {
+	authority := common.PublicKeyFromString("9QzsJf7LPLj8GkXbYT3LFDKqsj2hHG7TA3xinJHu8epQ")
+	older := types.TransferFee{Epoch: 10, MaximumFee: 1000, BasisPoints: 100}
+	newer := types.TransferFee{Epoch: 20, MaximumFee: 500, BasisPoints: 200}
+
+	t.Run("transfer fee mint", func(t *testing.T) {
+		config, err := types.ParseTransferFeeConfig(transferFeeMintData(authority, older, newer))
+		require.NoError(t, err)
+		require.NotNil(t, config)
+
+		assert.Equal(t, &authority, config.ConfigAuthority)
+		assert.Nil(t, config.WithdrawAuthority)
+		assert.Equal(t, uint64(7), config.WithheldAmount)
+		assert.Equal(t, older, config.OlderTransferFee)
+		assert.Equal(t, newer, config.NewerTransferFee)
+
+		assert.Equal(t, older, config.TransferFee(19))
+		assert.Equal(t, newer, config.TransferFee(20))
+		assert.Equal(t, uint64(100), config.Fee(19, 10_000))
+		assert.Equal(t, uint64(200), config.Fee(20, 10_000))
+	})
+
+	t.Run("classic mint", func(t *testing.T) {
+		config, err := types.ParseTransferFeeConfig(make([]byte, 82))
+		require.NoError(t, err)
+		assert.Nil(t, config)
+	})
+
+	t.Run("no transfer fee extension", func(t *testing.T) {
+		data := make([]byte, types.Token2022AccountTypeOffset+1)
+		data[types.Token2022AccountTypeOffset] = types.Token2022AccountTypeMint
+		config, err := types.ParseTransferFeeConfig(data)
+		require.NoError(t, err)
+		assert.Nil(t, config)
+	})
+
+	t.Run("truncated extension", func(t *testing.T) {
+		data := transferFeeMintData(authority, older, newer)
+		_, err := types.ParseTransferFeeConfig(data[:len(data)-1])
+		require.Error(t, err)
+	})
+
+	t.Run("not a mint", func(t *testing.T) {
+		data := transferFeeMintData(authority, older, newer)
+		data[types.Token2022AccountTypeOffset] = 2
+		_, err := types.ParseTransferFeeConfig(data)
+		require.Error(t, err)
+	})
+}
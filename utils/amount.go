@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 )
 
@@ -41,3 +42,57 @@ func Float64ToString(amount float64) string {
 
 	return s
 }
+
+// LamportsToDecimalString converts amount lamports to the exact decimal string with given decimals
+// using integer math, so big amounts aren't rounded as with AmountToString.
+// Trailing zeros are trimmed, e.g. 1500000000 with decimals 9 is converted to "1.5".
+func LamportsToDecimalString(lamports uint64, decimals uint8) string {
+	return FormatTokenAmount(lamports, decimals, FormatOptions{TrimTrailingZeros: true})
+}
+
+// FormatOptions configures FormatTokenAmount.
+type FormatOptions struct {
+	ThousandsSeparator string // optional; separator between the groups of three integer digits, e.g. "," or " "; no grouping if empty
+	DecimalSeparator   string // optional; separator of the fractional part; default is "."
+	TrimTrailingZeros  bool   // optional; trims the trailing zeros of the fractional part, e.g. "1.50" -> "1.5", "1.00" -> "1"
+	Symbol             string // optional; symbol appended after a space, e.g. "SOL"
+}
+
+// FormatTokenAmount formats amount lamports with given decimals for display without float rounding.
+// For example, 1000000500000 with decimals 6 and "," thousands separator is formatted as "1,000,000.500000",
+// or as "1,000,000.5" with TrimTrailingZeros.
+func FormatTokenAmount(amount uint64, decimals uint8, opts FormatOptions) string {
+	digits := strconv.FormatUint(amount, 10)
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-int(decimals)], digits[len(digits)-int(decimals):]
+
+	if opts.TrimTrailingZeros {
+		fracPart = TrimRightZeros(fracPart)
+	}
+
+	var sb strings.Builder
+	for i, d := range intPart {
+		if i > 0 && opts.ThousandsSeparator != "" && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(opts.ThousandsSeparator)
+		}
+		sb.WriteRune(d)
+	}
+
+	if fracPart != "" {
+		if opts.DecimalSeparator == "" {
+			sb.WriteString(".")
+		} else {
+			sb.WriteString(opts.DecimalSeparator)
+		}
+		sb.WriteString(fracPart)
+	}
+
+	if opts.Symbol != "" {
+		sb.WriteString(" ")
+		sb.WriteString(opts.Symbol)
+	}
+
+	return sb.String()
+}
@@ -161,3 +161,143 @@ func TestAmountToString(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatTokenAmount(t *testing.T) {
+	type args struct {
+		amount   uint64
+		decimals uint8
+		opts     utils.FormatOptions
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "zero with decimals 0",
+			args: args{amount: 0, decimals: 0},
+			want: "0",
+		},
+		{
+			name: "zero with decimals 9",
+			args: args{amount: 0, decimals: 9},
+			want: "0.000000000",
+		},
+		{
+			name: "zero with decimals 9 trimmed",
+			args: args{amount: 0, decimals: 9, opts: utils.FormatOptions{TrimTrailingZeros: true, Symbol: "SOL"}},
+			want: "0 SOL",
+		},
+		{
+			name: "grouped with trailing zeros",
+			args: args{amount: 1000000500000, decimals: 6, opts: utils.FormatOptions{ThousandsSeparator: ","}},
+			want: "1,000,000.500000",
+		},
+		{
+			name: "grouped and trimmed",
+			args: args{amount: 1000000500000, decimals: 6, opts: utils.FormatOptions{ThousandsSeparator: ",", TrimTrailingZeros: true}},
+			want: "1,000,000.5",
+		},
+		{
+			name: "european locale with symbol",
+			args: args{amount: 1234567890, decimals: 2, opts: utils.FormatOptions{
+				ThousandsSeparator: ".",
+				DecimalSeparator:   ",",
+				Symbol:             "USDC",
+			}},
+			want: "12.345.678,90 USDC",
+		},
+		{
+			name: "less than one",
+			args: args{amount: 5, decimals: 9, opts: utils.FormatOptions{ThousandsSeparator: ","}},
+			want: "0.000000005",
+		},
+		{
+			name: "no grouping below thousand",
+			args: args{amount: 999, decimals: 0, opts: utils.FormatOptions{ThousandsSeparator: ","}},
+			want: "999",
+		},
+		{
+			name: "very large amount",
+			args: args{amount: 18446744073709551615, decimals: 0, opts: utils.FormatOptions{ThousandsSeparator: ","}},
+			want: "18,446,744,073,709,551,615",
+		},
+		{
+			name: "very large amount with decimals 9",
+			args: args{amount: 18446744073709551615, decimals: 9, opts: utils.FormatOptions{ThousandsSeparator: " ", Symbol: "SOL"}},
+			want: "18 446 744 073.709551615 SOL",
+		},
+		{
+			name: "high decimals",
+			args: args{amount: 18446744073709551615, decimals: 25, opts: utils.FormatOptions{ThousandsSeparator: ","}},
+			want: "0.0000018446744073709551615",
+		},
+		{
+			name: "high decimals trimmed",
+			args: args{amount: 1000, decimals: 18, opts: utils.FormatOptions{TrimTrailingZeros: true}},
+			want: "0.000000000000001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.FormatTokenAmount(tt.args.amount, tt.args.decimals, tt.args.opts); got != tt.want {
+				t.Errorf("FormatTokenAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLamportsToDecimalString(t *testing.T) {
+	type args struct {
+		amount   uint64
+		decimals uint8
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "zero",
+			args: args{amount: 0, decimals: 9},
+			want: "0",
+		},
+		{
+			name: "1.5 with decimals 9",
+			args: args{amount: 1500000000, decimals: 9},
+			want: "1.5",
+		},
+		{
+			name: "1 lamport",
+			args: args{amount: 1, decimals: 9},
+			want: "0.000000001",
+		},
+		{
+			name: "max uint64 with decimals 9",
+			args: args{amount: 18446744073709551615, decimals: 9},
+			want: "18446744073.709551615",
+		},
+		{
+			name: "max uint64 with decimals 0",
+			args: args{amount: 18446744073709551615, decimals: 0},
+			want: "18446744073709551615",
+		},
+		{
+			name: "big balance with decimals 9",
+			args: args{amount: 123456789123456789, decimals: 9},
+			want: "123456789.123456789",
+		},
+		{
+			name: "big balance with decimals 6",
+			args: args{amount: 9007199254740993, decimals: 6},
+			want: "9007199254.740993",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.LamportsToDecimalString(tt.args.amount, tt.args.decimals); got != tt.want {
+				t.Errorf("LamportsToDecimalString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
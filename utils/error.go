@@ -9,6 +9,7 @@ import (
 )
 
 // StackErrors wraps multiple errors into a single error.
+// The result matches any of the errors, and the errors they wrap, with errors.Is and errors.As.
 func StackErrors(errs ...error) error {
 	return NewStakedError(errs...)
 }
@@ -54,16 +55,14 @@ func (e *StakedError) Error() string {
 
 // Unwrap returns the underlying error.
 // Implements the errors.Unwrap interface.
+// Returns nil if there are multiple errors, since Is and As check all of them;
+// returning the error itself would make errors.Is loop forever on a mismatch.
 func (e *StakedError) Unwrap() error {
-	if len(e.errors) == 0 {
-		return nil
-	}
-
 	if len(e.errors) == 1 {
 		return e.errors[0]
 	}
 
-	return e
+	return nil
 }
 
 // Is returns true if the error is equal to target.
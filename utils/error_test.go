@@ -0,0 +1,50 @@
+package utils_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dmitrymomot/solana/utils"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStackErrors(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	errOther := errors.New("other")
+
+	err := utils.StackErrors(errFirst, errSecond)
+	assert.EqualError(t, err, "first: second")
+	assert.ErrorIs(t, err, errFirst)
+	assert.ErrorIs(t, err, errSecond)
+	assert.False(t, errors.Is(err, errOther))
+
+	var target *utils.StakedError
+	assert.True(t, errors.As(utils.StackErrors(errOther, err), &target))
+
+	assert.ErrorIs(t, utils.StackErrors(errFirst), errFirst)
+	assert.False(t, errors.Is(utils.StackErrors(), errFirst))
+}
+
+func TestStackErrors_Deep(t *testing.T) {
+	errRoot := errors.New("root")
+	errOther := errors.New("other")
+	rpcErr := &rpc.JsonRpcError{Code: -32002, Message: "node error"}
+
+	err := utils.StackErrors(
+		errors.New("top"),
+		fmt.Errorf("wrapped: %w", utils.StackErrors(
+			errors.New("middle"),
+			utils.StackErrors(fmt.Errorf("rpc: %w", rpcErr), errRoot),
+		)),
+	)
+	assert.ErrorIs(t, err, errRoot)
+	assert.NotErrorIs(t, err, errOther)
+
+	var target *rpc.JsonRpcError
+	require.ErrorAs(t, err, &target)
+	assert.Equal(t, -32002, target.Code)
+}